@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+// NewLintCommand returns the `isvcctl lint` subcommand: it runs the same
+// validateInferenceService checks the admission webhook would, but purely
+// offline, so a GitOps pipeline can reject a bad manifest in CI before it
+// ever reaches a cluster. It intentionally skips the checks that need live
+// cluster state (v1beta1.BuildDryRunReportOnline's
+// InferenceGraph-reference/deploymentMode-transition checks); use the
+// webhook server's dry-run HTTP endpoint for those.
+func NewLintCommand() *cobra.Command {
+	var filename string
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Validate an InferenceService manifest offline and print a JSON report",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			in, err := openInput(filename)
+			if err != nil {
+				return err
+			}
+			defer in.Close()
+
+			isvc := &v1beta1.InferenceService{}
+			data, err := io.ReadAll(in)
+			if err != nil {
+				return fmt.Errorf("failed to read InferenceService manifest: %w", err)
+			}
+			if err := yaml.Unmarshal(data, isvc); err != nil {
+				return fmt.Errorf("failed to parse InferenceService manifest: %w", err)
+			}
+
+			report := v1beta1.BuildDryRunReport(isvc)
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(report); err != nil {
+				return fmt.Errorf("failed to encode dry-run report: %w", err)
+			}
+			if !report.Valid {
+				return fmt.Errorf("manifest failed validation: %d error(s)", len(report.Errors))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&filename, "file", "f", "-", "path to the InferenceService manifest, or - for stdin")
+	return cmd
+}
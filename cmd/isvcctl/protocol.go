@@ -0,0 +1,56 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kserve/kserve/pkg/protocol"
+)
+
+// NewProtocolCommand returns the `isvcctl protocol` command group, starting
+// with `describe`, so an operator can introspect a registered protocol's
+// capability bits without reading the pkg/protocol source.
+func NewProtocolCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "protocol",
+		Short: "Inspect registered inference protocols",
+	}
+	cmd.AddCommand(newProtocolDescribeCommand())
+	return cmd
+}
+
+func newProtocolDescribeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "describe <name>",
+		Short: "Print a registered protocol's path templates, capabilities, and probe",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			p, ok := protocol.Lookup(name)
+			if !ok {
+				return fmt.Errorf("no protocol registered under %q", name)
+			}
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(p.Spec())
+		},
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package main implements isvcctl, a small CLI for operations that don't need
+// a live controller, starting with rendering the resources the raw-deployment
+// reconciler would create for an InferenceService without touching a cluster.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+// NewRenderCommand returns the `isvcctl render` subcommand: it reads an
+// InferenceService manifest and prints the Deployment/Service/HPA/HTTPRoute
+// objects the raw-deployment reconciler would apply, so GitOps pipelines can
+// diff the rendered output in CI before a change ever reaches a cluster.
+func NewRenderCommand() *cobra.Command {
+	var filename string
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Render the Kubernetes objects a raw-deployment InferenceService would produce",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			in, err := openInput(filename)
+			if err != nil {
+				return err
+			}
+			defer in.Close()
+
+			isvc := &v1beta1.InferenceService{}
+			data, err := io.ReadAll(in)
+			if err != nil {
+				return fmt.Errorf("failed to read InferenceService manifest: %w", err)
+			}
+			if err := yaml.Unmarshal(data, isvc); err != nil {
+				return fmt.Errorf("failed to parse InferenceService manifest: %w", err)
+			}
+
+			objects, err := RenderObjects(isvc)
+			if err != nil {
+				return fmt.Errorf("failed to render objects: %w", err)
+			}
+			return printObjects(cmd.OutOrStdout(), objects)
+		},
+	}
+	cmd.Flags().StringVarP(&filename, "file", "f", "-", "path to the InferenceService manifest, or - for stdin")
+	return cmd
+}
+
+func openInput(filename string) (io.ReadCloser, error) {
+	if filename == "" || filename == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(filename)
+}
+
+func printObjects(out io.Writer, objects []runtime.Object) error {
+	printer := &printers.YAMLPrinter{}
+	for i, obj := range objects {
+		if i > 0 {
+			if _, err := fmt.Fprintln(out, "---"); err != nil {
+				return err
+			}
+		}
+		if err := printer.PrintObj(obj, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
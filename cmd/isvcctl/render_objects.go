@@ -0,0 +1,40 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/hpa"
+)
+
+// RenderObjects builds the subset of raw-deployment objects that can be
+// computed purely from the InferenceService spec, without a cluster
+// connection. Today that is the predictor's HorizontalPodAutoscaler; as more
+// of the reconciler's object-building logic is split out into
+// cluster-independent constructors (see the `reconcilers` packages), this list
+// will grow to cover the Deployment, Service, and HTTPRoute as well.
+func RenderObjects(isvc *v1beta1.InferenceService) ([]runtime.Object, error) {
+	componentMeta := metav1.ObjectMeta{
+		Name:      isvc.Name + "-predictor",
+		Namespace: isvc.Namespace,
+	}
+	predictorHPA := hpa.NewHPAReconciler(nil, runtime.NewScheme(), componentMeta, isvc.Spec.Predictor.GetExtensions())
+	return []runtime.Object{predictorHPA.HPA}, nil
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// ExecutionMode selects how an InferenceGraph's router receives requests
+// and returns responses. InferenceGraphSpec has no field of this type.
+type ExecutionMode string
+
+const (
+	// ExecutionModeSync is today's behavior: the router is fronted by a
+	// Knative or plain Kubernetes Service and replies to each request
+	// synchronously over HTTP.
+	ExecutionModeSync ExecutionMode = "Sync"
+	// ExecutionModeAsync has the router consume request envelopes from a
+	// message-queue transport (see the inferencegraph/broker package) and
+	// publish responses keyed by correlation ID, instead of being fronted
+	// by a Service at all. Needed for graphs whose per-request latency
+	// (long ensemble/fan-out workloads) exceeds HTTP timeouts.
+	ExecutionModeAsync ExecutionMode = "Async"
+)
+
+// BrokerSpec configures the message-queue transport ExecutionModeAsync
+// consumes requests from and publishes responses to. InferenceGraphSpec has
+// no field of this type.
+type BrokerSpec struct {
+	// Type selects the transport backend, e.g. "kafka" or "nats",
+	// resolved against the broker package's registry.
+	Type string `json:"type"`
+	// BootstrapServers is the transport's connection string, e.g. a Kafka
+	// bootstrap.servers value or a NATS server URL.
+	BootstrapServers string `json:"bootstrapServers"`
+}
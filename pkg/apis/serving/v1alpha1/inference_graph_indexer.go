@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// InferenceGraphStepServiceNameIndexKey is the field index registered by
+// RegisterInferenceGraphStepServiceNameIndex. Querying it with
+// client.MatchingFields replaces an O(N·M) full-namespace List + manual scan
+// of every node's Steps with a single indexed lookup, so checking whether an
+// InferenceService is still referenced by any InferenceGraph stays cheap in
+// clusters with thousands of graphs.
+const InferenceGraphStepServiceNameIndexKey = "serving.kserve.io/inferencegraph-step-servicename"
+
+// RegisterInferenceGraphStepServiceNameIndex registers the
+// InferenceGraphStepServiceNameIndexKey field index on mgr's cache. Call this
+// once at manager startup, before any controller that queries the index
+// starts running. If the manager's cache.Options.ByObject scopes the
+// InferenceGraph cache with a label/field selector (so it doesn't hold
+// graphs unrelated to that selector), the index is only populated for the
+// subset of InferenceGraphs the cache actually watches.
+
+func RegisterInferenceGraphStepServiceNameIndex(mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(context.Background(), &InferenceGraph{}, InferenceGraphStepServiceNameIndexKey,
+		func(obj client.Object) []string {
+			ig, ok := obj.(*InferenceGraph)
+			if !ok {
+				return nil
+			}
+			return inferenceGraphStepServiceNames(ig)
+		})
+}
+
+// inferenceGraphStepServiceNames returns the distinct Step.ServiceName
+// values referenced across every node of ig, the set the index stores ig
+// under.
+func inferenceGraphStepServiceNames(ig *InferenceGraph) []string {
+	seen := map[string]struct{}{}
+	var names []string
+	for _, node := range ig.Spec.Nodes {
+		for _, step := range node.Steps {
+			if step.ServiceName == "" {
+				continue
+			}
+			if _, ok := seen[step.ServiceName]; ok {
+				continue
+			}
+			seen[step.ServiceName] = struct{}{}
+			names = append(names, step.ServiceName)
+		}
+	}
+	return names
+}
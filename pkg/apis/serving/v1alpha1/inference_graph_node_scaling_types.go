@@ -0,0 +1,52 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// NodeScalingSpec overrides the graph-wide autoscaling defaults for a single
+// named node of InferenceGraphSpec.Nodes, so an expensive node (e.g. an
+// ensemble step) can scale to zero independently of the cheap routing hop
+// in front of it, and each node can pin its own
+// autoscaling.knative.dev/initial-scale instead of inheriting the root
+// router's. No field of InferenceGraphSpec or InferenceRouter refers to this
+// type.
+type NodeScalingSpec struct {
+	// MinReplicas is the minimum number of replicas for this node's
+	// generated Service/Deployment. Defaults to the graph-wide MinReplicas
+	// when unset.
+	// +optional
+	MinReplicas *int `json:"minReplicas,omitempty"`
+	// MaxReplicas is the maximum number of replicas for this node.
+	// Defaults to the graph-wide MaxReplicas when unset.
+	// +optional
+	MaxReplicas int `json:"maxReplicas,omitempty"`
+	// InitialScale sets this node's autoscaling.knative.dev/initial-scale
+	// annotation, letting e.g. a GPU-backed ensemble node start at 0 while a
+	// lightweight routing node still starts warm.
+	// +optional
+	InitialScale *int `json:"initialScale,omitempty"`
+	// Target is the per-replica concurrency/RPS target this node scales on.
+	// +optional
+	Target *int `json:"target,omitempty"`
+	// Metric selects which of Knative's autoscaling metrics Target is
+	// measured in; defaults to constants.AutoScalerKPAMetricsConcurrency.
+	// +optional
+	Metric constants.AutoScalerKPAMetricsType `json:"metric,omitempty"`
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "sort"
+
+// ResolveInferenceRoutePolicyConflicts orders candidate policies attached to the
+// same InferenceService by creationTimestamp so that the oldest policy wins; it
+// returns the winner and the losers in that order so callers can mark the losers
+// with a "Conflicted" status condition.
+func ResolveInferenceRoutePolicyConflicts(candidates []InferenceRoutePolicy) (winner *InferenceRoutePolicy, losers []InferenceRoutePolicy) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	ordered := make([]InferenceRoutePolicy, len(candidates))
+	copy(ordered, candidates)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		ti, tj := ordered[i].CreationTimestamp, ordered[j].CreationTimestamp
+		if ti.Equal(&tj) {
+			return ordered[i].Name < ordered[j].Name
+		}
+		return ti.Before(&tj)
+	})
+
+	return &ordered[0], ordered[1:]
+}
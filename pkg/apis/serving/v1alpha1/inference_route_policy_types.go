@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// InferenceRoutePolicyTargetReference identifies the InferenceService(s) an
+// InferenceRoutePolicy attaches to, modeled on the Gateway API policy-attachment
+// pattern (e.g. Kuadrant's Kuadrant/AuthPolicy targetRef): either a single named
+// target, or (when Name is empty) every InferenceService matched by Selector in
+// the policy's namespace.
+type InferenceRoutePolicyTargetReference struct {
+	// Name of the target InferenceService. Mutually exclusive with Selector.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Selector fleet-wide attaches this policy to every InferenceService matching
+	// the label selector in the policy's namespace. Mutually exclusive with Name.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// RetryPolicy configures HTTPRoute-level retries for the backend request.
+type RetryPolicy struct {
+	// Codes is the list of upstream response status codes that should be retried.
+	// +optional
+	Codes []int32 `json:"codes,omitempty"`
+	// Attempts is the maximum number of retry attempts.
+	Attempts int32 `json:"attempts"`
+	// Backoff is the base backoff duration between retry attempts, e.g. "25ms".
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+}
+
+// TimeoutPolicy configures HTTPRoute request/backend timeouts.
+type TimeoutPolicy struct {
+	// Request bounds the end-to-end time allowed for the request, including retries.
+	// +optional
+	Request *metav1.Duration `json:"request,omitempty"`
+	// BackendRequest bounds a single attempt to the backend.
+	// +optional
+	BackendRequest *metav1.Duration `json:"backendRequest,omitempty"`
+}
+
+// HeaderPolicy configures request/response header mutation filters.
+type HeaderPolicy struct {
+	// +optional
+	RequestAdd map[string]string `json:"requestAdd,omitempty"`
+	// +optional
+	RequestSet map[string]string `json:"requestSet,omitempty"`
+	// +optional
+	RequestRemove []string `json:"requestRemove,omitempty"`
+	// +optional
+	ResponseAdd map[string]string `json:"responseAdd,omitempty"`
+	// +optional
+	ResponseSet map[string]string `json:"responseSet,omitempty"`
+	// +optional
+	ResponseRemove []string `json:"responseRemove,omitempty"`
+}
+
+// CORSPolicy configures the Cross-Origin Resource Sharing filter on the route.
+type CORSPolicy struct {
+	// +optional
+	AllowOrigins []string `json:"allowOrigins,omitempty"`
+	// +optional
+	AllowMethods []string `json:"allowMethods,omitempty"`
+	// +optional
+	AllowHeaders []string `json:"allowHeaders,omitempty"`
+}
+
+// InferenceRoutePolicySpec configures routing behavior that is cross-cutting
+// enough (retries, timeouts, header rewrites, CORS) that users want to tune it
+// without editing every InferenceService's generated HTTPRoute.
+type InferenceRoutePolicySpec struct {
+	// TargetRef identifies the InferenceService(s) this policy applies to.
+	TargetRef InferenceRoutePolicyTargetReference `json:"targetRef"`
+	// +optional
+	Retry *RetryPolicy `json:"retry,omitempty"`
+	// +optional
+	Timeouts *TimeoutPolicy `json:"timeouts,omitempty"`
+	// +optional
+	Headers *HeaderPolicy `json:"headers,omitempty"`
+	// +optional
+	CORS *CORSPolicy `json:"cors,omitempty"`
+}
+
+// InferenceRoutePolicyStatus reports whether the policy's target was found and
+// its filters were merged into the generated HTTPRoute.
+type InferenceRoutePolicyStatus struct {
+	duckv1.Status `json:",inline"`
+}
+
+// InferenceRoutePolicy conditions.
+const (
+	InferenceRoutePolicyConditionAccepted apis.ConditionType = "Accepted"
+	InferenceRoutePolicyConditionEnforced apis.ConditionType = "Enforced"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=inferenceroutepolicies,shortName=irp
+// InferenceRoutePolicy is a Gateway-API-style policy-attachment resource that
+// tunes retry, timeout, header, and CORS behavior for the HTTPRoute(s) generated
+// for one or more InferenceServices.
+type InferenceRoutePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   InferenceRoutePolicySpec   `json:"spec,omitempty"`
+	Status InferenceRoutePolicyStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// InferenceRoutePolicyList contains a list of InferenceRoutePolicy.
+type InferenceRoutePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InferenceRoutePolicy `json:"items"`
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InferenceRouteRetryPolicySpec mirrors the shape of the RouteRetryFilter /
+// RouteTimeoutFilter extension filters found elsewhere in the Gateway API
+// ecosystem, so that when a cluster's Gateway implementation does not yet
+// support the native `HTTPRouteRule.Retry` field, the reconciler can still
+// express retry intent via an `ExtensionRef` HTTPRouteFilter pointing at this
+// CRD.
+type InferenceRouteRetryPolicySpec struct {
+	// NumRetries is the maximum number of retry attempts.
+	NumRetries int32 `json:"numRetries"`
+	// RetryOn lists the conditions that trigger a retry, e.g. "5xx",
+	// "gateway-error", "reset", "connect-failure".
+	// +optional
+	RetryOn []string `json:"retryOn,omitempty"`
+	// PerTryTimeout bounds a single retry attempt.
+	// +optional
+	PerTryTimeout *metav1.Duration `json:"perTryTimeout,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=inferencerouteretrypolicies,shortName=irrp
+// InferenceRouteRetryPolicy is referenced from a generated HTTPRoute's
+// `ExtensionRef` filter when the target Gateway API implementation does not
+// support the upstream `HTTPRouteRule.Retry` field natively.
+type InferenceRouteRetryPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec InferenceRouteRetryPolicySpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// InferenceRouteRetryPolicyList contains a list of InferenceRouteRetryPolicy.
+type InferenceRouteRetryPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InferenceRouteRetryPolicy `json:"items"`
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+// AutoScalingPreset names a built-in HPAScalingRules profile, so users
+// don't have to hand-write a HorizontalPodAutoscalerBehavior for common
+// workload shapes.
+type AutoScalingPreset string
+
+const (
+	// AutoScalingPresetLLMBurst scales up aggressively (for bursty LLM
+	// arrivals) and scales down slowly, to avoid thrashing through a long
+	// model warmup.
+	AutoScalingPresetLLMBurst AutoScalingPreset = "llm-burst"
+	// AutoScalingPresetBatchSteady scales up and down gradually, for
+	// predictable batch workloads that don't need to react within seconds.
+	AutoScalingPresetBatchSteady AutoScalingPreset = "batch-steady"
+	// AutoScalingPresetExplainerCheap scales down aggressively, since
+	// explainer pods are typically cheap to recreate and shouldn't be kept
+	// running once load drops.
+	AutoScalingPresetExplainerCheap AutoScalingPreset = "explainer-cheap"
+	// AutoScalingPresetAggressive scales up and down quickly with minimal
+	// stabilization, for latency-sensitive workloads that would rather
+	// over-provision briefly than queue requests.
+	AutoScalingPresetAggressive AutoScalingPreset = "aggressive"
+	// AutoScalingPresetBalanced is a moderate middle ground between
+	// AutoScalingPresetAggressive and AutoScalingPresetConservative, suitable
+	// as a default when a workload's traffic shape isn't yet known.
+	AutoScalingPresetBalanced AutoScalingPreset = "balanced"
+	// AutoScalingPresetConservative scales up cautiously and scales down
+	// slowly, for workloads where replica churn is more costly than
+	// momentary over-provisioning.
+	AutoScalingPresetConservative AutoScalingPreset = "conservative"
+)
+
+// AutoScalingPresetAnnotationKey lets a cluster operator select a preset via
+// the ingress/autoscaler ConfigMap's default component annotations, without
+// requiring every InferenceService to set Preset explicitly.
+const AutoScalingPresetAnnotationKey = "serving.kserve.io/autoscaling-preset"
+
+// AutoScalingBehaviorSpec configures a component's HorizontalPodAutoscaler
+// scaling behavior, either via a named Preset or an explicit Behavior block
+// that maps directly onto autoscalingv2.HorizontalPodAutoscalerBehavior. It
+// is intended to be embedded on ComponentExtensionSpec.
+type AutoScalingBehaviorSpec struct {
+	// Preset selects a built-in scaling profile. Mutually exclusive with
+	// Behavior; when both are set, Behavior wins.
+	// +optional
+	Preset AutoScalingPreset `json:"preset,omitempty"`
+	// Behavior is passed through verbatim to
+	// HorizontalPodAutoscalerSpec.Behavior.
+	// +optional
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// PodDisruptionBudgetSpec is the subset of policyv1.PodDisruptionBudgetSpec a
+// component can configure, intended to be embedded as
+// ComponentExtensionSpec.DisruptionBudget. Exactly one of MinAvailable or
+// MaxUnavailable should be set, mirroring the upstream PDB's own mutual
+// exclusivity.
+type PodDisruptionBudgetSpec struct {
+	// MinAvailable is either an absolute number or a percentage of replicas
+	// that must remain available during a voluntary disruption.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// MaxUnavailable is either an absolute number or a percentage of replicas
+	// that may be unavailable during a voluntary disruption.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// DryRunIssue is one field.ErrorList entry or admission.Warnings entry
+// rendered for DryRunReport's JSON body, so a CI job or `kubectl kserve lint`
+// plugin can print (or assert on) a specific field path/rule without
+// parsing a Go error string.
+type DryRunIssue struct {
+	Field  string `json:"field,omitempty"`
+	Detail string `json:"detail"`
+}
+
+// DryRunReport is the structured result of running the full
+// validateInferenceService pipeline against a manifest, returned by both
+// BuildDryRunReport (offline mode) and DryRunHandler (live-cluster mode).
+type DryRunReport struct {
+	Valid    bool          `json:"valid"`
+	Errors   []DryRunIssue `json:"errors,omitempty"`
+	Warnings []DryRunIssue `json:"warnings,omitempty"`
+}
+
+// BuildDryRunReport runs validateInferenceServiceFields against isvc without
+// touching a cluster, so it's safe to call from an offline CLI/CI step that
+// only has the manifest on disk. It does not run
+// validateInferenceServiceReferences or validateDeploymentMode, both of
+// which compare against live cluster/status state a purely offline caller
+// doesn't have.
+func BuildDryRunReport(isvc *InferenceService) *DryRunReport {
+	warnings, allErrs := validateInferenceServiceFields(isvc)
+	return newDryRunReport(warnings, allErrs)
+}
+
+// BuildDryRunReportOnline runs the same checks as BuildDryRunReport plus
+// validateInferenceServiceReferences and validateDeploymentMode against the
+// live cluster via cl, for callers (e.g. DryRunHandler) that do have cluster
+// access. oldIsvc is nil for a create-only dry run.
+func BuildDryRunReportOnline(ctx context.Context, cl client.Client, isvc, oldIsvc *InferenceService) (*DryRunReport, error) {
+	warnings, allErrs := validateInferenceServiceFields(isvc)
+
+	if oldIsvc != nil {
+		allErrs = append(allErrs, validateDeploymentMode(isvc, oldIsvc)...)
+		allErrs = append(allErrs, validateStopAnnotationTransition(isvc, oldIsvc)...)
+	}
+
+	validator := &InferenceServiceValidator{Client: cl}
+	refWarnings, err := validator.validateInferenceServiceReferences(ctx, isvc)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "name"), isvc.Name, err.Error()))
+	}
+	warnings = append(warnings, refWarnings...)
+
+	return newDryRunReport(warnings, allErrs), nil
+}
+
+func newDryRunReport(warnings admission.Warnings, allErrs field.ErrorList) *DryRunReport {
+	report := &DryRunReport{Valid: len(allErrs) == 0}
+	for _, err := range allErrs {
+		report.Errors = append(report.Errors, DryRunIssue{Field: err.Field, Detail: err.ErrorBody()})
+	}
+	for _, warning := range warnings {
+		report.Warnings = append(report.Warnings, DryRunIssue{Detail: warning})
+	}
+	return report
+}
+
+// DryRunHandler returns an http.HandlerFunc implementing
+// POST /validate-inferenceservice/dryrun: it decodes an InferenceService
+// manifest (YAML or JSON) from the request body, runs
+// BuildDryRunReportOnline against cl, and writes the resulting DryRunReport
+// as JSON. It's meant to be mounted onto the webhook server's mux alongside
+// the mutating/validating admission paths, so CI systems and a
+// `kubectl kserve lint` plugin can pre-check a manifest without going
+// through the admission chain at all.
+func DryRunHandler(cl client.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		isvc := &InferenceService{}
+		if err := k8syaml.NewYAMLOrJSONDecoder(r.Body, 4096).Decode(isvc); err != nil {
+			http.Error(w, "failed to decode InferenceService manifest: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		report, err := BuildDryRunReportOnline(r.Context(), cl, isvc, nil)
+		if err != nil {
+			http.Error(w, "failed to run dry-run validation: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if !report.Valid {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	}
+}
@@ -18,7 +18,6 @@ package v1beta1
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -28,7 +27,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"knative.dev/serving/pkg/apis/autoscaling"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -49,6 +52,11 @@ var (
 	validatorLogger = logf.Log.WithName("inferenceservice-v1beta1-validation-webhook")
 	// regular expressions for validation of isvc name
 	IsvcRegexp = regexp.MustCompile("^" + IsvcNameFmt + "$")
+	// isvcGroupKind identifies the InferenceService type for the
+	// apierrors.NewInvalid status returned by validateInferenceService, so
+	// kubectl reports every aggregated field.ErrorList entry in one response
+	// instead of only the first violation encountered.
+	isvcGroupKind = schema.GroupKind{Group: constants.KServeAPIGroupName, Kind: "InferenceService"}
 )
 
 // +kubebuilder:object:generate=false
@@ -89,11 +97,18 @@ func (v *InferenceServiceValidator) ValidateUpdate(ctx context.Context, oldObj,
 		validatorLogger.Error(err, "Unable to convert object to InferenceService")
 	}
 	validatorLogger.Info("validate update", "name", isvc.Name)
-	err = validateDeploymentMode(isvc, oldIsvc)
-	if err != nil {
-		return nil, err
+
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateDeploymentMode(isvc, oldIsvc)...)
+	allErrs = append(allErrs, validateStopAnnotationTransition(isvc, oldIsvc)...)
+
+	warnings, createErrs := validateInferenceServiceFields(isvc)
+	allErrs = append(allErrs, createErrs...)
+
+	if len(allErrs) != 0 {
+		return warnings, apierrors.NewInvalid(isvcGroupKind, isvc.Name, allErrs)
 	}
-	return validateInferenceService(isvc)
+	return warnings, nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -108,25 +123,23 @@ func (v *InferenceServiceValidator) ValidateDelete(ctx context.Context, obj runt
 }
 
 // validateInferenceServiceReferences checks if there are any InferenceGraphs that are referencing the given
-// InferenceService in isvc argument, and returns an error if there are references to it.
+// InferenceService in isvc argument, and returns an error if there are references to it. It queries the
+// v1alpha1.InferenceGraphStepServiceNameIndexKey field index rather than listing every InferenceGraph in the
+// namespace and scanning each one's nodes/steps by hand, so a delete stays cheap in clusters with thousands
+// of graphs.
 func (v *InferenceServiceValidator) validateInferenceServiceReferences(ctx context.Context, isvc *InferenceService) (admission.Warnings, error) {
 	igList := v1alpha1.InferenceGraphList{}
-	err := v.Client.List(ctx, &igList, client.InNamespace(isvc.GetNamespace()))
+	err := v.Client.List(ctx, &igList,
+		client.InNamespace(isvc.GetNamespace()),
+		client.MatchingFields{v1alpha1.InferenceGraphStepServiceNameIndexKey: isvc.GetName()},
+	)
 	if err != nil {
 		return admission.Warnings{}, fmt.Errorf("failed to fetch list of InferenceGraphs: %w", err)
 	}
 
-	var isvcReferences []string
+	isvcReferences := make([]string, 0, len(igList.Items))
 	for _, ig := range igList.Items {
-	node_loop:
-		for _, igNode := range ig.Spec.Nodes {
-			for _, step := range igNode.Steps {
-				if step.ServiceName == isvc.GetName() {
-					isvcReferences = append(isvcReferences, ig.GetName())
-					break node_loop
-				}
-			}
-		}
+		isvcReferences = append(isvcReferences, ig.GetName())
 	}
 
 	if len(isvcReferences) != 0 {
@@ -143,110 +156,214 @@ func GetIntReference(number int) *int {
 }
 
 func validateInferenceService(isvc *InferenceService) (admission.Warnings, error) {
-	var allWarnings admission.Warnings
-	annotations := isvc.Annotations
-
-	if err := validateInferenceServiceName(isvc); err != nil {
-		return allWarnings, err
-	}
-
-	if err := validateInferenceServiceAutoscaler(isvc); err != nil {
-		return allWarnings, err
-	}
-
-	if err := validateAutoscalerTargetUtilizationPercentage(isvc); err != nil {
-		return allWarnings, err
+	allWarnings, allErrs := validateInferenceServiceFields(isvc)
+	if len(allErrs) != 0 {
+		return allWarnings, apierrors.NewInvalid(isvcGroupKind, isvc.Name, allErrs)
 	}
+	return allWarnings, nil
+}
 
-	if err := validateMultiNodeVariables(isvc); err != nil {
-		return allWarnings, err
-	}
+// validateInferenceServiceFields runs every InferenceService validation
+// check and aggregates every violation into a single field.ErrorList instead
+// of returning as soon as the first check fails, so a caller fixing a
+// rejected InferenceService sees every problem at once instead of
+// discovering them one submission at a time.
+func validateInferenceServiceFields(isvc *InferenceService) (admission.Warnings, field.ErrorList) {
+	var allWarnings admission.Warnings
+	var allErrs field.ErrorList
+	annotations := isvc.Annotations
 
-	if err := validateCollocationStorageURI(isvc.Spec.Predictor); err != nil {
-		return allWarnings, err
+	allErrs = append(allErrs, validateInferenceServiceName(isvc)...)
+	allErrs = append(allErrs, validateInferenceServiceAutoscaler(isvc)...)
+	allErrs = append(allErrs, validateAutoscalerTargetUtilizationPercentage(isvc)...)
+	allErrs = append(allErrs, validateMultiNodeVariables(isvc)...)
+	allErrs = append(allErrs, validateCollocationStorageURI(isvc.Spec.Predictor)...)
+	allErrs = append(allErrs, validateAuthModeConflict(isvc)...)
+
+	componentPaths := map[string]*field.Path{
+		"predictor":   field.NewPath("spec", "predictor"),
+		"transformer": field.NewPath("spec", "transformer"),
+		"explainer":   field.NewPath("spec", "explainer"),
 	}
-
-	for _, component := range []Component{
-		&isvc.Spec.Predictor,
-		isvc.Spec.Transformer,
-		isvc.Spec.Explainer,
+	for name, component := range map[string]Component{
+		"predictor":   &isvc.Spec.Predictor,
+		"transformer": isvc.Spec.Transformer,
+		"explainer":   isvc.Spec.Explainer,
 	} {
-		if !reflect.ValueOf(component).IsNil() {
-			if err := validateExactlyOneImplementation(component); err != nil {
-				return allWarnings, err
-			}
-			if err := utils.FirstNonNilError([]error{
-				component.GetImplementation().Validate(),
-				component.GetExtensions().Validate(),
-				validateAutoScalingCompExtension(annotations, component.GetExtensions()),
-			}); err != nil {
-				return allWarnings, err
+		if reflect.ValueOf(component).IsNil() {
+			continue
+		}
+		fldPath := componentPaths[name]
+		if err := validateExactlyOneImplementation(component); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, name, err.Error()))
+			continue
+		}
+		for _, err := range []error{
+			component.GetImplementation().Validate(),
+			component.GetExtensions().Validate(),
+			validateAutoScalingCompExtension(annotations, component.GetExtensions()),
+			validateWorkloadRef(component.GetExtensions()),
+			validateScaleMetrics(component.GetExtensions().Metrics),
+		} {
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath, name, err.Error()))
 			}
 		}
 	}
-	return allWarnings, nil
+	return allWarnings, allErrs
 }
 
 // validateMultiNodeVariables validates when there is workerSpec set in isvc
-func validateMultiNodeVariables(isvc *InferenceService) error {
+func validateMultiNodeVariables(isvc *InferenceService) field.ErrorList {
+	var allErrs field.ErrorList
+	workerSpecPath := field.NewPath("spec", "predictor", "workerSpec")
 	if isvc.Spec.Predictor.WorkerSpec != nil {
 		if len(isvc.Spec.Predictor.WorkerSpec.Containers) > 1 {
-			return fmt.Errorf(DisallowedMultipleContainersInWorkerSpecError, isvc.Name)
+			allErrs = append(allErrs, field.Invalid(workerSpecPath.Child("containers"), len(isvc.Spec.Predictor.WorkerSpec.Containers),
+				fmt.Sprintf(DisallowedMultipleContainersInWorkerSpecError, isvc.Name)))
 		}
 		if isvc.Spec.Predictor.Model != nil {
+			modelPath := field.NewPath("spec", "predictor", "model")
 			if _, exists := utils.GetEnvVarValue(isvc.Spec.Predictor.Model.PredictorExtensionSpec.Container.Env, constants.PipelineParallelSizeEnvName); exists {
-				return fmt.Errorf(DisallowedWorkerSpecPipelineParallelSizeEnvError, isvc.Name)
+				allErrs = append(allErrs, field.Invalid(modelPath, constants.PipelineParallelSizeEnvName, fmt.Sprintf(DisallowedWorkerSpecPipelineParallelSizeEnvError, isvc.Name)))
 			}
 			if _, exists := utils.GetEnvVarValue(isvc.Spec.Predictor.Model.PredictorExtensionSpec.Container.Env, constants.TensorParallelSizeEnvName); exists {
-				return fmt.Errorf(DisallowedWorkerSpecTensorParallelSizeEnvError, isvc.Name)
+				allErrs = append(allErrs, field.Invalid(modelPath, constants.TensorParallelSizeEnvName, fmt.Sprintf(DisallowedWorkerSpecTensorParallelSizeEnvError, isvc.Name)))
 			}
 
+			customGPUResourceTypesPath := field.NewPath("metadata", "annotations").Key(constants.CustomGPUResourceTypesAnnotationKey)
 			customGPUResourceTypes := isvc.GetAnnotations()[constants.CustomGPUResourceTypesAnnotationKey]
 			if customGPUResourceTypes != "" {
 				if !utils.IsValidCustomGPUArray(customGPUResourceTypes) {
-					return fmt.Errorf(InvalidCustomGPUTypesAnnotationFormatError, isvc.Name, constants.CustomGPUResourceTypesAnnotationKey)
+					allErrs = append(allErrs, field.Invalid(customGPUResourceTypesPath, customGPUResourceTypes,
+						fmt.Sprintf(InvalidCustomGPUTypesAnnotationFormatError, isvc.Name, constants.CustomGPUResourceTypesAnnotationKey)))
 				}
 			}
 
 			if utils.IsUnknownGpuResourceType(isvc.Spec.Predictor.Model.Resources, customGPUResourceTypes) {
-				return fmt.Errorf(InvalidUnknownGPUTypeError, isvc.Name)
+				allErrs = append(allErrs, field.Invalid(modelPath.Child("resources"), isvc.Spec.Predictor.Model.Resources, fmt.Sprintf(InvalidUnknownGPUTypeError, isvc.Name)))
 			}
 
 			if isvc.Spec.Predictor.Model.StorageURI == nil {
-				return fmt.Errorf(MissingStorageURI, isvc.Name)
+				allErrs = append(allErrs, field.Required(modelPath.Child("storageUri"), fmt.Sprintf(MissingStorageURI, isvc.Name)))
 			} else {
 				storageProtocol := strings.Split(*isvc.Spec.Predictor.Model.StorageURI, "://")[0]
 				if storageProtocol != "pvc" && storageProtocol != "oci" {
-					return fmt.Errorf(InvalidNotSupportedStorageURIProtocolError, isvc.Name, storageProtocol)
+					allErrs = append(allErrs, field.Invalid(modelPath.Child("storageUri"), *isvc.Spec.Predictor.Model.StorageURI,
+						fmt.Sprintf(InvalidNotSupportedStorageURIProtocolError, isvc.Name, storageProtocol)))
 				}
 			}
 			if isvc.GetAnnotations()[constants.AutoscalerClass] != string(constants.AutoscalerClassExternal) {
-				return fmt.Errorf(InvalidAutoScalerError, isvc.Name, isvc.GetAnnotations()[constants.AutoscalerClass])
+				allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "annotations").Key(constants.AutoscalerClass), isvc.GetAnnotations()[constants.AutoscalerClass],
+					fmt.Sprintf(InvalidAutoScalerError, isvc.Name, isvc.GetAnnotations()[constants.AutoscalerClass])))
 			}
 		}
 
 		// WorkerSpec.PipelineParallelSize should not be less than 2 (head + worker)
 		if pps := isvc.Spec.Predictor.WorkerSpec.PipelineParallelSize; pps != nil && *pps < 2 {
-			return fmt.Errorf(InvalidWorkerSpecPipelineParallelSizeValueError, isvc.Name, strconv.Itoa(*pps))
+			allErrs = append(allErrs, field.Invalid(workerSpecPath.Child("pipelineParallelSize"), *pps,
+				fmt.Sprintf(InvalidWorkerSpecPipelineParallelSizeValueError, isvc.Name, strconv.Itoa(*pps))))
 		}
 
 		// WorkerSpec.TensorParallelSize should not be less than 1.
 		if tps := isvc.Spec.Predictor.WorkerSpec.TensorParallelSize; tps != nil && *tps < 1 {
-			return fmt.Errorf(InvalidWorkerSpecTensorParallelSizeValueError, isvc.Name, strconv.Itoa(*tps))
+			allErrs = append(allErrs, field.Invalid(workerSpecPath.Child("tensorParallelSize"), *tps,
+				fmt.Sprintf(InvalidWorkerSpecTensorParallelSizeValueError, isvc.Name, strconv.Itoa(*tps))))
 		}
 
 		if isvc.Spec.Predictor.WorkerSpec.Containers != nil {
-			for _, container := range isvc.Spec.Predictor.WorkerSpec.Containers {
+			for i, container := range isvc.Spec.Predictor.WorkerSpec.Containers {
 				if utils.IsUnknownGpuResourceType(container.Resources, isvc.GetAnnotations()[constants.CustomGPUResourceTypesAnnotationKey]) {
-					return fmt.Errorf(InvalidUnknownGPUTypeError, isvc.Name)
+					allErrs = append(allErrs, field.Invalid(workerSpecPath.Child("containers").Index(i).Child("resources"), container.Resources,
+						fmt.Sprintf(InvalidUnknownGPUTypeError, isvc.Name)))
 				}
 			}
 		}
 	}
-	return nil
+	return allErrs
 }
 
 // Validate scaling options component extensions
+// scalableWorkloadKinds are the APIVersion/Kind pairs the cluster is
+// guaranteed to expose a `/scale` subresource for, without needing a
+// discovery client call from the webhook. A WorkloadRef naming anything
+// else is rejected, since the HPA created against it would otherwise fail
+// silently at reconcile time instead of at admission time.
+var scalableWorkloadKinds = map[string]bool{
+	"apps/v1:Deployment":  true,
+	"apps/v1:StatefulSet": true,
+	"apps/v1:ReplicaSet":  true,
+}
+
+// validateWorkloadRef rejects a WorkloadRef naming a Kind not known to
+// expose the `/scale` subresource the HorizontalPodAutoscaler requires.
+// Custom workload CRDs that do implement `/scale` aren't statically
+// knowable here, so WorkloadRef.Kind needs to be added to
+// scalableWorkloadKinds once such a CRD is actually supported.
+func validateWorkloadRef(compExtSpec *ComponentExtensionSpec) error {
+	ref := compExtSpec.WorkloadRef
+	if ref == nil {
+		return nil
+	}
+	if ref.Kind == "" || ref.APIVersion == "" {
+		return fmt.Errorf("workloadRef must set both apiVersion and kind")
+	}
+	key := ref.APIVersion + ":" + ref.Kind
+	if !scalableWorkloadKinds[key] {
+		return fmt.Errorf("workloadRef %s does not expose the scale subresource required by the HorizontalPodAutoscaler", key)
+	}
+	return nil
+}
+
+// validateScaleMetrics rejects a ComponentExtensionSpec.Metrics entry whose
+// AverageValue/Threshold string isn't a valid resource.Quantity. The HPA
+// reconciler's parseQuantityOrZero turns a malformed quantity into a zero
+// target; rejecting them here means a bad quantity is caught at admission
+// instead of silently producing a zero HPA target.
+func validateScaleMetrics(metrics []ScaleMetricSpec) error {
+	for _, m := range metrics {
+		switch m.Type {
+		case PodsScaleMetricSourceType:
+			if m.Pods != nil {
+				if err := validateQuantityString(m.Pods.AverageValue); err != nil {
+					return fmt.Errorf("metrics: pods.averageValue %q: %w", m.Pods.AverageValue, err)
+				}
+			}
+		case ResourceScaleMetricSourceType:
+			if m.Resource != nil && m.Resource.TargetType == AverageValueScaleMetricTargetType {
+				if err := validateQuantityString(m.Resource.AverageValue); err != nil {
+					return fmt.Errorf("metrics: resource.averageValue %q: %w", m.Resource.AverageValue, err)
+				}
+			}
+		case ExternalScaleMetricSourceType:
+			if m.External != nil {
+				if err := validateQuantityString(m.External.Threshold); err != nil {
+					return fmt.Errorf("metrics: external.threshold %q: %w", m.External.Threshold, err)
+				}
+			}
+		case ObjectScaleMetricSourceType:
+			if m.Object != nil {
+				if m.Object.AverageValue != "" {
+					if err := validateQuantityString(m.Object.AverageValue); err != nil {
+						return fmt.Errorf("metrics: object.averageValue %q: %w", m.Object.AverageValue, err)
+					}
+				} else if err := validateQuantityString(m.Object.Threshold); err != nil {
+					return fmt.Errorf("metrics: object.threshold %q: %w", m.Object.Threshold, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateQuantityString reports whether s parses as a resource.Quantity,
+// mirroring the resource.ParseQuantity call hpa_reconciler.go's
+// parseQuantityOrZero makes on the same strings.
+func validateQuantityString(s string) error {
+	_, err := resource.ParseQuantity(s)
+	return err
+}
+
 func validateAutoScalingCompExtension(annotations map[string]string, compExtSpec *ComponentExtensionSpec) error {
 	deploymentMode := annotations["serving.kserve.io/deploymentMode"]
 	annotationClass := annotations[autoscaling.ClassAnnotationKey]
@@ -258,16 +375,17 @@ func validateAutoScalingCompExtension(annotations map[string]string, compExtSpec
 }
 
 // Validation of isvc name
-func validateInferenceServiceName(isvc *InferenceService) error {
+func validateInferenceServiceName(isvc *InferenceService) field.ErrorList {
 	if !IsvcRegexp.MatchString(isvc.Name) {
-		return fmt.Errorf(InvalidISVCNameFormatError, isvc.Name, IsvcNameFmt)
+		return field.ErrorList{field.Invalid(field.NewPath("metadata", "name"), isvc.Name, fmt.Sprintf(InvalidISVCNameFormatError, isvc.Name, IsvcNameFmt))}
 	}
 	return nil
 }
 
 // Validation of isvc autoscaler class
-func validateInferenceServiceAutoscaler(isvc *InferenceService) error {
+func validateInferenceServiceAutoscaler(isvc *InferenceService) field.ErrorList {
 	annotations := isvc.ObjectMeta.Annotations
+	fldPath := field.NewPath("metadata", "annotations").Key(constants.AutoscalerClass)
 	value, ok := annotations[constants.AutoscalerClass]
 	class := constants.AutoscalerClassType(value)
 	if ok {
@@ -276,23 +394,44 @@ func validateInferenceServiceAutoscaler(isvc *InferenceService) error {
 				switch class {
 				case constants.AutoscalerClassHPA:
 					if metric, ok := annotations[constants.AutoscalerMetrics]; ok {
-						return validateHPAMetrics(ScaleMetric(metric))
-					} else {
-						return nil
+						if err := validateHPAMetrics(ScaleMetric(metric)); err != nil {
+							return field.ErrorList{field.Invalid(field.NewPath("metadata", "annotations").Key(constants.AutoscalerMetrics), metric, err.Error())}
+						}
 					}
+					return nil
 				case constants.AutoscalerClassExternal:
 					return nil
+				case constants.AutoscalerClassKeda, constants.AutoscalerClassNone, constants.AutoscalerClassRayCluster:
+					return nil
 				default:
-					return fmt.Errorf("unknown autoscaler class [%s]", class)
+					return field.ErrorList{field.Invalid(fldPath, value, fmt.Sprintf("unknown autoscaler class [%s]", class))}
 				}
 			}
 		}
-		return fmt.Errorf("[%s] is not a supported autoscaler class type", value)
+		return field.ErrorList{field.Invalid(fldPath, value, fmt.Sprintf("[%s] is not a supported autoscaler class type", value))}
 	}
 
 	return nil
 }
 
+// validateAuthModeConflict rejects an InferenceService that selects both the
+// OpenShift oauth-proxy sidecar (constants.ODHKserveRawAuth) and JWT auth
+// mode (constants.RawAuthModeAnnotationKey == RawAuthModeJWT, or
+// constants.ODHEnableJWTAuthAnnotationKey == "true"): both sidecars bind the
+// raw-deployment pod's externally-reachable port, so only one auth mode can
+// actually own it.
+func validateAuthModeConflict(isvc *InferenceService) field.ErrorList {
+	annotations := isvc.Annotations
+	oauthProxy := annotations[constants.ODHKserveRawAuth] == "true"
+	jwtAuth := annotations[constants.RawAuthModeAnnotationKey] == constants.RawAuthModeJWT || annotations[constants.ODHEnableJWTAuthAnnotationKey] == "true"
+	if oauthProxy && jwtAuth {
+		return field.ErrorList{field.Invalid(field.NewPath("metadata", "annotations"), constants.ODHEnableJWTAuthAnnotationKey,
+			fmt.Sprintf("%s and JWT auth mode (%s or %s=true) cannot both be enabled on the same InferenceService",
+				constants.ODHKserveRawAuth, constants.RawAuthModeAnnotationKey, constants.ODHEnableJWTAuthAnnotationKey))}
+	}
+	return nil
+}
+
 // Validate of autoscaler HPA metrics
 func validateHPAMetrics(metric ScaleMetric) error {
 	for _, item := range constants.AutoscalerAllowedMetricsList {
@@ -304,14 +443,13 @@ func validateHPAMetrics(metric ScaleMetric) error {
 }
 
 // Validate of autoscaler targetUtilizationPercentage
-func validateAutoscalerTargetUtilizationPercentage(isvc *InferenceService) error {
+func validateAutoscalerTargetUtilizationPercentage(isvc *InferenceService) field.ErrorList {
 	annotations := isvc.ObjectMeta.Annotations
 	if value, ok := annotations[constants.TargetUtilizationPercentage]; ok {
+		fldPath := field.NewPath("metadata", "annotations").Key(constants.TargetUtilizationPercentage)
 		t, err := strconv.Atoi(value)
-		if err != nil {
-			return fmt.Errorf("the target utilization percentage should be a [1-100] integer")
-		} else if t < 1 || t > 100 {
-			return fmt.Errorf("the target utilization percentage should be a [1-100] integer")
+		if err != nil || t < 1 || t > 100 {
+			return field.ErrorList{field.Invalid(fldPath, value, "the target utilization percentage should be a [1-100] integer")}
 		}
 	}
 
@@ -380,12 +518,13 @@ func validateScalingKPACompExtension(compExtSpec *ComponentExtensionSpec) error
 }
 
 // validates if transformer container has storage uri or not in collocation of predictor and transformer scenario
-func validateCollocationStorageURI(predictorSpec PredictorSpec) error {
-	for _, container := range predictorSpec.Containers {
+func validateCollocationStorageURI(predictorSpec PredictorSpec) field.ErrorList {
+	for i, container := range predictorSpec.Containers {
 		if container.Name == constants.TransformerContainerName {
 			for _, env := range container.Env {
 				if env.Name == constants.CustomSpecStorageUriEnvVarKey {
-					return errors.New(StorageUriPresentInTransformerError)
+					fldPath := field.NewPath("spec", "predictor", "containers").Index(i).Child("env")
+					return field.ErrorList{field.Invalid(fldPath, constants.CustomSpecStorageUriEnvVarKey, StorageUriPresentInTransformerError)}
 				}
 			}
 			break
@@ -395,18 +534,37 @@ func validateCollocationStorageURI(predictorSpec PredictorSpec) error {
 }
 
 // validates if the deploymentMode specified in the annotation is not different from the one recorded in the status
-func validateDeploymentMode(newIsvc *InferenceService, oldIsvc *InferenceService) error {
+func validateDeploymentMode(newIsvc *InferenceService, oldIsvc *InferenceService) field.ErrorList {
 	statusDeploymentMode := oldIsvc.Status.DeploymentMode
 	if len(statusDeploymentMode) != 0 {
 		annotations := newIsvc.Annotations
 		annotationDeploymentMode, ok := annotations[constants.DeploymentMode]
 		if ok && annotationDeploymentMode != statusDeploymentMode {
-			return fmt.Errorf("update rejected: deploymentMode cannot be changed from '%s' to '%s'", statusDeploymentMode, annotationDeploymentMode)
+			fldPath := field.NewPath("metadata", "annotations").Key(constants.DeploymentMode)
+			return field.ErrorList{field.Invalid(fldPath, annotationDeploymentMode,
+				fmt.Sprintf("update rejected: deploymentMode cannot be changed from '%s' to '%s'", statusDeploymentMode, annotationDeploymentMode))}
 		}
 	}
 	return nil
 }
 
+// validateStopAnnotationTransition rejects an update that sets
+// constants.StopAnnotationKey to a value other than "true" or "false". The
+// stop reconciler only recognizes those two values; anything else would be
+// silently treated as "not stopped" by the reconciler, leaving the annotation
+// permanently out of sync with the resource's actual desired state.
+func validateStopAnnotationTransition(newIsvc *InferenceService, oldIsvc *InferenceService) field.ErrorList {
+	newValue, newOk := newIsvc.Annotations[constants.StopAnnotationKey]
+	if !newOk {
+		return nil
+	}
+	if newValue != "true" && newValue != "false" {
+		fldPath := field.NewPath("metadata", "annotations").Key(constants.StopAnnotationKey)
+		return field.ErrorList{field.Invalid(fldPath, newValue, fmt.Sprintf("update rejected: %s annotation must be 'true' or 'false', got '%s'", constants.StopAnnotationKey, newValue))}
+	}
+	return nil
+}
+
 // Convert runtime.Object into InferenceService
 func convertToInferenceService(obj runtime.Object) (*InferenceService, error) {
 	isvc, ok := obj.(*InferenceService)
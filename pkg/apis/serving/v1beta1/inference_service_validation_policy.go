@@ -0,0 +1,159 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidationRule is one user-authored rule an InferenceServiceValidationPolicy
+// (or the equivalent kserve-namespace ConfigMap) carries, modeled on
+// Kubernetes ValidatingAdmissionPolicy: Expression is evaluated with object
+// and oldObject bindings, MatchConditions gates whether the rule applies at
+// all. The rejection text is always the static Message; MessageExpression is
+// carried on the rule but EvaluateValidationRules does not evaluate it.
+type ValidationRule struct {
+	Expression        string `json:"expression"`
+	Message           string `json:"message,omitempty"`
+	MessageExpression string `json:"messageExpression,omitempty"`
+	Reason            string `json:"reason,omitempty"`
+	MatchConditions   string `json:"matchConditions,omitempty"`
+}
+
+// ExpressionEngine compiles and evaluates a ValidationRule's CEL
+// expressions. It's the seam a cel-go-backed implementation plugs into;
+// this package defines only the interface and ships no implementation of it.
+type ExpressionEngine interface {
+	// EstimateCost returns expression's estimated evaluation cost in the
+	// same units as a ValidationPolicyBudget, used to reject overly
+	// expensive rules at admission time before they're ever compiled.
+	EstimateCost(expression string) uint64
+	// Compile compiles expression into a Program, returning an error if it
+	// isn't syntactically valid.
+	Compile(expression string) (Program, error)
+}
+
+// Program evaluates a compiled expression against the object/oldObject
+// bindings, returning the expression's boolean result.
+type Program interface {
+	Evaluate(object, oldObject map[string]any) (bool, error)
+}
+
+// ValidationPolicyBudget bounds the total estimated cost
+// (ExpressionEngine.EstimateCost) a single ValidationRule may spend, so one
+// misbehaving or adversarial rule can't turn every InferenceService
+// admission into a denial-of-service. 10,000,000 mirrors cel-go's own
+// default per-evaluation cost budget.
+const ValidationPolicyBudget uint64 = 10_000_000
+
+// compiledPolicyCacheEntry pairs a policy's resourceVersion with the
+// Programs compiled from its rules, so a policy that hasn't changed between
+// admissions never pays recompilation cost.
+type compiledPolicyCacheEntry struct {
+	resourceVersion string
+	programs        []Program
+}
+
+// CompiledPolicyCache caches compiled ValidationRule programs per policy
+// name, invalidated by resourceVersion change, mirroring how a Kubernetes
+// informer cache treats resourceVersion as the freshness key.
+type CompiledPolicyCache struct {
+	mu      sync.RWMutex
+	engine  ExpressionEngine
+	entries map[string]compiledPolicyCacheEntry
+}
+
+// NewCompiledPolicyCache returns a cache that compiles rules with engine.
+func NewCompiledPolicyCache(engine ExpressionEngine) *CompiledPolicyCache {
+	return &CompiledPolicyCache{engine: engine, entries: map[string]compiledPolicyCacheEntry{}}
+}
+
+// Get returns the compiled Programs for policyName at resourceVersion,
+// recompiling rules only when resourceVersion doesn't match the cached
+// entry (i.e. the policy changed since it was last compiled). It rejects
+// any rule whose estimated cost exceeds ValidationPolicyBudget rather than
+// compiling it.
+func (c *CompiledPolicyCache) Get(policyName, resourceVersion string, rules []ValidationRule) ([]Program, error) {
+	c.mu.RLock()
+	entry, ok := c.entries[policyName]
+	c.mu.RUnlock()
+	if ok && entry.resourceVersion == resourceVersion {
+		return entry.programs, nil
+	}
+
+	programs := make([]Program, 0, len(rules))
+	for i, rule := range rules {
+		if cost := c.engine.EstimateCost(rule.Expression); cost > ValidationPolicyBudget {
+			return nil, fmt.Errorf("rule %d of policy %q: estimated cost %d exceeds the %d budget", i, policyName, cost, ValidationPolicyBudget)
+		}
+		program, err := c.engine.Compile(rule.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d of policy %q: %w", i, policyName, err)
+		}
+		programs = append(programs, program)
+	}
+
+	c.mu.Lock()
+	c.entries[policyName] = compiledPolicyCacheEntry{resourceVersion: resourceVersion, programs: programs}
+	c.mu.Unlock()
+	return programs, nil
+}
+
+// EvaluateValidationRules runs each of rules' compiled programs against
+// object/oldObject, appending a field.ErrorList entry for each rule whose
+// expression evaluates false. A rule whose MatchConditions isn't met is
+// skipped without being evaluated at all, so a narrowly-scoped rule (e.g.
+// "only namespace foo") doesn't pay evaluation cost on every admission.
+func EvaluateValidationRules(cache *CompiledPolicyCache, policyName, resourceVersion string, rules []ValidationRule, object, oldObject map[string]any) field.ErrorList {
+	var allErrs field.ErrorList
+	programs, err := cache.Get(policyName, resourceVersion, rules)
+	if err != nil {
+		return field.ErrorList{field.InternalError(field.NewPath("spec"), err)}
+	}
+
+	for i, rule := range rules {
+		if rule.MatchConditions != "" {
+			matchProgram, err := cache.engine.Compile(rule.MatchConditions)
+			if err != nil {
+				allErrs = append(allErrs, field.InternalError(field.NewPath("spec"), fmt.Errorf("rule %d matchConditions: %w", i, err)))
+				continue
+			}
+			matched, err := matchProgram.Evaluate(object, oldObject)
+			if err != nil || !matched {
+				continue
+			}
+		}
+
+		ok, err := programs[i].Evaluate(object, oldObject)
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(field.NewPath("spec"), fmt.Errorf("rule %d (%s): %w", i, rule.Reason, err)))
+			continue
+		}
+		if !ok {
+			// MessageExpression would need a string-typed CEL evaluation;
+			// Program.Evaluate only returns the rule's own boolean result,
+			// so a real ExpressionEngine would need a second, string-typed
+			// entry point to render it. Until then, the static Message is
+			// always used.
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec"), rule.Reason, rule.Message))
+		}
+	}
+	return allErrs
+}
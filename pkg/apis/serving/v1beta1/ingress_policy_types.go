@@ -0,0 +1,85 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IngressRetryPolicy configures retry behavior for the HTTPRoute generated for
+// a component (predictor/transformer/explainer).
+type IngressRetryPolicy struct {
+	// Attempts is the maximum number of retry attempts.
+	Attempts int32 `json:"attempts"`
+	// PerTryTimeout bounds a single retry attempt.
+	// +optional
+	PerTryTimeout *metav1.Duration `json:"perTryTimeout,omitempty"`
+	// Codes lists the upstream response codes that should be retried, e.g. 502, 503, 504.
+	// +optional
+	Codes []int32 `json:"codes,omitempty"`
+	// Conditions lists named retry conditions, e.g. "5xx", "gateway-error", "reset".
+	// +optional
+	Conditions []string `json:"conditions,omitempty"`
+}
+
+// IngressPolicy configures per-route behavior for the Gateway API HTTPRoute
+// generated for a component, beyond the component's own TimeoutSeconds. It is
+// intended to be embedded on ComponentExtensionSpec.
+type IngressPolicy struct {
+	// RequestTimeout bounds the end-to-end request, including retries. When unset,
+	// the route gets the 60s default request timeout; TimeoutSeconds is not consulted.
+	// +optional
+	RequestTimeout *metav1.Duration `json:"requestTimeout,omitempty"`
+	// BackendRequestTimeout bounds a single attempt to the backend.
+	// +optional
+	BackendRequestTimeout *metav1.Duration `json:"backendRequestTimeout,omitempty"`
+	// Retry configures HTTPRoute-level retries.
+	// +optional
+	Retry *IngressRetryPolicy `json:"retry,omitempty"`
+	// AuthPolicyName references an existing Kuadrant AuthPolicy-compatible
+	// AuthConfig by name; when set, the reconciler attaches an AuthPolicy
+	// targeting the generated HTTPRoute instead of requiring the user to
+	// hand-write one alongside the InferenceService.
+	// +optional
+	AuthPolicyName string `json:"authPolicyName,omitempty"`
+	// RateLimitPolicyName references an existing rate-limit configuration by
+	// name; when set, the reconciler attaches a RateLimitPolicy targeting the
+	// generated HTTPRoute.
+	// +optional
+	RateLimitPolicyName string `json:"rateLimitPolicyName,omitempty"`
+}
+
+// Validate checks that the configured timeouts are internally consistent: the
+// end-to-end request timeout must be able to accommodate every retry attempt.
+func (p *IngressPolicy) Validate() error {
+	if p == nil || p.RequestTimeout == nil || p.Retry == nil || p.Retry.PerTryTimeout == nil {
+		return nil
+	}
+	attempts := p.Retry.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	minimumRequired := time.Duration(attempts) * p.Retry.PerTryTimeout.Duration
+	if p.RequestTimeout.Duration < minimumRequired {
+		return fmt.Errorf("ingress policy requestTimeout (%s) must be >= retry.perTryTimeout (%s) * retry.attempts (%d)",
+			p.RequestTimeout.Duration, p.Retry.PerTryTimeout.Duration, attempts)
+	}
+	return nil
+}
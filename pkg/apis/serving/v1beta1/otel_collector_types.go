@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// OpenTelemetrySpec lets a component customize the OpenTelemetry Collector
+// sidecar beyond the default pipeline (a Prometheus receiver scraping the
+// container's metrics port, feeding the KEDA external-metric exporter):
+// additional receivers for traces from the model server, processors like
+// tail-based sampling, and additional exporters fanning out to a user's
+// observability backend. It is intended to be embedded on
+// PredictorSpec/TransformerSpec/ExplainerSpec.
+type OpenTelemetrySpec struct {
+	// Receivers are appended to the default Prometheus receiver.
+	// +optional
+	Receivers []OTelReceiver `json:"receivers,omitempty"`
+	// Processors are appended to the default KEDA-metric filter processor, in
+	// the order given; "batch" and "memory_limiter" are typically placed last
+	// so they process the combined signal from every receiver.
+	// +optional
+	Processors []OTelProcessor `json:"processors,omitempty"`
+	// Exporters are appended to the default otlp exporter feeding the KEDA
+	// scaler, so traces/metrics also reach a user's own backend.
+	// +optional
+	Exporters []OTelExporter `json:"exporters,omitempty"`
+}
+
+// OTelReceiverType enumerates the additional receiver kinds
+// OpenTelemetrySpec.Receivers supports, beyond the always-present default
+// Prometheus scrape receiver.
+type OTelReceiverType string
+
+const (
+	OTelReceiverOTLPGRPC    OTelReceiverType = "otlp-grpc"
+	OTelReceiverOTLPHTTP    OTelReceiverType = "otlp-http"
+	OTelReceiverHostMetrics OTelReceiverType = "hostmetrics"
+)
+
+// OTelReceiver configures one additional Collector receiver.
+type OTelReceiver struct {
+	Type OTelReceiverType `json:"type"`
+	// Endpoint overrides the receiver's default listen address, e.g.
+	// "0.0.0.0:4317" for otlp-grpc.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// OTelProcessorType enumerates the additional processor kinds
+// OpenTelemetrySpec.Processors supports, beyond the always-present default
+// KEDA-metric filter processor.
+type OTelProcessorType string
+
+const (
+	OTelProcessorBatch         OTelProcessorType = "batch"
+	OTelProcessorMemoryLimiter OTelProcessorType = "memory_limiter"
+	OTelProcessorTailSampling  OTelProcessorType = "tail_sampling"
+)
+
+// OTelTailSamplingPolicy is one policy of a tail_sampling processor, e.g.
+// "always keep error traces" or "always keep traces slower than 500ms".
+type OTelTailSamplingPolicy struct {
+	Name string `json:"name"`
+	// StatusCode samples traces whose root span status code matches, e.g.
+	// "ERROR".
+	// +optional
+	StatusCode string `json:"statusCode,omitempty"`
+	// LatencyThresholdMs samples traces whose duration exceeds this threshold.
+	// +optional
+	LatencyThresholdMs int32 `json:"latencyThresholdMs,omitempty"`
+}
+
+// OTelProcessor configures one additional Collector processor.
+type OTelProcessor struct {
+	Type OTelProcessorType `json:"type"`
+	// TailSamplingPolicies is only consulted when Type is tail_sampling.
+	// +optional
+	TailSamplingPolicies []OTelTailSamplingPolicy `json:"tailSamplingPolicies,omitempty"`
+}
+
+// OTelExporterType enumerates the additional exporter kinds
+// OpenTelemetrySpec.Exporters supports, beyond the always-present default
+// otlp exporter feeding the KEDA scaler.
+type OTelExporterType string
+
+const (
+	OTelExporterOTLP                  OTelExporterType = "otlp"
+	OTelExporterPrometheusRemoteWrite OTelExporterType = "prometheusremotewrite"
+	OTelExporterLogging               OTelExporterType = "logging"
+)
+
+// OTelExporter configures one additional Collector exporter.
+type OTelExporter struct {
+	Type     OTelExporterType `json:"type"`
+	Endpoint string           `json:"endpoint,omitempty"`
+	// Insecure disables TLS to Endpoint; defaults to false (TLS required).
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+}
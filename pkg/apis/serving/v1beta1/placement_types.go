@@ -0,0 +1,39 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PlacementSpec lets a component opt out of, or tune, the zone/hostname
+// topology spread and pod anti-affinity defaults a raw-deployment component
+// gets once it scales beyond one replica. It is intended to be embedded as
+// ComponentExtensionSpec.Placement.
+type PlacementSpec struct {
+	// DisableTopologySpread turns off the default zone/hostname
+	// TopologySpreadConstraints entirely, e.g. for small clusters with too
+	// few zones/nodes to satisfy them usefully.
+	// +optional
+	DisableTopologySpread bool `json:"disableTopologySpread,omitempty"`
+	// WhenUnsatisfiable overrides the default
+	// corev1.ScheduleAnyway applied to the generated constraints; set to
+	// corev1.DoNotSchedule to make zone/node spread a hard scheduling
+	// requirement instead of a best-effort one.
+	// +optional
+	WhenUnsatisfiable *corev1.UnsatisfiableConstraintAction `json:"whenUnsatisfiable,omitempty"`
+}
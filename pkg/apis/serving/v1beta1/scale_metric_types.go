@@ -0,0 +1,181 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScaleMetricSourceType mirrors autoscalingv2.MetricSourceType's subset
+// relevant to KServe components: a resource metric (CPU/memory) handled
+// natively by the HorizontalPodAutoscaler, an external metric, or an object
+// metric describing another Kubernetes object (e.g. an Ingress's request
+// rate), the latter two requiring either the custom/external metrics API
+// (AutoscalerClassHPA) or a KEDA ScaledObject (AutoscalerClassKeda) to poll.
+type ScaleMetricSourceType string
+
+const (
+	ResourceScaleMetricSourceType ScaleMetricSourceType = "Resource"
+	PodsScaleMetricSourceType     ScaleMetricSourceType = "Pods"
+	ExternalScaleMetricSourceType ScaleMetricSourceType = "External"
+	ObjectScaleMetricSourceType   ScaleMetricSourceType = "Object"
+)
+
+// ScaleMetricTargetType mirrors the subset of autoscalingv2.MetricTargetType
+// a ResourceMetricSource can use: Utilization (percent of the pod's
+// resource request) or AverageValue (a raw per-pod average, e.g. for
+// scaling on memory in absolute bytes rather than percent).
+type ScaleMetricTargetType string
+
+const (
+	UtilizationScaleMetricTargetType  ScaleMetricTargetType = "Utilization"
+	AverageValueScaleMetricTargetType ScaleMetricTargetType = "AverageValue"
+)
+
+// ResourceMetricSource is a Resource-type entry of ScaleMetricSpec,
+// mirroring autoscalingv2.ResourceMetricSource but with a configurable
+// TargetType: unlike the legacy ComponentExtensionSpec.ScaleMetric/
+// ScaleTarget pair (which only ever produce a Utilization target), an entry
+// here can also scale on AverageValue, e.g. a fixed memory-per-pod target
+// that doesn't depend on the container's resource request being set.
+type ResourceMetricSource struct {
+	// Name is the resource to scale on, e.g. "cpu" or "memory".
+	Name string `json:"name"`
+	// TargetType selects which of Utilization/AverageValue below is set;
+	// defaults to Utilization when empty.
+	// +optional
+	TargetType ScaleMetricTargetType `json:"targetType,omitempty"`
+	// Utilization is the target average resource utilization, as a
+	// percentage of the container's resource request. Only used when
+	// TargetType is Utilization (or empty).
+	// +optional
+	Utilization *int32 `json:"utilization,omitempty"`
+	// AverageValue is the target average value across all matching pods,
+	// e.g. "200Mi". Only used when TargetType is AverageValue.
+	// +optional
+	AverageValue string `json:"averageValue,omitempty"`
+}
+
+// PodsMetricSource names a custom per-pod metric (e.g. in-flight requests
+// scraped from a sidecar), mirroring autoscalingv2.PodsMetricSource. Unlike
+// ResourceMetricSource, a Pods metric's target is always an AverageValue.
+type PodsMetricSource struct {
+	// Name is the custom-metrics-API metric name.
+	Name string `json:"name"`
+	// AverageValue is the target value averaged across all matching pods.
+	AverageValue string `json:"averageValue"`
+	// Selector restricts which metric series the query matches.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// ExternalMetricSource names an external metric KEDA should poll, e.g. a
+// Prometheus query for queue depth or pending requests. When the component
+// uses AutoscalerClassHPA instead of KEDA, the same fields populate an
+// autoscalingv2.ExternalMetricSource against the custom-metrics API, with
+// Name read as the full metric name and Query ignored.
+type ExternalMetricSource struct {
+	// Name is the KEDA scaler type (e.g. "prometheus") when AutoscalerClass
+	// is keda, or the custom-metrics-API metric name when it is hpa.
+	Name string `json:"name"`
+	// Query is the scaler-specific query string, e.g. a PromQL expression.
+	// Only consulted for KEDA scalers; the HPA's external metrics API has no
+	// query field of its own.
+	Query string `json:"query"`
+	// Threshold is the target value the scaler/HPA compares the metric's
+	// result against.
+	Threshold string `json:"threshold"`
+	// ServerAddress is the scaler's data source endpoint, e.g. the Prometheus server URL.
+	ServerAddress string `json:"serverAddress,omitempty"`
+	// Selector restricts which metric series the HPA's external metrics
+	// query matches, e.g. {"queue": "predict-requests"}. Ignored for KEDA
+	// scalers, which express the equivalent restriction inside Query/metadata.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// AuthRef, when set, authenticates the scaler against a secured
+	// Prometheus/Thanos/OTLP endpoint via a KEDA
+	// TriggerAuthentication/ClusterTriggerAuthentication, instead of the
+	// anonymous scrape KServe assumes by default.
+	// +optional
+	AuthRef *MetricAuthRef `json:"authRef,omitempty"`
+}
+
+// ObjectMetricSource names a metric describing another Kubernetes object in
+// the component's namespace, e.g. an Ingress's requests-per-second, mirroring
+// autoscalingv2.ObjectMetricSource.
+type ObjectMetricSource struct {
+	// DescribedObject is the object the metric describes.
+	DescribedObject autoscalingv2.CrossVersionObjectReference `json:"describedObject"`
+	// Name is the custom-metrics-API metric name.
+	Name string `json:"name"`
+	// Threshold is the Value target, used when AverageValue is empty; it is
+	// ignored when AverageValue is set.
+	Threshold string `json:"threshold,omitempty"`
+	// AverageValue is the target averaged across all matching pods.
+	AverageValue string `json:"averageValue,omitempty"`
+	// Selector restricts which metric series the query matches.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// MetricAuthRef points at the Secret backing a KEDA TriggerAuthentication for
+// an ExternalMetricSource. The Secret's keys are named after the KEDA
+// secretTargetRef parameters they populate (bearerToken, ca, cert, key,
+// username, password), so only the keys relevant to Mode need to be present.
+type MetricAuthRef struct {
+	// SecretName is the Secret, in the component's namespace, holding the
+	// credential material.
+	SecretName string `json:"secretName"`
+	// Mode selects which KEDA auth parameters are wired from the Secret:
+	// "bearer" (bearerToken), "basic" (username/password), or "tls"
+	// (ca/cert/key for mTLS).
+	Mode MetricAuthMode `json:"mode"`
+}
+
+// MetricAuthMode enumerates the KEDA TriggerAuthentication shapes
+// ExternalMetricSource.AuthRef supports.
+type MetricAuthMode string
+
+const (
+	MetricAuthBearer MetricAuthMode = "bearer"
+	MetricAuthBasic  MetricAuthMode = "basic"
+	MetricAuthTLS    MetricAuthMode = "tls"
+)
+
+// ScaleMetricSpec is one entry of ComponentExtensionSpec.Metrics: a
+// component can mix a Resource metric (handled by the native HPA) with one
+// or more External/Object metrics (handled by either the native HPA against
+// the custom/external metrics API, or a KEDA ScaledObject).
+type ScaleMetricSpec struct {
+	Type     ScaleMetricSourceType `json:"type"`
+	Resource *ResourceMetricSource `json:"resource,omitempty"`
+	Pods     *PodsMetricSource     `json:"pods,omitempty"`
+	External *ExternalMetricSource `json:"external,omitempty"`
+	Object   *ObjectMetricSource   `json:"object,omitempty"`
+}
+
+// HasExternalMetric reports whether any entry in metrics requires a KEDA
+// ScaledObject rather than the native HorizontalPodAutoscaler.
+func HasExternalMetric(metrics []ScaleMetricSpec) bool {
+	for _, m := range metrics {
+		if m.Type == ExternalScaleMetricSourceType && m.External != nil {
+			return true
+		}
+	}
+	return false
+}
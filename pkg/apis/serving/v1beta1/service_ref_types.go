@@ -0,0 +1,43 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// ServiceRef lets a component (currently only PredictorSpec) point at a
+// Service owned by something other than the InferenceService itself,
+// e.g. a shared "models" namespace that hosts the actual runtime pods
+// while per-team namespaces own the InferenceService CRs. It is intended
+// to be embedded as PredictorSpec.ServiceRef.
+type ServiceRef struct {
+	// Name is the backing Service's name. When unset, the reconciler falls
+	// back to the Service it would otherwise have generated for this
+	// component.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Namespace is the backing Service's namespace. When unset or equal to
+	// the InferenceService's own namespace, no cross-namespace routing is
+	// involved and no ReferenceGrant is required.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// IsCrossNamespace reports whether ref points at a Service outside
+// isvcNamespace, the condition under which the ingress reconciler must
+// generate (or verify) a ReferenceGrant before the HTTPRoute's backendRef
+// will be honored.
+func (ref *ServiceRef) IsCrossNamespace(isvcNamespace string) bool {
+	return ref != nil && ref.Namespace != "" && ref.Namespace != isvcNamespace
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestValidateStopAnnotationTransitionNoAnnotation(t *testing.T) {
+	newIsvc := &InferenceService{}
+	oldIsvc := &InferenceService{}
+	if err := validateStopAnnotationTransition(newIsvc, oldIsvc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateStopAnnotationTransitionValidValues(t *testing.T) {
+	for _, value := range []string{"true", "false"} {
+		newIsvc := &InferenceService{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{constants.StopAnnotationKey: value},
+		}}
+		if err := validateStopAnnotationTransition(newIsvc, &InferenceService{}); err != nil {
+			t.Fatalf("unexpected error for value %q: %v", value, err)
+		}
+	}
+}
+
+func TestValidateStopAnnotationTransitionRejectsInvalidValue(t *testing.T) {
+	newIsvc := &InferenceService{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{constants.StopAnnotationKey: "yes"},
+	}}
+	if err := validateStopAnnotationTransition(newIsvc, &InferenceService{}); err == nil {
+		t.Fatalf("expected an error for an invalid stop annotation value")
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// WorkerSpec configures the worker half of a multinode (Ray-based)
+// predictor: a PodSpec override for the worker deployment plus the
+// tensor/pipeline-parallel sizing used to compute TENSOR_PARALLEL_SIZE,
+// PIPELINE_PARALLEL_SIZE and the group size AutoscalerClassRayCluster scales
+// in multiples of. The head itself stays a single-replica deployment; only
+// the worker pool scales.
+type WorkerSpec struct {
+	// PodSpec is applied to the worker deployment's pod template, the same
+	// way PredictorExtensionSpec.PodSpec is applied to the head/non-worker
+	// component.
+	// +optional
+	corev1.PodSpec `json:",inline"`
+
+	// MinReplicas is the minimum number of worker replica groups. Defaults to
+	// constants.DefaultMinReplicas, mirroring ComponentExtensionSpec.
+	// +optional
+	MinReplicas *int `json:"minReplicas,omitempty"`
+	// MaxReplicas is the maximum number of worker replica groups.
+	MaxReplicas int `json:"maxReplicas,omitempty"`
+
+	// PipelineParallelSize is the number of Ray nodes (head + workers) that
+	// make up one replica group. Defaults to constants.DefaultPipelineParallelSize.
+	// +optional
+	PipelineParallelSize *int `json:"pipelineParallelSize,omitempty"`
+	// TensorParallelSize is the number of GPUs a single Ray node uses to
+	// shard one model layer. Defaults to constants.DefaultTensorParallelSize.
+	// +optional
+	TensorParallelSize *int `json:"tensorParallelSize,omitempty"`
+
+	// AcceleratorProfile describes the GPU/accelerator resource the worker
+	// pool requests, in place of hardcoding a resource name on PodSpec.
+	// Leave unset to fall back to whatever GPU resource requests are already
+	// present on PodSpec.
+	// +optional
+	AcceleratorProfile *AcceleratorProfile `json:"acceleratorProfile,omitempty"`
+
+	// Strategy selects how the head and worker pods are deployed:
+	// constants.DeploymentStrategyDeployment (the default) or
+	// constants.DeploymentStrategyStatefulSet, which gives each pod a stable
+	// DNS name and PVC across restarts. Takes precedence over
+	// constants.DeploymentStrategyAnnotationKey when set.
+	// +optional
+	Strategy string `json:"strategy,omitempty"`
+
+	// Topology configures NUMA/interconnect-aware placement for the head and
+	// worker pods. Only consulted when TensorParallelSize > 1, since a TP=1
+	// deployment never needs its GPUs co-located.
+	// +optional
+	Topology *WorkerTopologySpec `json:"topology,omitempty"`
+
+	// Autoscaling enables AutoscalerClassRayCluster-style node-level
+	// autoscaling: an HPA (or KEDA ScaledObject) targets the head deployment
+	// on Metric, and the worker deployment's replica count is recomputed
+	// from the resulting node count rather than scaled independently.
+	// +optional
+	Autoscaling *WorkerAutoscalingSpec `json:"autoscaling,omitempty"`
+}
+
+// WorkerAutoscalingSpec bounds and drives node-level autoscaling for a
+// multinode predictor, in place of AutoscalerClassNone.
+type WorkerAutoscalingSpec struct {
+	// MinNodes is the minimum number of Ray nodes (head + workers) the
+	// replica group is scaled down to.
+	MinNodes int32 `json:"minNodes"`
+	// MaxNodes is the maximum number of Ray nodes the replica group is
+	// scaled up to.
+	MaxNodes int32 `json:"maxNodes"`
+	// Metric names the external metric (e.g. GPU utilization or queue depth)
+	// the head deployment's HPA/ScaledObject scales on.
+	Metric string `json:"metric"`
+}
+
+// WorkerTopologySpec opts a multinode predictor into NUMA/interconnect-aware
+// scheduling, so a tensor-parallel head's GPUs land on the same
+// NVLink/NVSwitch island and pipeline-parallel worker stages prefer
+// low-latency interconnect to the head.
+type WorkerTopologySpec struct {
+	// InterconnectDomainLabel is the node label whose value identifies a
+	// NUMA/interconnect domain, e.g. "kserve.io/gpu-interconnect-domain".
+	// Leave unset to disable topology-aware placement entirely.
+	// +optional
+	InterconnectDomainLabel string `json:"interconnectDomainLabel,omitempty"`
+	// RequireSameDomain makes InterconnectDomainLabel a hard scheduling
+	// requirement instead of a best-effort preference: the head requires a
+	// node advertising the label at all, and workers (up to
+	// CrossDomainTolerance of them) require the same label value as the
+	// head.
+	// +optional
+	RequireSameDomain bool `json:"requireSameDomain,omitempty"`
+	// CrossDomainTolerance is the number of worker replicas allowed to
+	// schedule outside the head's interconnect domain before
+	// RequireSameDomain's hard requirement is enforced on the rest; nil or 0
+	// means none are allowed once RequireSameDomain is set.
+	// +optional
+	CrossDomainTolerance *int32 `json:"crossDomainTolerance,omitempty"`
+}
+
+// AcceleratorProfile names the accelerator resource a head or worker pool
+// requests and, for vendors where more than one resource name is
+// acceptable on a cluster (e.g. a Habana device exposed as either
+// habana.ai/gaudi or gaudi.habana.ai depending on device-plugin version),
+// the list of resource names the controller will accept a node offering.
+type AcceleratorProfile struct {
+	// Name identifies the profile for use in head/worker compatibility
+	// validation and Warning events; it is not itself a resource name.
+	Name string `json:"name"`
+	// Count is the number of accelerator devices requested per pod.
+	Count int `json:"count"`
+	// Vendors lists the acceptable accelerator resource names for this
+	// profile, e.g. ["nvidia.com/gpu"] or ["amd.com/gpu", "habana.ai/gaudi"].
+	// The first entry is used as the resource.Requests/Limits key; the rest
+	// are only used to build the profile's node affinity.
+	// +optional
+	Vendors []string `json:"vendors,omitempty"`
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// WorkloadRef identifies the scalable workload a raw-deployment component's
+// HorizontalPodAutoscaler should target, as an alternative to the
+// Deployment KServe's own reconcilers create by default. Set this when the
+// predictor is instead deployed as a StatefulSet (needed for sharded LLM
+// serving that relies on stable pod identity) or as a custom CRD that
+// implements the `scale` subresource. A field of ComponentExtensionSpec.
+type WorkloadRef struct {
+	// APIVersion of the target resource, e.g. "apps/v1".
+	APIVersion string `json:"apiVersion"`
+	// Kind of the target resource, e.g. "StatefulSet".
+	Kind string `json:"kind"`
+	// Name of the target resource. Defaults to the component's own resource
+	// name (the same name KServe's built-in Deployment would have used)
+	// when empty.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
@@ -90,22 +90,54 @@ var (
 	AutoscalerClass                             = KServeAPIGroupName + "/autoscalerClass"
 	AutoscalerMetrics                           = KServeAPIGroupName + "/metrics"
 	TargetUtilizationPercentage                 = KServeAPIGroupName + "/targetUtilizationPercentage"
-	InitialScaleAnnotationKey                   = KnativeAutoscalingAPIGroupName + "/initial-scale"
-	MinScaleAnnotationKey                       = KnativeAutoscalingAPIGroupName + "/min-scale"
-	MaxScaleAnnotationKey                       = KnativeAutoscalingAPIGroupName + "/max-scale"
-	StopAnnotationKey                           = KServeAPIGroupName + "/stop"
-	RollOutDurationAnnotationKey                = KnativeServingAPIGroupName + "/rollout-duration"
-	KnativeOpenshiftEnablePassthroughKey        = "serving.knative.openshift.io/enablePassthrough"
-	EnableMetricAggregation                     = KServeAPIGroupName + "/enable-metric-aggregation"
-	SetPrometheusAnnotation                     = KServeAPIGroupName + "/enable-prometheus-scraping"
-	KserveContainerPrometheusPortKey            = "prometheus.kserve.io/port"
-	KServeContainerPrometheusPathKey            = "prometheus.kserve.io/path"
-	PrometheusPortAnnotationKey                 = "prometheus.io/port"
-	PrometheusPathAnnotationKey                 = "prometheus.io/path"
-	StorageReadonlyAnnotationKey                = "storage.kserve.io/readonly"
-	DefaultPrometheusPath                       = "/metrics"
-	QueueProxyAggregatePrometheusMetricsPort    = 9088
-	DefaultPodPrometheusPort                    = "9091"
+	// KedaTriggerAnnotationKey selects a KEDA trigger type for
+	// AutoscalerClassKeda components (see KedaTriggerX below), as a
+	// lighter-weight alternative to a full ComponentExtensionSpec.Metrics
+	// entry when the component only needs one trigger.
+	KedaTriggerAnnotationKey = KServeAPIGroupName + "/keda-trigger"
+	// KedaKafkaBootstrapServersAnnotationKey, KedaKafkaTopicAnnotationKey,
+	// KedaKafkaConsumerGroupAnnotationKey and KedaKafkaLagThresholdAnnotationKey
+	// configure the "kafka" KedaTriggerAnnotationKey value; unlike the
+	// Prometheus-shaped ExternalMetricSource, KEDA's kafka scaler needs these
+	// as distinct metadata keys rather than a single query string.
+	KedaKafkaBootstrapServersAnnotationKey = KServeAPIGroupName + "/keda-kafka-bootstrap-servers"
+	KedaKafkaTopicAnnotationKey            = KServeAPIGroupName + "/keda-kafka-topic"
+	KedaKafkaConsumerGroupAnnotationKey    = KServeAPIGroupName + "/keda-kafka-consumer-group"
+	KedaKafkaLagThresholdAnnotationKey     = KServeAPIGroupName + "/keda-kafka-lag-threshold"
+	// InferenceGraphDriftedAnnotationKey records the expected-template hash
+	// the InferenceGraph controller last rolled a Knative Revision for,
+	// written alongside the drift Event so `kubectl describe` shows why the
+	// Revision changed without the user having edited the InferenceGraph.
+	InferenceGraphDriftedAnnotationKey = KServeAPIGroupName + "/drifted"
+	// InferenceGraphDisableDriftAnnotationKey opts an InferenceGraph out of
+	// drift detection, for users who patch its generated Knative Service out
+	// of band and don't want the controller reverting or re-rolling it.
+	InferenceGraphDisableDriftAnnotationKey = KServeAPIGroupName + "/disable-drift"
+	// InferenceGraphRevisionHashAnnotationKey is written onto the generated
+	// Knative Service's RevisionTemplate to force a new Revision whenever the
+	// expected-template hash changes, since Knative only rolls a Revision
+	// when the template itself differs.
+	InferenceGraphRevisionHashAnnotationKey  = KServeAPIGroupName + "/revision-hash"
+	InitialScaleAnnotationKey                = KnativeAutoscalingAPIGroupName + "/initial-scale"
+	MinScaleAnnotationKey                    = KnativeAutoscalingAPIGroupName + "/min-scale"
+	MaxScaleAnnotationKey                    = KnativeAutoscalingAPIGroupName + "/max-scale"
+	StopAnnotationKey                        = KServeAPIGroupName + "/stop"
+	RollOutDurationAnnotationKey             = KnativeServingAPIGroupName + "/rollout-duration"
+	KnativeOpenshiftEnablePassthroughKey     = "serving.knative.openshift.io/enablePassthrough"
+	EnableMetricAggregation                  = KServeAPIGroupName + "/enable-metric-aggregation"
+	SetPrometheusAnnotation                  = KServeAPIGroupName + "/enable-prometheus-scraping"
+	KserveContainerPrometheusPortKey         = "prometheus.kserve.io/port"
+	KServeContainerPrometheusPathKey         = "prometheus.kserve.io/path"
+	PrometheusPortAnnotationKey              = "prometheus.io/port"
+	PrometheusPathAnnotationKey              = "prometheus.io/path"
+	StorageReadonlyAnnotationKey             = "storage.kserve.io/readonly"
+	DefaultPrometheusPath                    = "/metrics"
+	QueueProxyAggregatePrometheusMetricsPort = 9088
+	DefaultPodPrometheusPort                 = "9091"
+	// StorageSecretNameAnnotationKey selects the Secret the storage-initializer
+	// credentials builder reads for this InferenceService, overriding the
+	// storage-initializer config's cluster-wide default secret name.
+	StorageSecretNameAnnotationKey = KServeAPIGroupName + "/storageSecretName"
 )
 
 // InferenceService Internal Annotations
@@ -143,6 +175,60 @@ const (
 	ODHRouteEnabled                = "exposed"
 	ServingCertSecretSuffix        = "-serving-cert"
 	OpenshiftServingCertAnnotation = "service.beta.openshift.io/serving-cert-secret-name"
+	// RawAuthModeAnnotationKey selects a raw-deployment authn/authz mechanism
+	// other than the OpenShift-specific oauth-proxy sidecar (ODHKserveRawAuth).
+	// Currently only RawAuthModeJWT is recognized; any other/empty value keeps
+	// the existing oauth-proxy behavior.
+	RawAuthModeAnnotationKey = "serving.kserve.io/auth-mode"
+	RawAuthModeJWT           = "jwt"
+	// RawAuthJWKSURIAnnotationKey and RawAuthJWTIssuerAnnotationKey configure
+	// the Envoy JWT authentication filter injected when RawAuthModeAnnotationKey
+	// is RawAuthModeJWT; RawAuthJWTAudiencesAnnotationKey is a comma-separated
+	// list of accepted `aud` claim values.
+	RawAuthJWKSURIAnnotationKey      = "serving.kserve.io/jwt-jwks-uri"
+	RawAuthJWTIssuerAnnotationKey    = "serving.kserve.io/jwt-issuer"
+	RawAuthJWTAudiencesAnnotationKey = "serving.kserve.io/jwt-audiences"
+	// ODHEnableJWTAuthAnnotationKey is an ODH-namespaced alternative trigger
+	// for JWT auth mode, equivalent to RawAuthModeAnnotationKey ==
+	// RawAuthModeJWT, so an ODH-style manifest can opt in without adopting
+	// the serving.kserve.io/auth-mode annotation. Setting both
+	// ODHKserveRawAuth (the oauth-proxy sidecar) and this annotation to a
+	// truthy value on the same InferenceService is rejected by the
+	// validation webhook, since the two sidecars can't both terminate the
+	// same inbound port.
+	ODHEnableJWTAuthAnnotationKey = "security.opendatahub.io/enable-jwt-auth"
+	// RawAuthJWTForwardPayloadHeaderAnnotationKey overrides the header the
+	// JWT filter copies the validated payload into (default
+	// DefaultJWTForwardPayloadHeader).
+	RawAuthJWTForwardPayloadHeaderAnnotationKey = "serving.kserve.io/jwt-forward-payload-header"
+	// RawAuthJWTClaimToHeaderAnnotationKey is a comma-separated list of
+	// "claim:header" pairs, e.g. "sub:x-user-id,email:x-user-email", copying
+	// individual JWT claims onto their own request headers for downstream
+	// services that don't want to parse the full forwarded payload.
+	RawAuthJWTClaimToHeaderAnnotationKey = "serving.kserve.io/jwt-claim-to-header"
+	// RawAuthJWTRefreshIntervalAnnotationKey overrides how often the JWKS is
+	// refetched from RawAuthJWKSURIAnnotationKey (default
+	// DefaultJWTRefreshInterval). A duration string parseable by
+	// time.ParseDuration, e.g. "10m".
+	RawAuthJWTRefreshIntervalAnnotationKey = "serving.kserve.io/jwt-refresh-interval"
+	// RawAuthJWTAllowedSubjectsAnnotationKey and
+	// RawAuthJWTAllowedGroupsAnnotationKey are comma-separated allow-lists
+	// matched against the validated JWT's `sub`/`groups` claims by the RBAC
+	// filter chained after JWT authentication: a token presents a valid
+	// signature but is still rejected unless its subject or one of its groups
+	// appears in one of these lists. Neither list configured denies every
+	// request, the same deny-by-default posture as an InferenceGraph with no
+	// AllowedGroups/AllowedServiceAccounts.
+	RawAuthJWTAllowedSubjectsAnnotationKey = "serving.kserve.io/jwt-allowed-subjects"
+	RawAuthJWTAllowedGroupsAnnotationKey   = "serving.kserve.io/jwt-allowed-groups"
+)
+
+// DefaultJWTForwardPayloadHeader and DefaultJWTRefreshInterval are the
+// rawauth JWT sidecar's defaults when their respective annotations are
+// unset.
+const (
+	DefaultJWTForwardPayloadHeader = "x-jwt-claims"
+	DefaultJWTRefreshInterval      = "5m"
 )
 
 // StorageSpec Constants
@@ -181,6 +267,30 @@ var (
 var (
 	AutoscalerClassHPA      AutoscalerClassType = "hpa"
 	AutoscalerClassExternal AutoscalerClassType = "external"
+	AutoscalerClassKeda     AutoscalerClassType = "keda"
+	// AutoscalerClassNone disables autoscaling entirely: the component stays
+	// at MinReplicas and no HPA/ScaledObject/other autoscaler object is
+	// created. Used today by multinode WorkerSpec predictors, where scaling
+	// the head and worker deployments independently (as plain HPA would)
+	// would break the tensor/pipeline-parallel Ray cluster they form.
+	AutoscalerClassNone AutoscalerClassType = "none"
+	// AutoscalerClassRayCluster scales a WorkerSpec-enabled predictor's head
+	// and worker deployments together, in fixed multiples of
+	// PipelineParallelSize * TensorParallelSize, in place of
+	// AutoscalerClassNone.
+	AutoscalerClassRayCluster AutoscalerClassType = "rayCluster"
+)
+
+// KedaTriggerType is the KedaTriggerAnnotationKey value selecting which KEDA
+// trigger an AutoscalerClassKeda component scales on, as an alternative to a
+// ComponentExtensionSpec.Metrics entry.
+type KedaTriggerType string
+
+const (
+	KedaTriggerPrometheus KedaTriggerType = "prometheus"
+	KedaTriggerCPU        KedaTriggerType = "cpu"
+	KedaTriggerMemory     KedaTriggerType = "memory"
+	KedaTriggerKafka      KedaTriggerType = "kafka"
 )
 
 // Autoscaler Metrics
@@ -197,6 +307,9 @@ var (
 var AutoscalerAllowedClassList = []AutoscalerClassType{
 	AutoscalerClassHPA,
 	AutoscalerClassExternal,
+	AutoscalerClassKeda,
+	AutoscalerClassNone,
+	AutoscalerClassRayCluster,
 }
 
 // Autoscaler Metrics Allowed List
@@ -224,10 +337,12 @@ var (
 
 // GPU Constants
 const (
-	NvidiaGPUResourceType = "nvidia.com/gpu"
-	AmdGPUResourceType    = "amd.com/gpu"
-	IntelGPUResourceType  = "intel.com/gpu"
-	GaudiGPUResourceType  = "habana.ai/gaudi"
+	NvidiaGPUResourceType    = "nvidia.com/gpu"
+	AmdGPUResourceType       = "amd.com/gpu"
+	IntelGPUResourceType     = "intel.com/gpu"
+	GaudiGPUResourceType     = "habana.ai/gaudi"
+	IntelI915GPUResourceType = "gpu.intel.com/i915"
+	AWSNeuronResourceType    = "aws.amazon.com/neuron"
 )
 
 var (
@@ -239,6 +354,8 @@ var GPUResourceTypeList = []string{
 	AmdGPUResourceType,
 	IntelGPUResourceType,
 	GaudiGPUResourceType,
+	IntelI915GPUResourceType,
+	AWSNeuronResourceType,
 }
 
 // InferenceService Environment Variables
@@ -291,14 +408,36 @@ const (
 
 // InferenceService protocol enums
 const (
-	ProtocolV1         InferenceServiceProtocol = "v1"
-	ProtocolV2         InferenceServiceProtocol = "v2"
-	ProtocolGRPCV1     InferenceServiceProtocol = "grpc-v1"
-	ProtocolGRPCV2     InferenceServiceProtocol = "grpc-v2"
+	ProtocolV1          InferenceServiceProtocol = "v1"
+	ProtocolV2          InferenceServiceProtocol = "v2"
+	ProtocolGRPCV1      InferenceServiceProtocol = "grpc-v1"
+	ProtocolGRPCV2      InferenceServiceProtocol = "grpc-v2"
+	ProtocolV2SSE       InferenceServiceProtocol = "v2-sse"
+	ProtocolV2WebSocket InferenceServiceProtocol = "v2-websocket"
+	ProtocolOpenAI      InferenceServiceProtocol = "openai"
+	ProtocolGRPCStream  InferenceServiceProtocol = "grpc-stream"
+	// ProtocolAutoDetect tells the router/transformer to negotiate the
+	// actual protocol per-endpoint instead of assuming a fixed one; see
+	// pkg/protocol.NegotiateProtocol.
+	ProtocolAutoDetect InferenceServiceProtocol = "auto"
 	ProtocolUnknown    InferenceServiceProtocol = ""
 	ProtocolVersionENV                          = "PROTOCOL_VERSION"
 )
 
+// IsStreamingProtocol reports whether protocol is one of the streaming
+// variants (ProtocolV2SSE, ProtocolV2WebSocket, ProtocolOpenAI,
+// ProtocolGRPCStream), which keep the connection open past the first
+// response chunk and so need buffering, timeout, and ingress handling
+// distinct from the request/response protocols.
+func IsStreamingProtocol(protocol InferenceServiceProtocol) bool {
+	switch protocol {
+	case ProtocolV2SSE, ProtocolV2WebSocket, ProtocolOpenAI, ProtocolGRPCStream:
+		return true
+	default:
+		return false
+	}
+}
+
 // InferenceService Endpoint Ports
 const (
 	InferenceServiceDefaultHttpPort     = "8080"
@@ -466,6 +605,12 @@ const (
 	SupportedModelMLFlow      = "mlflow"
 )
 
+// SupportedModelLLM aliases SupportedModelHuggingFace: vLLM/TGI-style LLM
+// runtimes declare the huggingface model format, so callers that only care
+// whether a ServingRuntime is LLM-capable can test against this name
+// instead of hard-coding the underlying format string.
+const SupportedModelLLM = SupportedModelHuggingFace
+
 // opendatahub rawDeployment Auth
 const (
 	OauthProxyPort                  = 8443
@@ -477,6 +622,19 @@ const (
 	DefaultServiceAccount           = "default"
 )
 
+// JWT auth sidecar constants, the portable (non-OpenShift) alternative to the
+// oauth-proxy block above.
+const (
+	JWTAuthEnvoyContainerName            = "jwt-auth-envoy"
+	JWTAuthEnvoyImage                    = "envoyproxy/envoy:v1.29-latest"
+	JWTAuthEnvoyPort                     = 8443
+	JWTAuthEnvoyBootstrapConfigMapSuffix = "-jwt-auth-envoy-config"
+	JWTAuthEnvoyResourceMemoryLimit      = "128Mi"
+	JWTAuthEnvoyResourceCPULimit         = "200m"
+	JWTAuthEnvoyResourceMemoryRequest    = "64Mi"
+	JWTAuthEnvoyResourceCPURequest       = "100m"
+)
+
 type ProtocolVersion int
 
 const (
@@ -485,6 +643,10 @@ const (
 	V2
 	GRPCV1
 	GRPCV2
+	V2SSE
+	V2WebSocket
+	OpenAI
+	GRPCStream
 	Unknown
 )
 
@@ -514,6 +676,24 @@ const (
 const (
 	TensorParallelSizeEnvName   = "TENSOR_PARALLEL_SIZE"
 	PipelineParallelSizeEnvName = "PIPELINE_PARALLEL_SIZE"
+	RayNodeCountEnvName         = "RAY_NODE_COUNT"
+)
+
+// GPUAllocationPolicyAnnotationKey lets a single InferenceService override
+// the cluster-wide inferenceservice-config ConfigMap's default gpuallocator
+// policy name for its own WorkerSpec.
+const GPUAllocationPolicyAnnotationKey = "serving.kserve.io/gpu-allocation-policy"
+
+// DeploymentStrategyAnnotationKey opts a multinode (WorkerSpec-enabled)
+// predictor into the StatefulSet deployment strategy; any other value, or
+// WorkerSpec.Strategy taking precedence when set, keeps the default
+// two-Deployment strategy.
+const DeploymentStrategyAnnotationKey = "serving.kserve.io/deployment-strategy"
+
+// Deployment Strategy values
+const (
+	DeploymentStrategyDeployment  = "Deployment"
+	DeploymentStrategyStatefulSet = "StatefulSet"
 )
 
 // Model Parallel Options Default value
@@ -528,6 +708,31 @@ var (
 	MultiNodeHead         = "head"
 )
 
+// Locality/topology labels read off a pod (typically populated via the
+// downward API from the node's own topology.kubernetes.io/* labels) to
+// determine which zone a request originated from.
+const (
+	LocalityRegionLabel  = "topology.kubernetes.io/region"
+	LocalityZoneLabel    = "topology.kubernetes.io/zone"
+	LocalitySubzoneLabel = "topology.kubernetes.io/subzone"
+)
+
+// LocalityRoutingAnnotationKey controls whether the transformer->predictor
+// and inference-graph routing paths prefer endpoints in the caller's own
+// zone over an arbitrary one.
+const LocalityRoutingAnnotationKey = "serving.kserve.io/locality-routing"
+
+// Locality routing modes for LocalityRoutingAnnotationKey. PreferLocal
+// weights same-zone endpoints higher but still falls back cross-zone;
+// StrictLocal never crosses zones, returning a 503 when the local zone has
+// no Ready endpoint; Disabled (the default when the annotation is unset)
+// keeps today's zone-agnostic behavior.
+const (
+	LocalityRoutingPreferLocal = "PreferLocal"
+	LocalityRoutingStrictLocal = "StrictLocal"
+	LocalityRoutingDisabled    = "Disabled"
+)
+
 // OpenShift constants
 const (
 	OpenShiftServiceCaConfigMapName = "openshift-service-ca.crt"
@@ -632,6 +837,16 @@ func CanaryServiceName(name string, component InferenceServiceComponent) string
 	return name + "-" + component.String() + "-" + InferenceServiceCanary
 }
 
+// StorageSecretNameAnnotationKeyForComponent returns the per-component
+// override of StorageSecretNameAnnotationKey, e.g.
+// "serving.kserve.io/storageSecretName-predictor", so a transformer can pull
+// its artifacts from a different Secret than the predictor in the same
+// InferenceService. Callers fall back to StorageSecretNameAnnotationKey when
+// this key is absent from the ISVC's annotations.
+func StorageSecretNameAnnotationKeyForComponent(component InferenceServiceComponent) string {
+	return StorageSecretNameAnnotationKey + "-" + component.String()
+}
+
 func ModelConfigName(inferenceserviceName string, shardId int) string {
 	return fmt.Sprintf("modelconfig-%s-%d", inferenceserviceName, shardId)
 }
@@ -654,6 +869,32 @@ func ExplainPath(name string) string {
 	return fmt.Sprintf("/v1/models/%s:explain", name)
 }
 
+// GenerateStreamPath is the v2 streaming-inference path: a server-sent-event
+// or websocket stream of incremental generation results for name, as
+// opposed to PredictPath's single buffered response.
+func GenerateStreamPath(name string) string {
+	return fmt.Sprintf("/v2/models/%s/generate_stream", name)
+}
+
+// ChatCompletionsPath is the OpenAI-compatible chat completions endpoint
+// vLLM/TGI-style runtimes expose under ProtocolOpenAI.
+func ChatCompletionsPath() string {
+	return "/v1/chat/completions"
+}
+
+// CompletionsPath is the OpenAI-compatible (non-chat) completions endpoint
+// vLLM/TGI-style runtimes expose under ProtocolOpenAI.
+func CompletionsPath() string {
+	return "/v1/completions"
+}
+
+// StreamPrefix matches GenerateStreamPath, ChatCompletionsPath, and
+// CompletionsPath, the same way PredictPrefix/ExplainPrefix match their
+// respective path families.
+func StreamPrefix() string {
+	return "^(/v2/models/[\\w-]+/generate_stream|/v1/chat/completions|/v1/completions)$"
+}
+
 func PredictPrefix() string {
 	return "^/v1/models/[\\w-]+(:predict)?"
 }
@@ -687,6 +928,32 @@ func TransformerURL(metadata metav1.ObjectMeta, isCanary bool) string {
 	return fmt.Sprintf("%s.%s", serviceName, metadata.Namespace)
 }
 
+// PredictorZoneURL behaves like PredictorURL, but when locality is
+// LocalityRoutingPreferLocal or LocalityRoutingStrictLocal it appends the
+// caller's zone as a query-style selector the sidecar/agent forwarding the
+// request can read to prefer a same-zone endpoint. The underlying routing
+// decision (which endpoint actually gets picked) is enforced by the
+// Istio DestinationRule localityLbSetting or Service trafficDistribution
+// generated alongside the predictor, not by this string; callerZone is
+// typically read from a pod's topology.kubernetes.io/zone label via the
+// downward API.
+func PredictorZoneURL(metadata metav1.ObjectMeta, isCanary bool, locality string, callerZone string) string {
+	return zoneAwareURL(PredictorURL(metadata, isCanary), locality, callerZone)
+}
+
+// TransformerZoneURL is TransformerURL's locality-aware counterpart; see
+// PredictorZoneURL.
+func TransformerZoneURL(metadata metav1.ObjectMeta, isCanary bool, locality string, callerZone string) string {
+	return zoneAwareURL(TransformerURL(metadata, isCanary), locality, callerZone)
+}
+
+func zoneAwareURL(url string, locality string, callerZone string) string {
+	if callerZone == "" || (locality != LocalityRoutingPreferLocal && locality != LocalityRoutingStrictLocal) {
+		return url
+	}
+	return fmt.Sprintf("%s?zone=%s", url, callerZone)
+}
+
 // Should only match 1..65535, but for simplicity it matches 0-99999.
 const portMatch = `(?::\d{1,5})?`
 
@@ -721,6 +988,14 @@ func GetProtocolVersionInt(protocol InferenceServiceProtocol) ProtocolVersion {
 		return GRPCV1
 	case ProtocolGRPCV2:
 		return GRPCV2
+	case ProtocolV2SSE:
+		return V2SSE
+	case ProtocolV2WebSocket:
+		return V2WebSocket
+	case ProtocolOpenAI:
+		return OpenAI
+	case ProtocolGRPCStream:
+		return GRPCStream
 	default:
 		return Unknown
 	}
@@ -736,6 +1011,14 @@ func GetProtocolVersionString(protocol ProtocolVersion) InferenceServiceProtocol
 		return ProtocolGRPCV1
 	case GRPCV2:
 		return ProtocolGRPCV2
+	case V2SSE:
+		return ProtocolV2SSE
+	case V2WebSocket:
+		return ProtocolV2WebSocket
+	case OpenAI:
+		return ProtocolOpenAI
+	case GRPCStream:
+		return ProtocolGRPCStream
 	default:
 		return ProtocolUnknown
 	}
@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// AuthSpec configures how the router authenticates itself to a node's
+// downstream ServiceURL, as an alternative to today's unauthenticated
+// calls. Exactly one of BearerTokenSecretRef, OAuth2, or MTLSSecretRef
+// should be set; the reconciler should favor the first one set in that
+// order if more than one is. A field of RouterConfig.
+type AuthSpec struct {
+	// BearerTokenSecretRef names a Secret whose "token" key is sent as a
+	// static "Authorization: Bearer <token>" header on every downstream
+	// call, via the router's --auth-header flag.
+	// +optional
+	BearerTokenSecretRef *corev1.LocalObjectReference `json:"bearerTokenSecretRef,omitempty"`
+	// OAuth2 configures the router to fetch a bearer token via the OAuth2
+	// client-credentials grant before each downstream call.
+	// +optional
+	OAuth2 *OAuth2Spec `json:"oauth2,omitempty"`
+	// MTLSSecretRef names a Secret with "tls.crt"/"tls.key" keys the router
+	// presents as its client certificate to downstream ServiceURLs, via the
+	// router's --client-cert/--client-key flags.
+	// +optional
+	MTLSSecretRef *corev1.LocalObjectReference `json:"mtlsSecretRef,omitempty"`
+}
+
+// OAuth2Spec is RouterConfig.Auth.OAuth2: the OAuth2 client-credentials
+// source the router exchanges for a downstream bearer token.
+type OAuth2Spec struct {
+	// TokenURL is the OAuth2 token endpoint, passed to the router as
+	// --oauth-token-url.
+	TokenURL string `json:"tokenURL"`
+	// ClientCredentialsSecretRef names a Secret with "clientID"/
+	// "clientSecret" keys used for the client-credentials grant against
+	// TokenURL.
+	ClientCredentialsSecretRef corev1.LocalObjectReference `json:"clientCredentialsSecretRef"`
+}
+
+// RouterConfig is the per-graph override of the controller ConfigMap's
+// router defaults. InferenceGraphSpec has no field of this type.
+type RouterConfig struct {
+	// PropagateHeaders overrides the controller ConfigMap's
+	// router.headers.propagate default (e.g. "Authorization,Intuit_tid")
+	// for this graph alone.
+	// +optional
+	PropagateHeaders []string `json:"propagateHeaders,omitempty"`
+	// Auth configures how the router authenticates to downstream
+	// ServiceURL steps.
+	// +optional
+	Auth *AuthSpec `json:"auth,omitempty"`
+}
+
+// authSecretVolumeName is the volume/mount name used for whichever Secret
+// AuthSpec resolves to, so only one of the three auth modes is ever mounted
+// at a time.
+const authSecretVolumeName = "router-auth"
+
+// authSecretMountPath is where authSecretVolumeName is mounted; each auth
+// mode's env/flag values are built relative to this single path.
+const authSecretMountPath = "/etc/odh/router-auth"
+
+// BuildAuthEnvAndArgs translates auth into the router container's env vars
+// and CLI args for whichever mode is configured, preferring
+// BearerTokenSecretRef, then OAuth2, then MTLSSecretRef when more than one
+// is set. Returns nil, nil when auth is nil.
+func BuildAuthEnvAndArgs(auth *AuthSpec) (env []corev1.EnvVar, args []string) {
+	if auth == nil {
+		return nil, nil
+	}
+	switch {
+	case auth.BearerTokenSecretRef != nil:
+		return nil, []string{"--auth-header", "@" + authSecretMountPath + "/token"}
+	case auth.OAuth2 != nil:
+		env = []corev1.EnvVar{
+			{Name: "OAUTH_CLIENT_ID_FILE", Value: authSecretMountPath + "/clientID"},
+			{Name: "OAUTH_CLIENT_SECRET_FILE", Value: authSecretMountPath + "/clientSecret"},
+		}
+		return env, []string{"--oauth-token-url", auth.OAuth2.TokenURL}
+	case auth.MTLSSecretRef != nil:
+		return nil, []string{
+			"--client-cert", authSecretMountPath + "/tls.crt",
+			"--client-key", authSecretMountPath + "/tls.key",
+		}
+	default:
+		return nil, nil
+	}
+}
+
+// BuildAuthVolume builds the Secret volume backing BuildAuthEnvAndArgs'
+// file paths, sourced from whichever of auth's SecretRef fields is set.
+// Returns the zero Volume and ok=false when auth is nil or sets none.
+func BuildAuthVolume(auth *AuthSpec) (volume corev1.Volume, ok bool) {
+	if auth == nil {
+		return corev1.Volume{}, false
+	}
+	var secretName string
+	switch {
+	case auth.BearerTokenSecretRef != nil:
+		secretName = auth.BearerTokenSecretRef.Name
+	case auth.OAuth2 != nil:
+		secretName = auth.OAuth2.ClientCredentialsSecretRef.Name
+	case auth.MTLSSecretRef != nil:
+		secretName = auth.MTLSSecretRef.Name
+	default:
+		return corev1.Volume{}, false
+	}
+	return corev1.Volume{
+		Name:         authSecretVolumeName,
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: secretName}},
+	}, true
+}
+
+// BuildAuthVolumeMount is the router container's mount of BuildAuthVolume.
+func BuildAuthVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{Name: authSecretVolumeName, MountPath: authSecretMountPath}
+}
+
+// ResolvedAuthStatus summarizes, for InferenceGraphStatus, which auth mode
+// (if any) is currently active, without leaking secret contents into
+// Status. "" means no auth is configured.
+func ResolvedAuthStatus(auth *AuthSpec) string {
+	switch {
+	case auth == nil:
+		return ""
+	case auth.BearerTokenSecretRef != nil:
+		return "BearerToken"
+	case auth.OAuth2 != nil:
+		return "OAuth2"
+	case auth.MTLSSecretRef != nil:
+		return "MTLS"
+	default:
+		return ""
+	}
+}
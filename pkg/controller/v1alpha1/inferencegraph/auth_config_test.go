@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestBuildAuthEnvAndArgsNil(t *testing.T) {
+	env, args := BuildAuthEnvAndArgs(nil)
+	if env != nil || args != nil {
+		t.Fatalf("expected nil,nil for a nil AuthSpec, got %v,%v", env, args)
+	}
+}
+
+func TestBuildAuthEnvAndArgsBearerToken(t *testing.T) {
+	auth := &AuthSpec{BearerTokenSecretRef: &corev1.LocalObjectReference{Name: "tok"}}
+	env, args := BuildAuthEnvAndArgs(auth)
+	if env != nil {
+		t.Fatalf("expected no env vars for bearer-token auth, got %v", env)
+	}
+	if len(args) != 2 || args[0] != "--auth-header" {
+		t.Fatalf("unexpected args: %v", args)
+	}
+}
+
+func TestBuildAuthEnvAndArgsOAuth2(t *testing.T) {
+	auth := &AuthSpec{OAuth2: &OAuth2Spec{TokenURL: "https://idp.example.com/token"}}
+	env, args := BuildAuthEnvAndArgs(auth)
+	if len(env) != 2 {
+		t.Fatalf("expected two OAuth2 env vars, got %v", env)
+	}
+	if len(args) != 2 || args[0] != "--oauth-token-url" || args[1] != "https://idp.example.com/token" {
+		t.Fatalf("unexpected args: %v", args)
+	}
+}
+
+func TestBuildAuthEnvAndArgsMTLS(t *testing.T) {
+	auth := &AuthSpec{MTLSSecretRef: &corev1.LocalObjectReference{Name: "mtls"}}
+	env, args := BuildAuthEnvAndArgs(auth)
+	if env != nil {
+		t.Fatalf("expected no env vars for mTLS auth, got %v", env)
+	}
+	if len(args) != 4 || args[0] != "--client-cert" || args[2] != "--client-key" {
+		t.Fatalf("unexpected args: %v", args)
+	}
+}
+
+func TestBuildAuthEnvAndArgsPrefersBearerOverOthers(t *testing.T) {
+	auth := &AuthSpec{
+		BearerTokenSecretRef: &corev1.LocalObjectReference{Name: "tok"},
+		OAuth2:               &OAuth2Spec{TokenURL: "https://idp.example.com/token"},
+		MTLSSecretRef:        &corev1.LocalObjectReference{Name: "mtls"},
+	}
+	_, args := BuildAuthEnvAndArgs(auth)
+	if len(args) != 2 || args[0] != "--auth-header" {
+		t.Fatalf("expected BearerTokenSecretRef to win when all three are set, got args=%v", args)
+	}
+}
+
+func TestBuildAuthVolumeNil(t *testing.T) {
+	if vol, ok := BuildAuthVolume(nil); ok || vol.Name != "" {
+		t.Fatalf("expected ok=false and a zero Volume for a nil AuthSpec, got %+v, %v", vol, ok)
+	}
+	if vol, ok := BuildAuthVolume(&AuthSpec{}); ok || vol.Name != "" {
+		t.Fatalf("expected ok=false for an AuthSpec with no mode set, got %+v, %v", vol, ok)
+	}
+}
+
+func TestBuildAuthVolumeEachMode(t *testing.T) {
+	cases := []struct {
+		name       string
+		auth       *AuthSpec
+		secretName string
+	}{
+		{name: "bearer", auth: &AuthSpec{BearerTokenSecretRef: &corev1.LocalObjectReference{Name: "bearer-secret"}}, secretName: "bearer-secret"},
+		{name: "oauth2", auth: &AuthSpec{OAuth2: &OAuth2Spec{ClientCredentialsSecretRef: corev1.LocalObjectReference{Name: "oauth-secret"}}}, secretName: "oauth-secret"},
+		{name: "mtls", auth: &AuthSpec{MTLSSecretRef: &corev1.LocalObjectReference{Name: "mtls-secret"}}, secretName: "mtls-secret"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			vol, ok := BuildAuthVolume(tc.auth)
+			if !ok {
+				t.Fatalf("expected ok=true")
+			}
+			if vol.Name != authSecretVolumeName {
+				t.Fatalf("unexpected volume name: %q", vol.Name)
+			}
+			if vol.Secret == nil || vol.Secret.SecretName != tc.secretName {
+				t.Fatalf("expected secret %q, got %+v", tc.secretName, vol.Secret)
+			}
+		})
+	}
+}
+
+func TestBuildAuthVolumeMount(t *testing.T) {
+	mount := BuildAuthVolumeMount()
+	if mount.Name != authSecretVolumeName || mount.MountPath != authSecretMountPath {
+		t.Fatalf("unexpected mount: %+v", mount)
+	}
+}
+
+func TestResolvedAuthStatus(t *testing.T) {
+	cases := []struct {
+		name string
+		auth *AuthSpec
+		want string
+	}{
+		{name: "nil", auth: nil, want: ""},
+		{name: "empty", auth: &AuthSpec{}, want: ""},
+		{name: "bearer", auth: &AuthSpec{BearerTokenSecretRef: &corev1.LocalObjectReference{Name: "t"}}, want: "BearerToken"},
+		{name: "oauth2", auth: &AuthSpec{OAuth2: &OAuth2Spec{TokenURL: "u"}}, want: "OAuth2"},
+		{name: "mtls", auth: &AuthSpec{MTLSSecretRef: &corev1.LocalObjectReference{Name: "m"}}, want: "MTLS"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ResolvedAuthStatus(tc.auth); got != tc.want {
+				t.Fatalf("ResolvedAuthStatus() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"strconv"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"knative.dev/serving/pkg/apis/autoscaling"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// AutoScalingSpec is the Raw-mode counterpart of the
+// autoscaling.knative.dev annotations the Serverless path already honors,
+// so a raw-deployment graph's router gets the same min/max/target/metric
+// control without requiring Knative. InferenceGraphSpec has no field of
+// this type.
+type AutoScalingSpec struct {
+	// MinReplicas defaults to 1 when unset; set to 0 to allow the router to
+	// scale to zero (requires KEDA, since a plain HPA cannot scale below 1).
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas defaults to MinReplicas (i.e. no autoscaling) when unset.
+	// +optional
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+	// Metric selects what the router scales on: "cpu" or "memory" (a native
+	// HPA Resource metric), or "rps" or "concurrency" (a KEDA Prometheus
+	// trigger on the router's request rate or in-flight concurrency).
+	// Defaults to "cpu".
+	// +optional
+	Metric constants.AutoScalerKPAMetricsType `json:"metric,omitempty"`
+	// Target is the per-replica target value: a utilization percentage for
+	// Metric=cpu/memory, or the KEDA trigger threshold for
+	// Metric=rps/concurrency. Defaults to constants.DefaultCPUUtilization for
+	// cpu/memory and to 100 for rps/concurrency.
+	// +optional
+	Target *int32 `json:"target,omitempty"`
+}
+
+// minScaleBackwardCompatAnnotation is honored by ResolveMinReplicas for
+// graphs created before AutoScalingSpec existed, so an operator's existing
+// "serving.kserve.io/min-scale" annotation keeps working unchanged.
+const minScaleBackwardCompatAnnotation = constants.KServeAPIGroupName + "/min-scale"
+
+// ResolveMinReplicas returns spec's MinReplicas, falling back to the
+// metadata.annotations["serving.kserve.io/min-scale"] value for backward
+// compatibility when spec is nil or sets no MinReplicas, and finally to 1.
+func ResolveMinReplicas(spec *AutoScalingSpec, annotations map[string]string) int32 {
+	if spec != nil && spec.MinReplicas != nil {
+		return *spec.MinReplicas
+	}
+	if value, err := strconv.Atoi(annotations[minScaleBackwardCompatAnnotation]); err == nil && value >= 0 {
+		return int32(value)
+	}
+	return 1
+}
+
+// ResolveMaxReplicas returns spec's MaxReplicas, falling back to
+// minReplicas (i.e. a fixed replica count) when spec is nil or sets no
+// MaxReplicas.
+func ResolveMaxReplicas(spec *AutoScalingSpec, minReplicas int32) int32 {
+	if spec != nil && spec.MaxReplicas > 0 {
+		return spec.MaxReplicas
+	}
+	return minReplicas
+}
+
+// autoscalingAnnotations renders spec as the same autoscaling.knative.dev
+// annotations BuildRouterHPA/BuildRouterScaledObject already read, so
+// AutoScalingSpec reuses those builders instead of duplicating their HPA/
+// ScaledObject construction logic.
+func autoscalingAnnotations(spec *AutoScalingSpec) map[string]string {
+	metric := constants.AutoScalerKPAMetricsType("cpu")
+	if spec != nil && spec.Metric != "" {
+		metric = spec.Metric
+	}
+	annotations := map[string]string{autoscaling.MetricAnnotationKey: string(metric)}
+	if spec != nil && spec.Target != nil {
+		annotations[autoscaling.TargetAnnotationKey] = strconv.Itoa(int(*spec.Target))
+	}
+	return annotations
+}
+
+// BuildRouterAutoscaling resolves an InferenceGraph's owner-referenced
+// router HPA and, when Metric needs it (see needsScaledObject), its
+// ScaledObject, stamping both with an OwnerReference to owner so deleting
+// the InferenceGraph cleans them up via Kubernetes garbage collection. The
+// ScaledObject return value is nil when spec's metric has a native HPA
+// equivalent.
+func BuildRouterAutoscaling(componentMeta metav1.ObjectMeta, spec *AutoScalingSpec, owner metav1.OwnerReference) (*autoscalingv2.HorizontalPodAutoscaler, *unstructured.Unstructured) {
+	minReplicas := ResolveMinReplicas(spec, componentMeta.Annotations)
+	maxReplicas := ResolveMaxReplicas(spec, minReplicas)
+
+	meta := componentMeta.DeepCopy()
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	for k, v := range autoscalingAnnotations(spec) {
+		meta.Annotations[k] = v
+	}
+	meta.OwnerReferences = append(meta.OwnerReferences, owner)
+
+	hpa := BuildRouterHPA(*meta, minReplicas, maxReplicas)
+	scaledObject := BuildRouterScaledObject(*meta, minReplicas, maxReplicas)
+	return hpa, scaledObject
+}
+
+// AutoScalingStatus holds the router's current desired-replica count,
+// independent of whether it came from a plain HPA or a KEDA ScaledObject.
+// InferenceGraphStatus has no field of this type.
+type AutoScalingStatus struct {
+	// DesiredReplicas mirrors the owned HPA/ScaledObject's own status, so
+	// users don't need to separately inspect it to see the graph's current
+	// scale.
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func int32ptr(v int32) *int32 { return &v }
+
+func TestResolveMinReplicasDefault(t *testing.T) {
+	if got := ResolveMinReplicas(nil, nil); got != 1 {
+		t.Fatalf("ResolveMinReplicas(nil, nil) = %d, want 1", got)
+	}
+}
+
+func TestResolveMinReplicasFromSpec(t *testing.T) {
+	spec := &AutoScalingSpec{MinReplicas: int32ptr(0)}
+	if got := ResolveMinReplicas(spec, nil); got != 0 {
+		t.Fatalf("expected the spec's MinReplicas to win, got %d", got)
+	}
+}
+
+func TestResolveMinReplicasBackwardCompatAnnotation(t *testing.T) {
+	annotations := map[string]string{minScaleBackwardCompatAnnotation: "3"}
+	if got := ResolveMinReplicas(nil, annotations); got != 3 {
+		t.Fatalf("expected the legacy min-scale annotation to be honored, got %d", got)
+	}
+}
+
+func TestResolveMinReplicasSpecWinsOverAnnotation(t *testing.T) {
+	spec := &AutoScalingSpec{MinReplicas: int32ptr(2)}
+	annotations := map[string]string{minScaleBackwardCompatAnnotation: "5"}
+	if got := ResolveMinReplicas(spec, annotations); got != 2 {
+		t.Fatalf("expected spec.MinReplicas to take priority over the legacy annotation, got %d", got)
+	}
+}
+
+func TestResolveMaxReplicas(t *testing.T) {
+	if got := ResolveMaxReplicas(nil, 3); got != 3 {
+		t.Fatalf("expected nil spec to fall back to minReplicas, got %d", got)
+	}
+	if got := ResolveMaxReplicas(&AutoScalingSpec{MaxReplicas: 10}, 3); got != 10 {
+		t.Fatalf("expected the spec's MaxReplicas to win, got %d", got)
+	}
+	if got := ResolveMaxReplicas(&AutoScalingSpec{}, 3); got != 3 {
+		t.Fatalf("expected an unset (zero) MaxReplicas to fall back to minReplicas, got %d", got)
+	}
+}
+
+func TestAutoscalingAnnotationsDefaultsToCPU(t *testing.T) {
+	annotations := autoscalingAnnotations(nil)
+	if annotations["autoscaling.knative.dev/metric"] != "cpu" {
+		t.Fatalf("expected the default metric to be cpu, got %+v", annotations)
+	}
+	if _, ok := annotations["autoscaling.knative.dev/target"]; ok {
+		t.Fatalf("expected no target annotation when spec is nil, got %+v", annotations)
+	}
+}
+
+func TestAutoscalingAnnotationsCustomMetricAndTarget(t *testing.T) {
+	spec := &AutoScalingSpec{Metric: "rps", Target: int32ptr(50)}
+	annotations := autoscalingAnnotations(spec)
+	if annotations["autoscaling.knative.dev/metric"] != "rps" {
+		t.Fatalf("expected the configured metric, got %+v", annotations)
+	}
+	if annotations["autoscaling.knative.dev/target"] != "50" {
+		t.Fatalf("expected the configured target, got %+v", annotations)
+	}
+}
+
+func TestBuildRouterAutoscalingHPAOnly(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "graph", Namespace: "ns"}
+	owner := metav1.OwnerReference{Name: "graph", Kind: "InferenceGraph"}
+	hpa, scaledObject := BuildRouterAutoscaling(meta, &AutoScalingSpec{MaxReplicas: 5}, owner)
+
+	if hpa == nil {
+		t.Fatalf("expected a non-nil HPA")
+	}
+	if scaledObject != nil {
+		t.Fatalf("expected no ScaledObject for the default cpu metric, got %+v", scaledObject)
+	}
+	if len(hpa.OwnerReferences) != 1 || hpa.OwnerReferences[0].Name != "graph" {
+		t.Fatalf("expected the owner reference to be stamped onto the HPA, got %+v", hpa.OwnerReferences)
+	}
+	if *hpa.Spec.MinReplicas != 1 || hpa.Spec.MaxReplicas != 5 {
+		t.Fatalf("unexpected replica bounds: min=%d max=%d", *hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas)
+	}
+}
+
+func TestBuildRouterAutoscalingWithScaledObject(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "graph", Namespace: "ns"}
+	owner := metav1.OwnerReference{Name: "graph", Kind: "InferenceGraph"}
+	spec := &AutoScalingSpec{MinReplicas: int32ptr(0), MaxReplicas: 5, Metric: "rps", Target: int32ptr(20)}
+	_, scaledObject := BuildRouterAutoscaling(meta, spec, owner)
+
+	if scaledObject == nil {
+		t.Fatalf("expected a ScaledObject for metric=rps")
+	}
+	if len(scaledObject.GetOwnerReferences()) != 1 || scaledObject.GetOwnerReferences()[0].Name != "graph" {
+		t.Fatalf("expected the owner reference to be stamped onto the ScaledObject, got %+v", scaledObject.GetOwnerReferences())
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kafka is the broker.Transport backend for BrokerSpec.Type="kafka".
+// Each InferenceGraph gets an input topic name (request envelopes) and an
+// output topic name (responses); Transport.Reconcile builds a Strimzi
+// KafkaTopic custom resource for the input topic only.
+// Strimzi's CRDs aren't vendored as typed clients in this module, so, like
+// the reconcilers/keda package does for ScaledObject, KafkaTopic is built
+// and compared as unstructured.Unstructured.
+package kafka
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/controller/v1alpha1/inferencegraph/broker"
+)
+
+func init() {
+	broker.Register("kafka", func(graphName, namespace, bootstrapServers string) broker.Transport {
+		return &Transport{graphName: graphName, namespace: namespace, bootstrapServers: bootstrapServers}
+	})
+}
+
+// kafkaTopicGVK is the GroupVersionKind of Strimzi's KafkaTopic CRD.
+var kafkaTopicGVK = map[string]string{
+	"group":   "kafka.strimzi.io",
+	"version": "v1beta2",
+	"kind":    "KafkaTopic",
+}
+
+// Transport is the kafka package's broker.Transport implementation: one
+// InferenceGraph gets one input and one output topic name, named after the
+// graph so multiple async graphs in a namespace don't collide.
+type Transport struct {
+	graphName        string
+	namespace        string
+	bootstrapServers string
+}
+
+// InputTopic is where the router consumes request envelopes from.
+func (t *Transport) InputTopic() string {
+	return t.graphName + "-input"
+}
+
+// OutputTopic is where the router publishes responses keyed by correlation
+// ID, and where each InferenceStep's intermediate result is also emitted so
+// downstream consumers can subscribe to partial graph progress.
+func (t *Transport) OutputTopic() string {
+	return t.graphName + "-output"
+}
+
+// Reconcile builds the input KafkaTopic; the caller is expected to also
+// reconcile OutputTopic() the same way. Returned as a single object per
+// broker.Transport's interface, matching the "one reconciled object per
+// call" contract the autoscaler.Reconciler interface already established.
+func (t *Transport) Reconcile(ctx context.Context) (client.Object, error) {
+	topic := &unstructured.Unstructured{}
+	topic.SetAPIVersion(kafkaTopicGVK["group"] + "/" + kafkaTopicGVK["version"])
+	topic.SetKind(kafkaTopicGVK["kind"])
+	topic.SetName(t.InputTopic())
+	topic.SetNamespace(t.namespace)
+	_ = unstructured.SetNestedField(topic.Object, t.bootstrapServers, "spec", "bootstrapServers")
+	return topic, nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kserve/kserve/pkg/controller/v1alpha1/inferencegraph/broker"
+)
+
+func TestRegistersKafkaFactory(t *testing.T) {
+	factory, err := broker.Get("kafka")
+	if err != nil {
+		t.Fatalf("expected the kafka package's init() to register a \"kafka\" factory: %v", err)
+	}
+	transport := factory("graph", "ns", "broker:9092")
+	if transport.InputTopic() != "graph-input" || transport.OutputTopic() != "graph-output" {
+		t.Fatalf("unexpected topics: in=%q out=%q", transport.InputTopic(), transport.OutputTopic())
+	}
+}
+
+func TestTransportTopics(t *testing.T) {
+	transport := &Transport{graphName: "my-graph"}
+	if transport.InputTopic() != "my-graph-input" {
+		t.Fatalf("unexpected input topic: %q", transport.InputTopic())
+	}
+	if transport.OutputTopic() != "my-graph-output" {
+		t.Fatalf("unexpected output topic: %q", transport.OutputTopic())
+	}
+}
+
+func TestTransportReconcile(t *testing.T) {
+	transport := &Transport{graphName: "my-graph", namespace: "ns", bootstrapServers: "broker:9092"}
+	obj, err := transport.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	topic, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		t.Fatalf("expected an *unstructured.Unstructured, got %T", obj)
+	}
+	if topic.GetName() != "my-graph-input" || topic.GetNamespace() != "ns" {
+		t.Fatalf("unexpected object metadata: name=%q namespace=%q", topic.GetName(), topic.GetNamespace())
+	}
+	if topic.GetAPIVersion() != "kafka.strimzi.io/v1beta2" || topic.GetKind() != "KafkaTopic" {
+		t.Fatalf("unexpected GVK: %s/%s", topic.GetAPIVersion(), topic.GetKind())
+	}
+	bootstrapServers, found, err := unstructured.NestedString(topic.Object, "spec", "bootstrapServers")
+	if err != nil || !found || bootstrapServers != "broker:9092" {
+		t.Fatalf("expected spec.bootstrapServers to be set, got %q found=%v err=%v", bootstrapServers, found, err)
+	}
+}
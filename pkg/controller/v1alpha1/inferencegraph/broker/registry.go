@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package broker decouples the InferenceGraph async-execution reconciler
+// from any single message-queue implementation, mirroring the
+// reconcilers/autoscaler package's Reconciler registry: each transport
+// (Kafka, NATS, ...) registers a Factory under its own type name so new
+// transports can be added without changing the reconciler's call site.
+package broker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Transport is implemented by every async broker backend (Kafka, NATS, ...).
+type Transport interface {
+	// Reconcile creates/updates the transport's topic/subject (and, where
+	// the backend has one, consumer-group) resources for graphName and
+	// returns the object that was reconciled, for status propagation.
+	Reconcile(ctx context.Context) (client.Object, error)
+	// InputTopic/OutputTopic name the request/response channel the router
+	// should be configured with via --input-topic/--output-topic.
+	InputTopic() string
+	OutputTopic() string
+}
+
+// Factory constructs a Transport for one InferenceGraph's async execution.
+type Factory func(graphName, namespace, bootstrapServers string) Transport
+
+var (
+	mu       sync.RWMutex
+	registry = map[string]Factory{}
+)
+
+// Register associates a BrokerSpec.Type with the Factory that builds its
+// Transport. Intended to be called from each backend's package init().
+func Register(brokerType string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	registry[brokerType] = factory
+}
+
+// Get looks up the Factory registered for a BrokerSpec.Type.
+func Get(brokerType string) (Factory, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	factory, ok := registry[brokerType]
+	if !ok {
+		return nil, fmt.Errorf("no async broker backend registered for type %q", brokerType)
+	}
+	return factory, nil
+}
+
+// RouterArgs builds the router container's --async-broker/--input-topic/
+// --output-topic args for a reconciled Transport, shared by every backend
+// so an individual Transport implementation doesn't need to know the
+// router's flag names.
+func RouterArgs(brokerType string, transport Transport) []string {
+	return []string{
+		"--async-broker", brokerType,
+		"--input-topic", transport.InputTopic(),
+		"--output-topic", transport.OutputTopic(),
+	}
+}
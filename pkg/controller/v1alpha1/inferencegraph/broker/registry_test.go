@@ -0,0 +1,67 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type fakeTransport struct {
+	input, output string
+}
+
+func (f *fakeTransport) Reconcile(ctx context.Context) (client.Object, error) { return nil, nil }
+func (f *fakeTransport) InputTopic() string                                   { return f.input }
+func (f *fakeTransport) OutputTopic() string                                  { return f.output }
+
+func TestRegisterAndGet(t *testing.T) {
+	Register("test-broker-type", func(graphName, namespace, bootstrapServers string) Transport {
+		return &fakeTransport{input: graphName + "-in", output: graphName + "-out"}
+	})
+
+	factory, err := Get("test-broker-type")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	transport := factory("graph", "ns", "broker:9092")
+	if transport.InputTopic() != "graph-in" || transport.OutputTopic() != "graph-out" {
+		t.Fatalf("unexpected transport built from registered factory: in=%q out=%q", transport.InputTopic(), transport.OutputTopic())
+	}
+}
+
+func TestGetUnregisteredType(t *testing.T) {
+	if _, err := Get("no-such-broker-type"); err == nil {
+		t.Fatalf("expected an error for an unregistered broker type")
+	}
+}
+
+func TestRouterArgs(t *testing.T) {
+	transport := &fakeTransport{input: "graph-input", output: "graph-output"}
+	args := RouterArgs("kafka", transport)
+	want := []string{"--async-broker", "kafka", "--input-topic", "graph-input", "--output-topic", "graph-output"}
+	if len(args) != len(want) {
+		t.Fatalf("unexpected args: %v", args)
+	}
+	for i := range want {
+		if args[i] != want[i] {
+			t.Fatalf("unexpected args: %v, want %v", args, want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ServiceDependencyIndex maps InferenceService NamespacedNames to the set of
+// InferenceGraphs whose Steps reference them by ServiceName, kept in sync by
+// the reconcile loop calling Set on every InferenceGraph event. Backing a
+// Watches(&v1beta1.InferenceService{}, handler.EnqueueRequestsFromMapFunc(...))
+// with this index, instead of listing every InferenceGraph on every
+// InferenceService event, keeps fan-out cheap in clusters with many graphs.
+//
+// This is the in-memory counterpart of
+// v1alpha1.RegisterInferenceGraphStepServiceNameIndex's controller-runtime
+// field index; a field index answers the same question via a cache List
+// call, while this index lets MapFunc build reconcile.Requests without any
+// API/cache access on the hot path.
+type ServiceDependencyIndex struct {
+	mu sync.RWMutex
+	// bySvc maps a referenced InferenceService to the graphs referencing it.
+	bySvc map[types.NamespacedName]map[types.NamespacedName]struct{}
+	// byGraph is bySvc's reverse mapping, so Set can remove a graph's stale
+	// entries without a full scan of bySvc.
+	byGraph map[types.NamespacedName]map[types.NamespacedName]struct{}
+}
+
+// NewServiceDependencyIndex constructs an empty ServiceDependencyIndex.
+func NewServiceDependencyIndex() *ServiceDependencyIndex {
+	return &ServiceDependencyIndex{
+		bySvc:   map[types.NamespacedName]map[types.NamespacedName]struct{}{},
+		byGraph: map[types.NamespacedName]map[types.NamespacedName]struct{}{},
+	}
+}
+
+// Set records that graph currently references services, replacing whatever
+// was previously recorded for graph. Call this from the reconcile loop on
+// every InferenceGraph event, including deletion (with an empty services
+// slice), so a removed Step or deleted graph doesn't keep stale entries
+// alive.
+func (idx *ServiceDependencyIndex) Set(graph types.NamespacedName, services []types.NamespacedName) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	for svc := range idx.byGraph[graph] {
+		delete(idx.bySvc[svc], graph)
+		if len(idx.bySvc[svc]) == 0 {
+			delete(idx.bySvc, svc)
+		}
+	}
+
+	graphSet := make(map[types.NamespacedName]struct{}, len(services))
+	for _, svc := range services {
+		graphSet[svc] = struct{}{}
+		if idx.bySvc[svc] == nil {
+			idx.bySvc[svc] = map[types.NamespacedName]struct{}{}
+		}
+		idx.bySvc[svc][graph] = struct{}{}
+	}
+	idx.byGraph[graph] = graphSet
+}
+
+// Lookup returns the graphs currently referencing svc.
+func (idx *ServiceDependencyIndex) Lookup(svc types.NamespacedName) []types.NamespacedName {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	graphs := make([]types.NamespacedName, 0, len(idx.bySvc[svc]))
+	for graph := range idx.bySvc[svc] {
+		graphs = append(graphs, graph)
+	}
+	return graphs
+}
+
+// MapFunc builds the handler.EnqueueRequestsFromMapFunc callback for a
+// Watches(&v1beta1.InferenceService{}, ...) on the InferenceGraph
+// controller: it consults idx instead of listing every InferenceGraph, so a
+// burst of InferenceService status updates enqueues only the graphs that
+// actually reference the changed Service.
+func (idx *ServiceDependencyIndex) MapFunc() func(ctx context.Context, obj client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		svc := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+		graphs := idx.Lookup(svc)
+		requests := make([]reconcile.Request, 0, len(graphs))
+		for _, graph := range graphs {
+			requests = append(requests, reconcile.Request{NamespacedName: graph})
+		}
+		return requests
+	}
+}
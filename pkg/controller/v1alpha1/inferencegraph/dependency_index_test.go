@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func namesOf(names []types.NamespacedName) []string {
+	out := make([]string, 0, len(names))
+	for _, n := range names {
+		out = append(out, n.String())
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestServiceDependencyIndexLookupEmpty(t *testing.T) {
+	idx := NewServiceDependencyIndex()
+	if got := idx.Lookup(types.NamespacedName{Namespace: "ns", Name: "predictor"}); len(got) != 0 {
+		t.Fatalf("expected no graphs for an untouched index, got %v", got)
+	}
+}
+
+func TestServiceDependencyIndexSetAndLookup(t *testing.T) {
+	idx := NewServiceDependencyIndex()
+	svcA := types.NamespacedName{Namespace: "ns", Name: "svc-a"}
+	svcB := types.NamespacedName{Namespace: "ns", Name: "svc-b"}
+	graph1 := types.NamespacedName{Namespace: "ns", Name: "graph-1"}
+	graph2 := types.NamespacedName{Namespace: "ns", Name: "graph-2"}
+
+	idx.Set(graph1, []types.NamespacedName{svcA, svcB})
+	idx.Set(graph2, []types.NamespacedName{svcA})
+
+	if got := namesOf(idx.Lookup(svcA)); len(got) != 2 {
+		t.Fatalf("expected both graphs referencing svc-a, got %v", got)
+	}
+	if got := namesOf(idx.Lookup(svcB)); len(got) != 1 || got[0] != graph1.String() {
+		t.Fatalf("expected only graph-1 referencing svc-b, got %v", got)
+	}
+}
+
+func TestServiceDependencyIndexSetReplacesStaleEntries(t *testing.T) {
+	idx := NewServiceDependencyIndex()
+	svcA := types.NamespacedName{Namespace: "ns", Name: "svc-a"}
+	svcB := types.NamespacedName{Namespace: "ns", Name: "svc-b"}
+	graph := types.NamespacedName{Namespace: "ns", Name: "graph-1"}
+
+	idx.Set(graph, []types.NamespacedName{svcA})
+	idx.Set(graph, []types.NamespacedName{svcB})
+
+	if got := idx.Lookup(svcA); len(got) != 0 {
+		t.Fatalf("expected svc-a's stale reference to be removed after Set, got %v", got)
+	}
+	if got := idx.Lookup(svcB); len(got) != 1 {
+		t.Fatalf("expected svc-b to now be referenced by graph-1, got %v", got)
+	}
+}
+
+func TestServiceDependencyIndexSetEmptyRemovesGraph(t *testing.T) {
+	idx := NewServiceDependencyIndex()
+	svcA := types.NamespacedName{Namespace: "ns", Name: "svc-a"}
+	graph := types.NamespacedName{Namespace: "ns", Name: "graph-1"}
+
+	idx.Set(graph, []types.NamespacedName{svcA})
+	idx.Set(graph, nil)
+
+	if got := idx.Lookup(svcA); len(got) != 0 {
+		t.Fatalf("expected no graphs referencing svc-a after the graph's entry is cleared, got %v", got)
+	}
+}
+
+func TestServiceDependencyIndexMapFunc(t *testing.T) {
+	idx := NewServiceDependencyIndex()
+	svcA := types.NamespacedName{Namespace: "ns", Name: "svc-a"}
+	graph := types.NamespacedName{Namespace: "ns", Name: "graph-1"}
+	idx.Set(graph, []types.NamespacedName{svcA})
+
+	mapFunc := idx.MapFunc()
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "svc-a", Namespace: "ns"}}
+	requests := mapFunc(context.Background(), svc)
+	if len(requests) != 1 || requests[0].NamespacedName != graph {
+		t.Fatalf("expected a single reconcile.Request for graph-1, got %+v", requests)
+	}
+
+	other := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "unreferenced", Namespace: "ns"}}
+	if requests := mapFunc(context.Background(), other); len(requests) != 0 {
+		t.Fatalf("expected no requests for an unreferenced service, got %+v", requests)
+	}
+}
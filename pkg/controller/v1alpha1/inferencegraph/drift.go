@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// DriftDetectedEventReason is the Kubernetes Event reason emitted against an
+// InferenceGraph when TemplateHash finds the managed Knative Service's
+// PodSpec no longer matches what the controller last rolled, e.g. an admin
+// editing the inferenceservice-config ConfigMap to bump the router image.
+const DriftDetectedEventReason = "InferenceGraphDrifted"
+
+// hashedContainer is the subset of corev1.Container the drift hash covers:
+// the router image, its resource requests/limits, its env vars (including
+// PROPAGATE_HEADERS), its readiness probe, and its volume mounts. Fields
+// Knative itself manages (container name suffixes, injected env) are
+// deliberately left out so the hash doesn't change underneath the
+// controller.
+type hashedContainer struct {
+	Image          string
+	Resources      corev1.ResourceRequirements
+	Env            []corev1.EnvVar
+	ReadinessProbe *corev1.Probe
+	VolumeMounts   []corev1.VolumeMount
+}
+
+// TemplateHash hashes the fields of a router PodSpec the InferenceGraph
+// controller itself sets -- container image, resources, PROPAGATE_HEADERS
+// and other env, readiness probe, pod security context, and volumes -- into
+// a stable hex digest, stored as InferenceGraphRevisionHashAnnotationKey on
+// the generated Knative Service's RevisionTemplate. Knative only rolls a new
+// Revision when the template changes, so forcing this annotation to change
+// whenever the expected hash does is what actually triggers the rollout.
+func TemplateHash(spec corev1.PodSpec) (string, error) {
+	containers := make([]hashedContainer, len(spec.Containers))
+	for i, c := range spec.Containers {
+		containers[i] = hashedContainer{
+			Image:          c.Image,
+			Resources:      c.Resources,
+			Env:            c.Env,
+			ReadinessProbe: c.ReadinessProbe,
+			VolumeMounts:   c.VolumeMounts,
+		}
+	}
+	payload := struct {
+		Containers      []hashedContainer
+		SecurityContext *corev1.PodSecurityContext
+		Volumes         []corev1.Volume
+	}{containers, spec.SecurityContext, spec.Volumes}
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// HasDrift reports whether expectedHash differs from the
+// InferenceGraphRevisionHashAnnotationKey recorded on actual's RevisionTemplate
+// the last time this controller reconciled it, i.e. whether
+// actual.Spec.Template has changed out-of-band since then (or has never been
+// annotated at all).
+func HasDrift(actual *knservingv1.Service, expectedHash string) bool {
+	recorded, ok := actual.Spec.Template.Annotations[constants.InferenceGraphRevisionHashAnnotationKey]
+	return !ok || recorded != expectedHash
+}
+
+// DriftDisabled reports whether drift detection should be skipped for an
+// InferenceGraph carrying the given annotations, either because the
+// controller-wide disableDrift flag is set or because the graph opted out
+// via InferenceGraphDisableDriftAnnotationKey, for users who patch the
+// generated Knative Service out-of-band and don't want it reverted.
+func DriftDisabled(disableDrift bool, annotations map[string]string) bool {
+	return disableDrift || annotations[constants.InferenceGraphDisableDriftAnnotationKey] == "true"
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestTemplateHashStableForSameSpec(t *testing.T) {
+	spec := corev1.PodSpec{
+		Containers: []corev1.Container{{Image: "router:v1"}},
+	}
+	h1, err := TemplateHash(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h2, err := TemplateHash(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h1 != h2 {
+		t.Fatalf("expected the same PodSpec to hash identically, got %q and %q", h1, h2)
+	}
+}
+
+func TestTemplateHashChangesWithImage(t *testing.T) {
+	h1, err := TemplateHash(corev1.PodSpec{Containers: []corev1.Container{{Image: "router:v1"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h2, err := TemplateHash(corev1.PodSpec{Containers: []corev1.Container{{Image: "router:v2"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h1 == h2 {
+		t.Fatalf("expected a changed container image to change the hash")
+	}
+}
+
+func TestHasDrift(t *testing.T) {
+	cases := []struct {
+		name         string
+		annotations  map[string]string
+		expectedHash string
+		wantDrift    bool
+	}{
+		{
+			name:         "no recorded hash",
+			annotations:  map[string]string{},
+			expectedHash: "abc",
+			wantDrift:    true,
+		},
+		{
+			name:         "recorded hash matches",
+			annotations:  map[string]string{constants.InferenceGraphRevisionHashAnnotationKey: "abc"},
+			expectedHash: "abc",
+			wantDrift:    false,
+		},
+		{
+			name:         "recorded hash differs",
+			annotations:  map[string]string{constants.InferenceGraphRevisionHashAnnotationKey: "abc"},
+			expectedHash: "def",
+			wantDrift:    true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := &knservingv1.Service{Spec: knservingv1.ServiceSpec{ConfigurationSpec: knservingv1.ConfigurationSpec{
+				Template: knservingv1.RevisionTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}},
+			}}}
+			if got := HasDrift(actual, tc.expectedHash); got != tc.wantDrift {
+				t.Fatalf("HasDrift() = %v, want %v", got, tc.wantDrift)
+			}
+		})
+	}
+}
+
+func TestDriftDisabled(t *testing.T) {
+	cases := []struct {
+		name         string
+		disableDrift bool
+		annotations  map[string]string
+		want         bool
+	}{
+		{name: "neither set", disableDrift: false, annotations: nil, want: false},
+		{name: "controller-wide flag", disableDrift: true, annotations: nil, want: true},
+		{
+			name:         "per-graph opt-out annotation",
+			disableDrift: false,
+			annotations:  map[string]string{constants.InferenceGraphDisableDriftAnnotationKey: "true"},
+			want:         true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := DriftDisabled(tc.disableDrift, tc.annotations); got != tc.want {
+				t.Fatalf("DriftDisabled() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// GatewayRef names the Gateway API Gateway an InferenceGraph's raw-mode
+// router should attach an HTTPRoute to, as an alternative to the OpenShift
+// Route the raw reconciler creates by default. InferenceGraphSpec has no
+// field of this type, and the raw reconciler does not call
+// BuildGraphHTTPRoute, so it still creates only the osv1.Route.
+type GatewayRef struct {
+	// Name of the Gateway.
+	Name string `json:"name"`
+	// Namespace of the Gateway. Defaults to the InferenceGraph's own
+	// namespace when empty.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// SectionName selects a single listener on the Gateway; if empty, the
+	// HTTPRoute attaches to the whole Gateway.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// BuildGraphHTTPRoute builds the single-rule HTTPRoute exposing an
+// InferenceGraph's router Service when gatewayRef is configured, mirroring
+// BuildRouterHPA/BuildRouterScaledObject's "one concrete builder per
+// resource kind" shape. hostnames is typically just the graph's own
+// "<name>-<namespace>.<suffix>" hostname, matching what the OpenShift Route
+// path would otherwise report as the graph's external host.
+func BuildGraphHTTPRoute(componentMeta metav1.ObjectMeta, gatewayRef GatewayRef, hostnames []gatewayapiv1.Hostname, backendServiceName string, backendPort int32) *gatewayapiv1.HTTPRoute {
+	namespace := gatewayRef.Namespace
+	if namespace == "" {
+		namespace = componentMeta.Namespace
+	}
+	parentRef := gatewayapiv1.ParentReference{
+		Name:      gatewayapiv1.ObjectName(gatewayRef.Name),
+		Namespace: (*gatewayapiv1.Namespace)(&namespace),
+	}
+	if gatewayRef.SectionName != "" {
+		sectionName := gatewayapiv1.SectionName(gatewayRef.SectionName)
+		parentRef.SectionName = &sectionName
+	}
+
+	port := gatewayapiv1.PortNumber(backendPort)
+	return &gatewayapiv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      componentMeta.Name,
+			Namespace: componentMeta.Namespace,
+		},
+		Spec: gatewayapiv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayapiv1.CommonRouteSpec{
+				ParentRefs: []gatewayapiv1.ParentReference{parentRef},
+			},
+			Hostnames: hostnames,
+			Rules: []gatewayapiv1.HTTPRouteRule{{
+				BackendRefs: []gatewayapiv1.HTTPBackendRef{{
+					BackendRef: gatewayapiv1.BackendRef{
+						BackendObjectReference: gatewayapiv1.BackendObjectReference{
+							Name: gatewayapiv1.ObjectName(backendServiceName),
+							Port: &port,
+						},
+					},
+				}},
+			}},
+		},
+	}
+}
+
+// ResolveHTTPRouteHostname is the Gateway API counterpart of reading
+// osRoute.Status.Ingress[0].Host: it returns route's first Spec.Hostnames
+// entry once any parent in route's status reports Accepted, i.e. the hostname the
+// InferenceGraph's status URL should report once its router is reachable
+// through the Gateway. Returns "" when no parent has accepted the route yet.
+func ResolveHTTPRouteHostname(route *gatewayapiv1.HTTPRoute) string {
+	for _, parent := range route.Status.Parents {
+		for _, cond := range parent.Conditions {
+			if cond.Type == string(gatewayapiv1.RouteConditionAccepted) && cond.Status == metav1.ConditionTrue {
+				if len(route.Spec.Hostnames) > 0 {
+					return string(route.Spec.Hostnames[0])
+				}
+			}
+		}
+	}
+	return ""
+}
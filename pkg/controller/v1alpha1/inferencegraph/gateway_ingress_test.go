@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestBuildGraphHTTPRouteDefaultsNamespace(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "graph", Namespace: "ns"}
+	route := BuildGraphHTTPRoute(meta, GatewayRef{Name: "gw"}, []gatewayapiv1.Hostname{"graph-ns.example.com"}, "graph-predictor", 80)
+
+	if len(route.Spec.ParentRefs) != 1 {
+		t.Fatalf("expected exactly one parentRef, got %d", len(route.Spec.ParentRefs))
+	}
+	parent := route.Spec.ParentRefs[0]
+	if parent.Name != "gw" || parent.Namespace == nil || *parent.Namespace != "ns" {
+		t.Fatalf("expected the gateway's namespace to default to the graph's own namespace, got %+v", parent)
+	}
+	if parent.SectionName != nil {
+		t.Fatalf("expected no SectionName when GatewayRef doesn't set one, got %v", *parent.SectionName)
+	}
+}
+
+func TestBuildGraphHTTPRouteExplicitNamespaceAndSection(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "graph", Namespace: "ns"}
+	gatewayRef := GatewayRef{Name: "gw", Namespace: "gw-ns", SectionName: "https"}
+	route := BuildGraphHTTPRoute(meta, gatewayRef, nil, "graph-predictor", 8080)
+
+	parent := route.Spec.ParentRefs[0]
+	if parent.Namespace == nil || *parent.Namespace != "gw-ns" {
+		t.Fatalf("expected the explicit GatewayRef namespace to be used, got %+v", parent.Namespace)
+	}
+	if parent.SectionName == nil || *parent.SectionName != "https" {
+		t.Fatalf("expected the explicit SectionName to be set, got %v", parent.SectionName)
+	}
+	if len(route.Spec.Rules) != 1 || len(route.Spec.Rules[0].BackendRefs) != 1 {
+		t.Fatalf("expected a single rule with a single backendRef, got %+v", route.Spec.Rules)
+	}
+	backend := route.Spec.Rules[0].BackendRefs[0]
+	if backend.Name != "graph-predictor" || backend.Port == nil || *backend.Port != 8080 {
+		t.Fatalf("unexpected backendRef: %+v", backend)
+	}
+	if route.Name != "graph" || route.Namespace != "ns" {
+		t.Fatalf("expected the HTTPRoute to be named after the graph in its own namespace, got %s/%s", route.Namespace, route.Name)
+	}
+}
+
+func TestResolveHTTPRouteHostnameNoParents(t *testing.T) {
+	route := &gatewayapiv1.HTTPRoute{
+		Spec: gatewayapiv1.HTTPRouteSpec{Hostnames: []gatewayapiv1.Hostname{"graph-ns.example.com"}},
+	}
+	if got := ResolveHTTPRouteHostname(route); got != "" {
+		t.Fatalf("expected an empty hostname with no Status.Parents, got %q", got)
+	}
+}
+
+func TestResolveHTTPRouteHostnameNotYetAccepted(t *testing.T) {
+	route := &gatewayapiv1.HTTPRoute{
+		Spec: gatewayapiv1.HTTPRouteSpec{Hostnames: []gatewayapiv1.Hostname{"graph-ns.example.com"}},
+	}
+	route.Status.Parents = []gatewayapiv1.RouteParentStatus{{
+		Conditions: []metav1.Condition{{
+			Type:   string(gatewayapiv1.RouteConditionAccepted),
+			Status: metav1.ConditionFalse,
+		}},
+	}}
+	if got := ResolveHTTPRouteHostname(route); got != "" {
+		t.Fatalf("expected an empty hostname when no parent has accepted, got %q", got)
+	}
+}
+
+func TestResolveHTTPRouteHostnameAccepted(t *testing.T) {
+	route := &gatewayapiv1.HTTPRoute{
+		Spec: gatewayapiv1.HTTPRouteSpec{Hostnames: []gatewayapiv1.Hostname{"graph-ns.example.com", "other.example.com"}},
+	}
+	route.Status.Parents = []gatewayapiv1.RouteParentStatus{{
+		Conditions: []metav1.Condition{{
+			Type:   string(gatewayapiv1.RouteConditionAccepted),
+			Status: metav1.ConditionFalse,
+		}},
+	}, {
+		Conditions: []metav1.Condition{{
+			Type:   string(gatewayapiv1.RouteConditionAccepted),
+			Status: metav1.ConditionTrue,
+		}},
+	}}
+	if got := ResolveHTTPRouteHostname(route); got != "graph-ns.example.com" {
+		t.Fatalf("expected the first declared hostname once any parent accepts, got %q", got)
+	}
+}
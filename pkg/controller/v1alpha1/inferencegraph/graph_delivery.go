@@ -0,0 +1,112 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// graphJSONSizeThreshold is the serialized graph size past which
+// NeedsConfigMapDelivery switches the router from --graph-json to a mounted
+// ConfigMap even without graphDeliveryMode: configmap configured, since a
+// graph this large risks the kernel's ARG_MAX and etcd's per-object size
+// limit if left on the container args.
+const graphJSONSizeThreshold = 128 * 1024
+
+// graphConfigMapKey is the ConfigMap data key BuildGraphConfigMap writes
+// the serialized graph to, and the file name it appears under at
+// graphVolumeMountPath.
+const graphConfigMapKey = "graph.json"
+
+// graphVolumeName/graphVolumeMountPath are the router container's mount of
+// BuildGraphConfigMap, read back via --graph-json-file.
+const graphVolumeName = "graph-json"
+const graphVolumeMountPath = "/etc/kserve/graph"
+
+// NeedsConfigMapDelivery reports whether the router should read its graph
+// from a mounted ConfigMap instead of the --graph-json arg: either the
+// controller ConfigMap opted in via graphDeliveryMode: configmap, or
+// graphJSON is large enough to risk ARG_MAX/etcd object-size limits on its
+// own.
+func NeedsConfigMapDelivery(graphJSON string, graphDeliveryMode string) bool {
+	return graphDeliveryMode == "configmap" || len(graphJSON) > graphJSONSizeThreshold
+}
+
+// GraphContentHash hashes graphJSON the same way drift.go's TemplateHash
+// hashes a PodSpec, so BuildGraphConfigMap's content-hash annotation
+// changes exactly when the graph itself does, triggering a Knative
+// revision or Deployment rollout that picks up the new mounted file.
+func GraphContentHash(graphJSON string) string {
+	sum := sha256.Sum256([]byte(graphJSON))
+	return hex.EncodeToString(sum[:])
+}
+
+// BuildGraphConfigMap builds the per-graph ConfigMap NeedsConfigMapDelivery
+// switches the router to, owned by owner so deleting the InferenceGraph
+// garbage-collects it, and annotated with constants.
+// InferenceGraphRevisionHashAnnotationKey so the owning Knative revision or
+// Deployment template can carry the same value and roll out on change.
+func BuildGraphConfigMap(componentMeta metav1.ObjectMeta, graphJSON string, owner metav1.OwnerReference) *corev1.ConfigMap {
+	meta := *componentMeta.DeepCopy()
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[constants.InferenceGraphRevisionHashAnnotationKey] = GraphContentHash(graphJSON)
+	meta.OwnerReferences = append(meta.OwnerReferences, owner)
+
+	return &corev1.ConfigMap{
+		ObjectMeta: meta,
+		Data: map[string]string{
+			graphConfigMapKey: graphJSON,
+		},
+	}
+}
+
+// BuildGraphVolume/BuildGraphVolumeMount mount configMapName's
+// graphConfigMapKey at graphVolumeMountPath, so RouterArgs' --graph-json-file
+// has something to read.
+func BuildGraphVolume(configMapName string) corev1.Volume {
+	return corev1.Volume{
+		Name: graphVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+			},
+		},
+	}
+}
+
+func BuildGraphVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{Name: graphVolumeName, MountPath: graphVolumeMountPath, ReadOnly: true}
+}
+
+// RouterArgs builds the router container's graph-delivery arg: --graph-json
+// with the serialized graph inline, or --graph-json-file pointing at
+// BuildGraphVolumeMount's mounted path once NeedsConfigMapDelivery reports
+// true.
+func RouterArgs(graphJSON string, graphDeliveryMode string) []string {
+	if NeedsConfigMapDelivery(graphJSON, graphDeliveryMode) {
+		return []string{"--graph-json-file=" + graphVolumeMountPath + "/" + graphConfigMapKey}
+	}
+	return []string{"--graph-json", graphJSON}
+}
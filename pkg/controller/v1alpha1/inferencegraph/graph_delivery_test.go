@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestNeedsConfigMapDelivery(t *testing.T) {
+	if NeedsConfigMapDelivery(`{"nodes":{}}`, "") {
+		t.Fatalf("expected a small inline graph with no configured mode to stay inline")
+	}
+	if !NeedsConfigMapDelivery(`{"nodes":{}}`, "configmap") {
+		t.Fatalf("expected graphDeliveryMode=configmap to always use a ConfigMap")
+	}
+	large := strings.Repeat("a", graphJSONSizeThreshold+1)
+	if !NeedsConfigMapDelivery(large, "") {
+		t.Fatalf("expected a graph over the size threshold to use a ConfigMap even without graphDeliveryMode set")
+	}
+}
+
+func TestGraphContentHashStableAndSensitive(t *testing.T) {
+	h1 := GraphContentHash(`{"nodes":{"a":1}}`)
+	h2 := GraphContentHash(`{"nodes":{"a":1}}`)
+	if h1 != h2 {
+		t.Fatalf("expected the same graph JSON to hash identically")
+	}
+	h3 := GraphContentHash(`{"nodes":{"a":2}}`)
+	if h1 == h3 {
+		t.Fatalf("expected a changed graph JSON to change the hash")
+	}
+}
+
+func TestBuildGraphConfigMap(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "graph", Namespace: "ns"}
+	owner := metav1.OwnerReference{Name: "graph", Kind: "InferenceGraph"}
+	graphJSON := `{"nodes":{"root":{}}}`
+
+	cm := BuildGraphConfigMap(meta, graphJSON, owner)
+	if cm.Data[graphConfigMapKey] != graphJSON {
+		t.Fatalf("expected the graph JSON to be stored under %q, got %+v", graphConfigMapKey, cm.Data)
+	}
+	if cm.Annotations[constants.InferenceGraphRevisionHashAnnotationKey] != GraphContentHash(graphJSON) {
+		t.Fatalf("expected the revision-hash annotation to match GraphContentHash, got %+v", cm.Annotations)
+	}
+	if len(cm.OwnerReferences) != 1 || cm.OwnerReferences[0].Name != "graph" {
+		t.Fatalf("expected the owner reference to be stamped onto the ConfigMap, got %+v", cm.OwnerReferences)
+	}
+}
+
+func TestBuildGraphVolumeAndMount(t *testing.T) {
+	vol := BuildGraphVolume("graph-json-cm")
+	if vol.Name != graphVolumeName || vol.ConfigMap == nil || vol.ConfigMap.Name != "graph-json-cm" {
+		t.Fatalf("unexpected volume: %+v", vol)
+	}
+	mount := BuildGraphVolumeMount()
+	if mount.Name != graphVolumeName || mount.MountPath != graphVolumeMountPath || !mount.ReadOnly {
+		t.Fatalf("unexpected volume mount: %+v", mount)
+	}
+}
+
+func TestRouterArgsInline(t *testing.T) {
+	args := RouterArgs(`{"nodes":{}}`, "")
+	if len(args) != 2 || args[0] != "--graph-json" || args[1] != `{"nodes":{}}` {
+		t.Fatalf("unexpected inline args: %v", args)
+	}
+}
+
+func TestRouterArgsConfigMap(t *testing.T) {
+	args := RouterArgs(`{"nodes":{}}`, "configmap")
+	if len(args) != 1 || !strings.HasPrefix(args[0], "--graph-json-file="+graphVolumeMountPath) {
+		t.Fatalf("unexpected configmap args: %v", args)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// leaseRoleName is the Role BuildLeaseRole produces, named after the graph
+// so two graphs in the same namespace don't share (and so over-grant) Lease
+// access.
+func leaseRoleName(graphName string) string {
+	return graphName + "-leader-election"
+}
+
+// NeedsLeaderElection reports whether the router should coordinate a single
+// leader via BuildLeaseRole/RouterLeaderElectionArgs: only relevant once
+// more than one replica could otherwise race on stateful work (streaming,
+// Splitter/Ensemble fan-in aggregation, idempotency-keyed retries).
+func NeedsLeaderElection(minReplicas int32) bool {
+	return minReplicas > 1
+}
+
+// BuildLeaseRole grants create/get/update on coordination.k8s.io/v1 Leases
+// in the graph's namespace, the minimum the router's leader-election client
+// needs to create and renew its own Lease.
+func BuildLeaseRole(componentMeta metav1.ObjectMeta, graphName string, owner metav1.OwnerReference) *rbacv1.Role {
+	meta := *componentMeta.DeepCopy()
+	meta.Name = leaseRoleName(graphName)
+	meta.OwnerReferences = append(meta.OwnerReferences, owner)
+
+	return &rbacv1.Role{
+		ObjectMeta: meta,
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{"coordination.k8s.io"},
+			Resources: []string{"leases"},
+			Verbs:     []string{"create", "get", "update"},
+		}},
+	}
+}
+
+// BuildLeaseRoleBinding binds BuildLeaseRole to the graph's own
+// ServiceAccount (the one already created alongside the router Deployment),
+// so the router container's in-process leader-election client can use its
+// own pod identity rather than a separate credential.
+func BuildLeaseRoleBinding(componentMeta metav1.ObjectMeta, graphName, serviceAccountName string, owner metav1.OwnerReference) *rbacv1.RoleBinding {
+	meta := *componentMeta.DeepCopy()
+	meta.Name = leaseRoleName(graphName)
+	meta.OwnerReferences = append(meta.OwnerReferences, owner)
+
+	return &rbacv1.RoleBinding{
+		ObjectMeta: meta,
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     leaseRoleName(graphName),
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      serviceAccountName,
+			Namespace: componentMeta.Namespace,
+		}},
+	}
+}
+
+// RouterLeaderElectionArgs builds the router container's
+// --enable-leader-election/--lease-name args when minReplicas warrants it
+// (see NeedsLeaderElection); returns nil for a single-replica graph, leaving
+// its one router replica implicitly the leader.
+func RouterLeaderElectionArgs(graphName string, minReplicas int32) []string {
+	if !NeedsLeaderElection(minReplicas) {
+		return nil
+	}
+	return []string{"--enable-leader-election", "--lease-name", graphName + "-router"}
+}
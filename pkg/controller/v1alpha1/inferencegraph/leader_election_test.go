@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNeedsLeaderElection(t *testing.T) {
+	cases := []struct {
+		minReplicas int32
+		want        bool
+	}{
+		{minReplicas: 0, want: false},
+		{minReplicas: 1, want: false},
+		{minReplicas: 2, want: true},
+		{minReplicas: 5, want: true},
+	}
+	for _, tc := range cases {
+		if got := NeedsLeaderElection(tc.minReplicas); got != tc.want {
+			t.Fatalf("NeedsLeaderElection(%d) = %v, want %v", tc.minReplicas, got, tc.want)
+		}
+	}
+}
+
+func TestBuildLeaseRole(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "graph", Namespace: "ns"}
+	owner := metav1.OwnerReference{Name: "graph", Kind: "InferenceGraph"}
+	role := BuildLeaseRole(meta, "graph", owner)
+
+	if role.Name != "graph-leader-election" {
+		t.Fatalf("unexpected role name: %q", role.Name)
+	}
+	if role.Namespace != "ns" {
+		t.Fatalf("expected a namespace-scoped Role, got namespace %q", role.Namespace)
+	}
+	if len(role.Rules) != 1 || role.Rules[0].Resources[0] != "leases" {
+		t.Fatalf("unexpected rules: %+v", role.Rules)
+	}
+	verbs := role.Rules[0].Verbs
+	for _, v := range []string{"create", "get", "update"} {
+		found := false
+		for _, rv := range verbs {
+			if rv == v {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("expected verb %q to be granted, got %v", v, verbs)
+		}
+	}
+	if len(role.OwnerReferences) != 1 || role.OwnerReferences[0].Name != "graph" {
+		t.Fatalf("expected the owner reference to be stamped onto the Role, got %+v", role.OwnerReferences)
+	}
+}
+
+func TestBuildLeaseRoleBinding(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "graph", Namespace: "ns"}
+	owner := metav1.OwnerReference{Name: "graph", Kind: "InferenceGraph"}
+	binding := BuildLeaseRoleBinding(meta, "graph", "graph-sa", owner)
+
+	if binding.Name != "graph-leader-election" {
+		t.Fatalf("unexpected binding name: %q", binding.Name)
+	}
+	if binding.RoleRef.Name != "graph-leader-election" || binding.RoleRef.Kind != "Role" {
+		t.Fatalf("unexpected roleRef: %+v", binding.RoleRef)
+	}
+	if len(binding.Subjects) != 1 {
+		t.Fatalf("expected exactly one subject, got %+v", binding.Subjects)
+	}
+	subject := binding.Subjects[0]
+	if subject.Kind != rbacv1.ServiceAccountKind || subject.Name != "graph-sa" || subject.Namespace != "ns" {
+		t.Fatalf("unexpected subject: %+v", subject)
+	}
+}
+
+func TestRouterLeaderElectionArgs(t *testing.T) {
+	if args := RouterLeaderElectionArgs("graph", 1); args != nil {
+		t.Fatalf("expected nil args for a single-replica graph, got %v", args)
+	}
+	args := RouterLeaderElectionArgs("graph", 3)
+	if len(args) != 3 || args[0] != "--enable-leader-election" || args[1] != "--lease-name" || args[2] != "graph-router" {
+		t.Fatalf("unexpected leader-election args: %v", args)
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// NetworkPolicyOptOutAnnotationKey disables BuildStepEgressNetworkPolicy for
+// a graph that needs broader egress than "only the declared Steps" allows,
+// e.g. a node whose ServiceURL varies at request time.
+const NetworkPolicyOptOutAnnotationKey = constants.KServeAPIGroupName + "/disable-network-policy"
+
+// StepTarget is the reconciler's resolved form of one
+// InferenceRouter.Steps[].InferenceTarget: exactly one of ServiceName or
+// ServiceURL is set, mirroring InferenceTarget's own "exactly one of"
+// contract.
+type StepTarget struct {
+	// ServiceName names an InferenceService in the same namespace as the
+	// graph, or "name.namespace" to cross namespaces.
+	ServiceName string
+	// ServiceURL is an arbitrary, possibly-external URL.
+	ServiceURL string
+}
+
+// NetworkPolicyDisabled reports whether annotations opt this graph out of
+// BuildStepEgressNetworkPolicy via NetworkPolicyOptOutAnnotationKey.
+func NetworkPolicyDisabled(annotations map[string]string) bool {
+	return annotations[NetworkPolicyOptOutAnnotationKey] == "true"
+}
+
+// BuildStepEgressNetworkPolicy builds the NetworkPolicy restricting a raw-
+// deployment graph's router to exactly the Steps it declares, plus ingress
+// from ingressNamespaces (and, when authNamespace is non-empty, the auth
+// service account's namespace for ODHKserveRawAuth-protected graphs).
+// Returns nil when annotations opt out via NetworkPolicyOptOutAnnotationKey.
+func BuildStepEgressNetworkPolicy(componentMeta metav1.ObjectMeta, targets []StepTarget, ingressNamespaces []string, authNamespace string, owner metav1.OwnerReference) *networkingv1.NetworkPolicy {
+	if NetworkPolicyDisabled(componentMeta.Annotations) {
+		return nil
+	}
+
+	meta := *componentMeta.DeepCopy()
+	meta.OwnerReferences = append(meta.OwnerReferences, owner)
+
+	egress := make([]networkingv1.NetworkPolicyEgressRule, 0, len(targets))
+	for _, target := range targets {
+		if rule, ok := stepEgressRule(target); ok {
+			egress = append(egress, rule)
+		}
+	}
+
+	namespaces := ingressNamespaces
+	if authNamespace != "" {
+		namespaces = append(append([]string{}, ingressNamespaces...), authNamespace)
+	}
+	ingress := []networkingv1.NetworkPolicyIngressRule{{From: namespaceSelectors(namespaces)}}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: meta,
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{
+				constants.InferenceGraphLabel: componentMeta.Name,
+			}},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+			Ingress:     ingress,
+			Egress:      egress,
+		},
+	}
+}
+
+// lookupIP resolves a DNS name to its addresses; a var so tests can stub it
+// without depending on a real resolver.
+var lookupIP = net.LookupIP
+
+// stepEgressRule resolves one StepTarget into a single egress rule: a
+// namespaceSelector+podSelector match for a ServiceName target or a
+// cluster-internal "*.svc.cluster.local" ServiceURL, or an ipBlock+port
+// rule for any other ServiceURL host. ok is false when a ServiceURL target
+// can't be parsed, or can't be resolved to a concrete set of addresses --
+// in either case there's no rule that can legitimately restrict egress to
+// that target, so the caller must drop it rather than fall back to a rule
+// with no "to" restriction (which networking.k8s.io/v1 treats as "allow to
+// anywhere", defeating the whole point of this NetworkPolicy).
+func stepEgressRule(target StepTarget) (networkingv1.NetworkPolicyEgressRule, bool) {
+	if target.ServiceName != "" {
+		name, namespace := target.ServiceName, ""
+		if idx := strings.LastIndex(name, "."); idx != -1 {
+			name, namespace = name[:idx], name[idx+1:]
+		}
+		return networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{svcPeer(name, namespace)},
+		}, true
+	}
+
+	host, port, err := parseServiceURL(target.ServiceURL)
+	if err != nil {
+		return networkingv1.NetworkPolicyEgressRule{}, false
+	}
+	if strings.HasSuffix(host, ".svc.cluster.local") {
+		labels := strings.SplitN(strings.TrimSuffix(host, ".svc.cluster.local"), ".", 2)
+		name := labels[0]
+		namespace := ""
+		if len(labels) == 2 {
+			namespace = labels[1]
+		}
+		return networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{svcPeer(name, namespace)},
+		}, true
+	}
+
+	peers, ok := ipBlockPeers(host)
+	if !ok {
+		return networkingv1.NetworkPolicyEgressRule{}, false
+	}
+	rule := networkingv1.NetworkPolicyEgressRule{To: peers}
+	if port != "" {
+		if portNum, err := strconv.Atoi(port); err == nil {
+			p := intstr.FromInt(portNum)
+			rule.Ports = []networkingv1.NetworkPolicyPort{{Port: &p}}
+		}
+	}
+	return rule, true
+}
+
+// ipBlockPeers builds one ipBlock peer per address host resolves to: a
+// single /32 (or /128) CIDR when host is already a literal IP, or one CIDR
+// per address returned by lookupIP when it's a DNS name. ok is false when
+// host is a DNS name that fails to resolve, since emitting a rule with no
+// addresses at all would silently fall back to "allow to anywhere" under
+// networking.k8s.io/v1 semantics.
+func ipBlockPeers(host string) ([]networkingv1.NetworkPolicyPeer, bool) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: cidrFor(ip)}}}, true
+	}
+
+	addrs, err := lookupIP(host)
+	if err != nil || len(addrs) == 0 {
+		return nil, false
+	}
+	peers := make([]networkingv1.NetworkPolicyPeer, 0, len(addrs))
+	for _, addr := range addrs {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidrFor(addr)}})
+	}
+	return peers, true
+}
+
+// cidrFor renders a single-address CIDR for ip, using /32 for IPv4 and /128
+// for IPv6.
+func cidrFor(ip net.IP) string {
+	if ip.To4() != nil {
+		return ip.String() + "/32"
+	}
+	return ip.String() + "/128"
+}
+
+// svcPeer builds the namespaceSelector+podSelector peer matching name's
+// Kubernetes Service, scoped to namespace when set, or otherwise left with
+// no namespaceSelector, so the peer matches pods in the NetworkPolicy's own
+// namespace only.
+func svcPeer(name, namespace string) networkingv1.NetworkPolicyPeer {
+	peer := networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{
+			constants.InferenceServiceLabel: name,
+		}},
+	}
+	if namespace != "" {
+		peer.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{
+			"kubernetes.io/metadata.name": namespace,
+		}}
+	}
+	return peer
+}
+
+// namespaceSelectors builds one NetworkPolicyPeer per namespace, matched by
+// its immutable "kubernetes.io/metadata.name" label.
+func namespaceSelectors(namespaces []string) []networkingv1.NetworkPolicyPeer {
+	peers := make([]networkingv1.NetworkPolicyPeer, 0, len(namespaces))
+	for _, ns := range namespaces {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{
+				"kubernetes.io/metadata.name": ns,
+			}},
+		})
+	}
+	return peers
+}
+
+// parseServiceURL splits a ServiceURL into host and port, defaulting port
+// to "443" for an https:// URL and "80" otherwise when the URL carries no
+// explicit port.
+func parseServiceURL(serviceURL string) (host, port string, err error) {
+	rest := serviceURL
+	scheme := "http"
+	if idx := strings.Index(rest, "://"); idx != -1 {
+		scheme = rest[:idx]
+		rest = rest[idx+3:]
+	}
+	if idx := strings.IndexAny(rest, "/"); idx != -1 {
+		rest = rest[:idx]
+	}
+	host, port, err = net.SplitHostPort(rest)
+	if err != nil {
+		host = rest
+		port = "80"
+		if scheme == "https" {
+			port = "443"
+		}
+		err = nil
+	}
+	if host == "" {
+		return "", "", &net.AddrError{Err: "empty host", Addr: serviceURL}
+	}
+	return host, port, nil
+}
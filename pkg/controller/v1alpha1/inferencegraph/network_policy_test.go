@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"net"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStepEgressRuleServiceName(t *testing.T) {
+	rule, ok := stepEgressRule(StepTarget{ServiceName: "predictor"})
+	if !ok {
+		t.Fatalf("expected ok=true for a ServiceName target")
+	}
+	if len(rule.To) != 1 || rule.To[0].PodSelector == nil || rule.To[0].NamespaceSelector != nil {
+		t.Fatalf("expected a namespace-less podSelector peer, got %+v", rule.To)
+	}
+
+	rule, ok = stepEgressRule(StepTarget{ServiceName: "predictor.other-ns"})
+	if !ok {
+		t.Fatalf("expected ok=true for a cross-namespace ServiceName target")
+	}
+	if len(rule.To) != 1 || rule.To[0].NamespaceSelector == nil {
+		t.Fatalf("expected a namespaceSelector peer for a cross-namespace target, got %+v", rule.To)
+	}
+}
+
+func TestStepEgressRuleClusterLocalURL(t *testing.T) {
+	rule, ok := stepEgressRule(StepTarget{ServiceURL: "http://predictor.ns.svc.cluster.local:8080"})
+	if !ok {
+		t.Fatalf("expected ok=true for a cluster-local ServiceURL")
+	}
+	if len(rule.To) != 1 || rule.To[0].IPBlock != nil {
+		t.Fatalf("expected a podSelector peer, not an ipBlock, got %+v", rule.To)
+	}
+}
+
+func TestStepEgressRuleLiteralIPURL(t *testing.T) {
+	rule, ok := stepEgressRule(StepTarget{ServiceURL: "https://203.0.113.5:443"})
+	if !ok {
+		t.Fatalf("expected ok=true for a literal-IP ServiceURL")
+	}
+	if len(rule.To) != 1 || rule.To[0].IPBlock == nil || rule.To[0].IPBlock.CIDR != "203.0.113.5/32" {
+		t.Fatalf("expected a single /32 ipBlock peer, got %+v", rule.To)
+	}
+}
+
+// TestStepEgressRuleExternalHostnameNeverAllowsAll guards against the bug
+// where an unresolvable-at-build-time DNS hostname fell back to a
+// NetworkPolicyEgressRule with a nil/empty To, which networking.k8s.io/v1
+// treats as "allow to any destination" -- silently defeating the egress
+// restriction for exactly the external-hostname case the feature targets.
+func TestStepEgressRuleExternalHostnameNeverAllowsAll(t *testing.T) {
+	restore := lookupIP
+	defer func() { lookupIP = restore }()
+
+	t.Run("resolves to concrete addresses", func(t *testing.T) {
+		lookupIP = func(host string) ([]net.IP, error) {
+			return []net.IP{net.ParseIP("198.51.100.10")}, nil
+		}
+		rule, ok := stepEgressRule(StepTarget{ServiceURL: "https://api.example.com"})
+		if !ok {
+			t.Fatalf("expected ok=true when the hostname resolves")
+		}
+		if len(rule.To) == 0 {
+			t.Fatalf("rule.To must never be empty: an empty/nil To matches all destinations under networking.k8s.io/v1")
+		}
+		for _, peer := range rule.To {
+			if peer.IPBlock == nil || peer.IPBlock.CIDR != "198.51.100.10/32" {
+				t.Fatalf("expected a /32 ipBlock peer per resolved address, got %+v", peer)
+			}
+		}
+	})
+
+	t.Run("fails to resolve", func(t *testing.T) {
+		lookupIP = func(host string) ([]net.IP, error) {
+			return nil, &net.DNSError{Err: "no such host", Name: host}
+		}
+		rule, ok := stepEgressRule(StepTarget{ServiceURL: "https://api.example.com"})
+		if ok {
+			t.Fatalf("expected ok=false when the hostname can't be resolved, got rule %+v", rule)
+		}
+	})
+}
+
+func TestBuildStepEgressNetworkPolicyOptOut(t *testing.T) {
+	meta := metav1.ObjectMeta{
+		Name:        "graph",
+		Namespace:   "ns",
+		Annotations: map[string]string{NetworkPolicyOptOutAnnotationKey: "true"},
+	}
+	if policy := BuildStepEgressNetworkPolicy(meta, nil, nil, "", metav1.OwnerReference{}); policy != nil {
+		t.Fatalf("expected nil policy when opted out, got %+v", policy)
+	}
+}
+
+func TestBuildStepEgressNetworkPolicyDropsUnresolvableTargets(t *testing.T) {
+	restore := lookupIP
+	defer func() { lookupIP = restore }()
+	lookupIP = func(host string) ([]net.IP, error) {
+		return nil, &net.DNSError{Err: "no such host", Name: host}
+	}
+
+	meta := metav1.ObjectMeta{Name: "graph", Namespace: "ns"}
+	targets := []StepTarget{
+		{ServiceName: "predictor"},
+		{ServiceURL: "https://unresolvable.example.com"},
+	}
+	policy := BuildStepEgressNetworkPolicy(meta, targets, []string{"istio-system"}, "", metav1.OwnerReference{})
+	if policy == nil {
+		t.Fatalf("expected a non-nil policy")
+	}
+	if len(policy.Spec.Egress) != 1 {
+		t.Fatalf("expected the unresolvable target to be dropped, got %d egress rules", len(policy.Spec.Egress))
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"strconv"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"knative.dev/serving/pkg/apis/autoscaling"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// scaledObjectGVK is the GroupVersionKind of the KEDA ScaledObject CRD,
+// mirroring pkg/controller/.../reconcilers/keda's own unexported copy: KEDA's
+// CRDs aren't vendored as typed clients in this module.
+var scaledObjectGVK = map[string]string{
+	"group":   "keda.sh",
+	"version": "v1alpha1",
+	"kind":    "ScaledObject",
+}
+
+// BuildRouterHPA builds the router Deployment's HorizontalPodAutoscaler for
+// RawDeployment-mode InferenceGraphs, translating the same
+// autoscaling.knative.dev/metric and /target annotations the Serverless path
+// already reads so a graph's autoscaling intent doesn't change shape across
+// deploymentMode. A "cpu"/"memory" metric becomes a native Resource metric;
+// any other value (e.g. "rps"/"concurrency") has no native HPA equivalent
+// and is left to BuildRouterScaledObject's external Prometheus trigger
+// instead, so this only emits the Resource metric in that case.
+func BuildRouterHPA(componentMeta metav1.ObjectMeta, minReplicas, maxReplicas int32) *autoscalingv2.HorizontalPodAutoscaler {
+	annotations := componentMeta.Annotations
+	resourceName := corev1.ResourceCPU
+	if annotations[autoscaling.MetricAnnotationKey] == "memory" {
+		resourceName = corev1.ResourceMemory
+	}
+	target := int32(constants.DefaultCPUUtilization)
+	if value, err := strconv.Atoi(annotations[autoscaling.TargetAnnotationKey]); err == nil && value > 0 {
+		target = int32(value)
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: componentMeta,
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       componentMeta.Name,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: maxReplicas,
+			Metrics: []autoscalingv2.MetricSpec{{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: resourceName,
+					Target: autoscalingv2.MetricTarget{
+						Type:               autoscalingv2.UtilizationMetricType,
+						AverageUtilization: &target,
+					},
+				},
+			}},
+		},
+	}
+}
+
+// needsScaledObject reports whether the router's autoscaling.knative.dev/
+// metric annotation names a metric (rps or concurrency) that has no native
+// HPA Resource-metric equivalent, so scaling it in Raw mode needs KEDA's
+// external-metrics trigger (and, for MinReplicas=0, KEDA's activator to
+// support scale-to-zero) rather than a plain HPA.
+func needsScaledObject(annotations map[string]string) bool {
+	metric := annotations[autoscaling.MetricAnnotationKey]
+	return metric == string(constants.AutoScalerKPAMetricsRPS) || metric == string(constants.AutoScalerKPAMetricsConcurrency)
+}
+
+// BuildRouterScaledObject builds the router Deployment's KEDA ScaledObject
+// for an InferenceGraph scaling on rps/concurrency in Raw mode, querying the
+// router's own queue-proxy-style Prometheus metric. Returns nil when the
+// router's metric annotation doesn't need KEDA (see needsScaledObject),
+// so the caller falls back to BuildRouterHPA alone.
+func BuildRouterScaledObject(componentMeta metav1.ObjectMeta, minReplicas, maxReplicas int32) *unstructured.Unstructured {
+	annotations := componentMeta.Annotations
+	if !needsScaledObject(annotations) {
+		return nil
+	}
+	target := annotations[autoscaling.TargetAnnotationKey]
+	if target == "" {
+		target = "100"
+	}
+
+	so := &unstructured.Unstructured{}
+	so.SetAPIVersion(scaledObjectGVK["group"] + "/" + scaledObjectGVK["version"])
+	so.SetKind(scaledObjectGVK["kind"])
+	so.SetName(componentMeta.Name)
+	so.SetNamespace(componentMeta.Namespace)
+
+	spec := map[string]interface{}{
+		"scaleTargetRef": map[string]interface{}{
+			"name": componentMeta.Name,
+		},
+		"minReplicaCount": int64(minReplicas),
+		"maxReplicaCount": int64(maxReplicas),
+		"triggers": []interface{}{
+			map[string]interface{}{
+				"type": "prometheus",
+				"metadata": map[string]interface{}{
+					"query":     routerMetricQuery(componentMeta.Name, annotations[autoscaling.MetricAnnotationKey]),
+					"threshold": target,
+				},
+			},
+		},
+	}
+	_ = unstructured.SetNestedMap(so.Object, spec, "spec")
+	return so
+}
+
+// routerMetricQuery renders the PromQL query for the router's rps or
+// concurrency metric, scoped to this InferenceGraph's own router Deployment
+// by name so multiple graphs' ScaledObjects don't poll each other's series.
+func routerMetricQuery(routerName, metric string) string {
+	if metric == string(constants.AutoScalerKPAMetricsRPS) {
+		return "sum(rate(revision_request_count{deployment=\"" + routerName + "\"}[1m]))"
+	}
+	return "avg(revision_app_request_concurrency{deployment=\"" + routerName + "\"})"
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"knative.dev/serving/pkg/apis/autoscaling"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestBuildRouterHPADefaultsToCPU(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "graph", Namespace: "ns"}
+	hpa := BuildRouterHPA(meta, 1, 5)
+	if len(hpa.Spec.Metrics) != 1 || hpa.Spec.Metrics[0].Resource.Name != corev1.ResourceCPU {
+		t.Fatalf("expected a single cpu Resource metric, got %+v", hpa.Spec.Metrics)
+	}
+	if *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization != int32(constants.DefaultCPUUtilization) {
+		t.Fatalf("expected the default utilization target when no annotation is set")
+	}
+}
+
+func TestBuildRouterHPAMemoryAndTarget(t *testing.T) {
+	meta := metav1.ObjectMeta{Annotations: map[string]string{
+		autoscaling.MetricAnnotationKey: "memory",
+		autoscaling.TargetAnnotationKey: "75",
+	}}
+	hpa := BuildRouterHPA(meta, 1, 5)
+	if hpa.Spec.Metrics[0].Resource.Name != corev1.ResourceMemory {
+		t.Fatalf("expected a memory Resource metric, got %q", hpa.Spec.Metrics[0].Resource.Name)
+	}
+	if *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization != 75 {
+		t.Fatalf("expected target utilization 75, got %d", *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+	}
+}
+
+func TestNeedsScaledObject(t *testing.T) {
+	cases := []struct {
+		metric string
+		want   bool
+	}{
+		{metric: "", want: false},
+		{metric: "cpu", want: false},
+		{metric: "rps", want: true},
+		{metric: "concurrency", want: true},
+	}
+	for _, tc := range cases {
+		got := needsScaledObject(map[string]string{autoscaling.MetricAnnotationKey: tc.metric})
+		if got != tc.want {
+			t.Fatalf("needsScaledObject(%q) = %v, want %v", tc.metric, got, tc.want)
+		}
+	}
+}
+
+func TestBuildRouterScaledObjectNilWhenNotNeeded(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "graph", Namespace: "ns"}
+	if so := BuildRouterScaledObject(meta, 0, 5); so != nil {
+		t.Fatalf("expected nil ScaledObject for a metric with a native HPA equivalent, got %+v", so)
+	}
+}
+
+func TestBuildRouterScaledObjectRPS(t *testing.T) {
+	meta := metav1.ObjectMeta{
+		Name:      "graph",
+		Namespace: "ns",
+		Annotations: map[string]string{
+			autoscaling.MetricAnnotationKey: "rps",
+			autoscaling.TargetAnnotationKey: "50",
+		},
+	}
+	so := BuildRouterScaledObject(meta, 0, 5)
+	if so == nil {
+		t.Fatalf("expected a non-nil ScaledObject for metric=rps")
+	}
+	if so.GetKind() != "ScaledObject" || so.GetAPIVersion() != "keda.sh/v1alpha1" {
+		t.Fatalf("unexpected GVK: %s/%s", so.GetAPIVersion(), so.GetKind())
+	}
+	triggers, found, err := unstructured.NestedSlice(so.Object, "spec", "triggers")
+	if err != nil || !found {
+		t.Fatalf("expected spec.triggers to be set, err=%v found=%v", err, found)
+	}
+	if len(triggers) != 1 {
+		t.Fatalf("expected exactly one trigger, got %d", len(triggers))
+	}
+	trigger, ok := triggers[0].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected trigger to be a map, got %T", triggers[0])
+	}
+	metadata, ok := trigger["metadata"].(map[string]interface{})
+	if !ok || metadata["threshold"] != "50" {
+		t.Fatalf("expected threshold 50 from the target annotation, got %+v", metadata)
+	}
+}
+
+func TestBuildRouterScaledObjectDefaultTarget(t *testing.T) {
+	meta := metav1.ObjectMeta{
+		Name:      "graph",
+		Namespace: "ns",
+		Annotations: map[string]string{
+			autoscaling.MetricAnnotationKey: "concurrency",
+		},
+	}
+	so := BuildRouterScaledObject(meta, 0, 5)
+	triggers, _, _ := unstructured.NestedSlice(so.Object, "spec", "triggers")
+	trigger := triggers[0].(map[string]interface{})
+	metadata := trigger["metadata"].(map[string]interface{})
+	if metadata["threshold"] != "100" {
+		t.Fatalf("expected the default threshold of 100 when no target annotation is set, got %+v", metadata)
+	}
+}
+
+func TestRouterMetricQuery(t *testing.T) {
+	if q := routerMetricQuery("graph-router", "rps"); !strings.Contains(q, "revision_request_count") || !strings.Contains(q, "graph-router") {
+		t.Fatalf("unexpected rps query: %q", q)
+	}
+	if q := routerMetricQuery("graph-router", "concurrency"); !strings.Contains(q, "revision_app_request_concurrency") || !strings.Contains(q, "graph-router") {
+		t.Fatalf("unexpected concurrency query: %q", q)
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// inferenceGraphInvokeResource is the virtual subresource a SubjectAccessReview
+// checks "get" against before the router forwards a request, the
+// authorization counterpart of the TokenReview the router already performs
+// to authenticate the caller. Named "inferencegraphs/invoke" rather than
+// reusing the plain "inferencegraphs" resource so this grant is additive to
+// (and distinguishable from) ordinary CRUD RBAC on InferenceGraph objects.
+const inferenceGraphInvokeResource = "inferencegraphs/invoke"
+
+// AuthorizationSpec says who may invoke a graph, rendered into the
+// ClusterRole/ClusterRoleBinding pair BuildInvokeRole/BuildInvokeRoleBinding
+// build. InferenceGraphSpec has no field of this type. Kept distinct from
+// RouterConfig.Auth, which configures the router's own outbound calls to
+// downstream ServiceURL steps rather than who may call the router itself.
+type AuthorizationSpec struct {
+	// AllowedGroups lists the caller groups (as reported by the router's
+	// TokenReview) permitted to invoke the whole graph.
+	// +optional
+	AllowedGroups []string `json:"allowedGroups,omitempty"`
+	// AllowedServiceAccounts lists "namespace:name" service accounts
+	// permitted to invoke the whole graph, in addition to AllowedGroups.
+	// +optional
+	AllowedServiceAccounts []string `json:"allowedServiceAccounts,omitempty"`
+}
+
+// StepAuthorizedSubjects is a per-node override of AuthorizationSpec, letting
+// one step in a graph (e.g. a step that calls a sensitive ensemble member)
+// require a narrower set of callers than the graph as a whole. InferenceStep
+// has no field of this type, and BuildInvokeRoleBinding does not read it.
+type StepAuthorizedSubjects struct {
+	Groups          []string `json:"groups,omitempty"`
+	ServiceAccounts []string `json:"serviceAccounts,omitempty"`
+}
+
+// BuildInvokeRole builds the ClusterRole granting "get" on
+// inferenceGraphInvokeResource, named after the graph so BuildInvokeRoleBinding
+// can bind it per-graph without every graph sharing one ClusterRole (and
+// thus one set of subjects).
+func BuildInvokeRole(componentMeta metav1.ObjectMeta, owner metav1.OwnerReference) *rbacv1.ClusterRole {
+	meta := *componentMeta.DeepCopy()
+	meta.OwnerReferences = append(meta.OwnerReferences, owner)
+	meta.Namespace = ""
+
+	return &rbacv1.ClusterRole{
+		ObjectMeta: meta,
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{"serving.kserve.io"},
+			Resources: []string{inferenceGraphInvokeResource},
+			Verbs:     []string{"get"},
+		}},
+	}
+}
+
+// BuildInvokeRoleBinding binds auth's AllowedGroups/AllowedServiceAccounts to
+// roleName (BuildInvokeRole's name) as the subjects the router's
+// SubjectAccessReview should find authorized. Returns nil when auth grants
+// no subjects, since an empty RoleBinding would deny everyone anyway.
+func BuildInvokeRoleBinding(componentMeta metav1.ObjectMeta, roleName string, auth AuthorizationSpec, owner metav1.OwnerReference) *rbacv1.ClusterRoleBinding {
+	var subjects []rbacv1.Subject
+	for _, group := range auth.AllowedGroups {
+		subjects = append(subjects, rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: group})
+	}
+	for _, sa := range auth.AllowedServiceAccounts {
+		namespace, name := splitServiceAccount(sa)
+		subjects = append(subjects, rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: namespace, Name: name})
+	}
+	if len(subjects) == 0 {
+		return nil
+	}
+
+	meta := *componentMeta.DeepCopy()
+	meta.OwnerReferences = append(meta.OwnerReferences, owner)
+	meta.Namespace = ""
+
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: meta,
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     roleName,
+		},
+		Subjects: subjects,
+	}
+}
+
+// splitServiceAccount parses a "namespace:name" AllowedServiceAccounts
+// entry; an entry with no ":" yields an empty namespace, which
+// BuildInvokeRoleBinding passes through unchanged as the subject's
+// Namespace.
+func splitServiceAccount(ref string) (namespace, name string) {
+	for i := 0; i < len(ref); i++ {
+		if ref[i] == ':' {
+			return ref[:i], ref[i+1:]
+		}
+	}
+	return "", ref
+}
+
+// subjectAccessReviewPolicyRule is appended to the router's existing
+// system:auth-delegator ClusterRoleBinding rules so the router's own
+// ServiceAccount, not just the caller's identity, can create the
+// SubjectAccessReview that authorizes the caller.
+var subjectAccessReviewPolicyRule = rbacv1.PolicyRule{
+	APIGroups: []string{"authorization.k8s.io"},
+	Resources: []string{"subjectaccessreviews"},
+	Verbs:     []string{"create"},
+}
+
+// ExtendAuthDelegatorRole appends subjectAccessReviewPolicyRule to role's
+// Rules if not already present, so the reconciler can idempotently extend
+// the router's existing system:auth-delegator-bound role with
+// SubjectAccessReview rights rather than maintaining a second RBAC object.
+func ExtendAuthDelegatorRole(role *rbacv1.ClusterRole) {
+	for _, rule := range role.Rules {
+		if len(rule.Resources) == 1 && rule.Resources[0] == "subjectaccessreviews" {
+			return
+		}
+	}
+	role.Rules = append(role.Rules, subjectAccessReviewPolicyRule)
+}
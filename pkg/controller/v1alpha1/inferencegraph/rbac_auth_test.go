@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildInvokeRole(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "graph", Namespace: "ns"}
+	owner := metav1.OwnerReference{Name: "graph", Kind: "InferenceGraph"}
+	role := BuildInvokeRole(meta, owner)
+
+	if role.Namespace != "" {
+		t.Fatalf("expected a cluster-scoped (namespace-less) ClusterRole, got namespace %q", role.Namespace)
+	}
+	if len(role.Rules) != 1 || role.Rules[0].Resources[0] != inferenceGraphInvokeResource || role.Rules[0].Verbs[0] != "get" {
+		t.Fatalf("unexpected rules: %+v", role.Rules)
+	}
+	if len(role.OwnerReferences) != 1 || role.OwnerReferences[0].Name != "graph" {
+		t.Fatalf("expected the owner reference to be stamped onto the ClusterRole, got %+v", role.OwnerReferences)
+	}
+}
+
+// TestBuildInvokeRoleBindingNoSubjectsDeniesByDefault guards the case the
+// review calls out: a graph with no AllowedGroups/AllowedServiceAccounts
+// must produce no RoleBinding at all, not an empty one, since an RBAC
+// SubjectAccessReview against a nonexistent binding falls through to
+// Kubernetes' own deny-by-default and the router reports 403 for every
+// token-authenticated-but-unauthorized caller.
+func TestBuildInvokeRoleBindingNoSubjectsDeniesByDefault(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "graph", Namespace: "ns"}
+	owner := metav1.OwnerReference{Name: "graph", Kind: "InferenceGraph"}
+	if binding := BuildInvokeRoleBinding(meta, "graph", AuthorizationSpec{}, owner); binding != nil {
+		t.Fatalf("expected a nil RoleBinding when no subjects are granted, got %+v", binding)
+	}
+}
+
+func TestBuildInvokeRoleBindingGroupsAndServiceAccounts(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "graph", Namespace: "ns"}
+	owner := metav1.OwnerReference{Name: "graph", Kind: "InferenceGraph"}
+	auth := AuthorizationSpec{
+		AllowedGroups:          []string{"system:authenticated"},
+		AllowedServiceAccounts: []string{"other-ns:caller-sa", "bare-name"},
+	}
+	binding := BuildInvokeRoleBinding(meta, "graph", auth, owner)
+	if binding == nil {
+		t.Fatalf("expected a non-nil RoleBinding when subjects are granted")
+	}
+	if binding.Namespace != "" {
+		t.Fatalf("expected a cluster-scoped ClusterRoleBinding, got namespace %q", binding.Namespace)
+	}
+	if binding.RoleRef.Name != "graph" || binding.RoleRef.Kind != "ClusterRole" {
+		t.Fatalf("unexpected roleRef: %+v", binding.RoleRef)
+	}
+	if len(binding.Subjects) != 3 {
+		t.Fatalf("expected 3 subjects (1 group + 2 service accounts), got %d: %+v", len(binding.Subjects), binding.Subjects)
+	}
+	if binding.Subjects[0].Kind != rbacv1.GroupKind || binding.Subjects[0].Name != "system:authenticated" {
+		t.Fatalf("unexpected group subject: %+v", binding.Subjects[0])
+	}
+	if binding.Subjects[1].Kind != rbacv1.ServiceAccountKind || binding.Subjects[1].Namespace != "other-ns" || binding.Subjects[1].Name != "caller-sa" {
+		t.Fatalf("unexpected cross-namespace service account subject: %+v", binding.Subjects[1])
+	}
+	if binding.Subjects[2].Namespace != "" || binding.Subjects[2].Name != "bare-name" {
+		t.Fatalf("expected a bare service account name to carry no namespace, got %+v", binding.Subjects[2])
+	}
+}
+
+func TestSplitServiceAccount(t *testing.T) {
+	cases := []struct {
+		ref           string
+		wantNamespace string
+		wantName      string
+	}{
+		{ref: "ns:name", wantNamespace: "ns", wantName: "name"},
+		{ref: "bare-name", wantNamespace: "", wantName: "bare-name"},
+		{ref: "", wantNamespace: "", wantName: ""},
+	}
+	for _, tc := range cases {
+		namespace, name := splitServiceAccount(tc.ref)
+		if namespace != tc.wantNamespace || name != tc.wantName {
+			t.Fatalf("splitServiceAccount(%q) = %q,%q; want %q,%q", tc.ref, namespace, name, tc.wantNamespace, tc.wantName)
+		}
+	}
+}
+
+func TestExtendAuthDelegatorRoleAppendsOnce(t *testing.T) {
+	role := &rbacv1.ClusterRole{}
+	ExtendAuthDelegatorRole(role)
+	if len(role.Rules) != 1 {
+		t.Fatalf("expected the SubjectAccessReview rule to be appended, got %+v", role.Rules)
+	}
+	ExtendAuthDelegatorRole(role)
+	if len(role.Rules) != 1 {
+		t.Fatalf("expected ExtendAuthDelegatorRole to be idempotent, got %d rules: %+v", len(role.Rules), role.Rules)
+	}
+}
+
+func TestExtendAuthDelegatorRolePreservesExistingRules(t *testing.T) {
+	role := &rbacv1.ClusterRole{Rules: []rbacv1.PolicyRule{{
+		APIGroups: []string{"authentication.k8s.io"},
+		Resources: []string{"tokenreviews"},
+		Verbs:     []string{"create"},
+	}}}
+	ExtendAuthDelegatorRole(role)
+	if len(role.Rules) != 2 {
+		t.Fatalf("expected the pre-existing rule to be kept alongside the new one, got %+v", role.Rules)
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// RouterObservabilityConfig is the "observability" section of the router
+// config block (alongside the existing image/resources/headers.propagate
+// fields) parsed from the inferenceservice-config ConfigMap's "router" key.
+// Setting OTLPEndpoint turns on trace export for the generated router
+// container.
+type RouterObservabilityConfig struct {
+	// OTLPEndpoint is injected as OTEL_EXPORTER_OTLP_ENDPOINT. Tracing is
+	// left disabled when empty.
+	OTLPEndpoint string `json:"otlpEndpoint,omitempty"`
+	// TracesSampler is injected as OTEL_TRACES_SAMPLER, e.g.
+	// "parentbased_traceidratio". Defaults to "parentbased_always_on" when
+	// empty so a graph with tracing enabled samples every request unless
+	// the operator tunes this down.
+	TracesSampler string `json:"tracesSampler,omitempty"`
+}
+
+// w3cTraceContextHeaders are added to PROPAGATE_HEADERS unconditionally
+// (independent of RouterObservabilityConfig) so span context still flows
+// across a graph's Sequence/Switch/Ensemble hops even when a step's own
+// instrumentation, rather than the router, started the trace.
+var w3cTraceContextHeaders = []string{"traceparent", "tracestate", "baggage"}
+
+// BuildObservabilityEnv builds the OTEL_EXPORTER_OTLP_ENDPOINT,
+// OTEL_SERVICE_NAME, and OTEL_TRACES_SAMPLER env vars for the router
+// container of the InferenceGraph named igName. Returns nil when cfg has no
+// OTLPEndpoint configured, leaving the router's tracing disabled.
+func BuildObservabilityEnv(igName string, cfg RouterObservabilityConfig) []corev1.EnvVar {
+	if cfg.OTLPEndpoint == "" {
+		return nil
+	}
+	sampler := cfg.TracesSampler
+	if sampler == "" {
+		sampler = "parentbased_always_on"
+	}
+	return []corev1.EnvVar{
+		{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: cfg.OTLPEndpoint},
+		{Name: "OTEL_SERVICE_NAME", Value: igName},
+		{Name: "OTEL_TRACES_SAMPLER", Value: sampler},
+	}
+}
+
+// ExpandPropagateHeaders appends the W3C trace-context headers to a router
+// config's headers.propagate list, deduplicating case-insensitively against
+// headers the user already listed, so span context propagates across every
+// graph hop regardless of whether observability is otherwise configured.
+func ExpandPropagateHeaders(propagate []string) []string {
+	seen := make(map[string]bool, len(propagate))
+	for _, h := range propagate {
+		seen[strings.ToLower(h)] = true
+	}
+	expanded := append([]string{}, propagate...)
+	for _, h := range w3cTraceContextHeaders {
+		if seen[strings.ToLower(h)] {
+			continue
+		}
+		expanded = append(expanded, h)
+		seen[strings.ToLower(h)] = true
+	}
+	return expanded
+}
+
+// defaultContentType is advertised when the router config's contentTypes
+// list is empty, preserving today's JSON-only behavior.
+const defaultContentType = "application/json"
+
+// ContentTypeHeaders builds the Accept and Content-Type header values the
+// router sends to a node's downstream ServiceURL from the router config's
+// contentTypes list (e.g. ["application/json", "application/cbor"]),
+// advertising every configured type in Accept but only the first as
+// Content-Type, since a single request body can only be encoded one way.
+func ContentTypeHeaders(contentTypes []string) (accept, contentType string) {
+	if len(contentTypes) == 0 {
+		return defaultContentType, defaultContentType
+	}
+	return strings.Join(contentTypes, ", "), contentTypes[0]
+}
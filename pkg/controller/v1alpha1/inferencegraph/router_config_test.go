@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildObservabilityEnvDisabledWhenNoEndpoint(t *testing.T) {
+	if env := BuildObservabilityEnv("graph", RouterObservabilityConfig{}); env != nil {
+		t.Fatalf("expected nil env when OTLPEndpoint is unset, got %+v", env)
+	}
+}
+
+func TestBuildObservabilityEnvDefaultsSampler(t *testing.T) {
+	env := BuildObservabilityEnv("graph", RouterObservabilityConfig{OTLPEndpoint: "otel:4317"})
+	want := map[string]string{
+		"OTEL_EXPORTER_OTLP_ENDPOINT": "otel:4317",
+		"OTEL_SERVICE_NAME":           "graph",
+		"OTEL_TRACES_SAMPLER":         "parentbased_always_on",
+	}
+	if len(env) != len(want) {
+		t.Fatalf("expected %d env vars, got %d: %+v", len(want), len(env), env)
+	}
+	for _, e := range env {
+		if want[e.Name] != e.Value {
+			t.Fatalf("unexpected value for %s: got %q, want %q", e.Name, e.Value, want[e.Name])
+		}
+	}
+}
+
+func TestBuildObservabilityEnvCustomSampler(t *testing.T) {
+	env := BuildObservabilityEnv("graph", RouterObservabilityConfig{OTLPEndpoint: "otel:4317", TracesSampler: "parentbased_traceidratio"})
+	for _, e := range env {
+		if e.Name == "OTEL_TRACES_SAMPLER" && e.Value != "parentbased_traceidratio" {
+			t.Fatalf("expected the configured sampler to be used, got %q", e.Value)
+		}
+	}
+}
+
+func TestExpandPropagateHeaders(t *testing.T) {
+	cases := []struct {
+		name      string
+		propagate []string
+		want      []string
+	}{
+		{
+			name:      "empty list gets all three w3c headers",
+			propagate: nil,
+			want:      []string{"traceparent", "tracestate", "baggage"},
+		},
+		{
+			name:      "dedupes case-insensitively against an already-listed header",
+			propagate: []string{"X-Request-Id", "TraceParent"},
+			want:      []string{"X-Request-Id", "TraceParent", "tracestate", "baggage"},
+		},
+		{
+			name:      "all three already present, nothing appended",
+			propagate: []string{"traceparent", "tracestate", "baggage"},
+			want:      []string{"traceparent", "tracestate", "baggage"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ExpandPropagateHeaders(tc.propagate)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("ExpandPropagateHeaders(%v) = %v, want %v", tc.propagate, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestContentTypeHeaders(t *testing.T) {
+	if accept, contentType := ContentTypeHeaders(nil); accept != "application/json" || contentType != "application/json" {
+		t.Fatalf("expected the default JSON content type, got accept=%q contentType=%q", accept, contentType)
+	}
+
+	accept, contentType := ContentTypeHeaders([]string{"application/json", "application/cbor"})
+	if accept != "application/json, application/cbor" {
+		t.Fatalf("expected Accept to list every configured type, got %q", accept)
+	}
+	if contentType != "application/json" {
+		t.Fatalf("expected Content-Type to be only the first configured type, got %q", contentType)
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// ExpiredEventReason is the Kubernetes Event reason emitted against an
+// InferenceGraph when IsExpired reports its TTL has elapsed and the
+// controller tears down its Knative Service, Route, and OAuth proxy
+// resources.
+const ExpiredEventReason = "Expired"
+
+// IsExpired reports whether an InferenceGraph created at createdAt should be
+// deleted, given an optional TTL in seconds after creation. A nil TTL means
+// the graph never expires.
+func IsExpired(createdAt time.Time, ttlSecondsAfterCreation *int64, now time.Time) bool {
+	if ttlSecondsAfterCreation == nil {
+		return false
+	}
+	return now.After(createdAt.Add(time.Duration(*ttlSecondsAfterCreation) * time.Second))
+}
+
+// ScheduleSpec flips an InferenceGraph's generated Knative Service between
+// an "active" and "idle" min-scale/initial-scale pair on a daily schedule,
+// e.g. so a demo graph zero-scales overnight even when the cluster disables
+// allow-zero-initial-scale. InferenceGraphSpec has no field of this type.
+type ScheduleSpec struct {
+	// Active is the 5-field cron expression (minute hour dom month dow) the
+	// graph returns to ActiveMinScale/ActiveInitialScale at. Only daily
+	// schedules are supported: dom, month, and dow must all be "*".
+	Active string `json:"active"`
+	// Idle is the 5-field cron expression the graph scales down to
+	// IdleMinScale/IdleInitialScale at. Only daily schedules are supported:
+	// dom, month, and dow must all be "*".
+	Idle string `json:"idle"`
+	// ActiveMinScale/ActiveInitialScale apply from Active until Idle next
+	// fires. Both default to 1 when unset.
+	// +optional
+	ActiveMinScale *int `json:"activeMinScale,omitempty"`
+	// +optional
+	ActiveInitialScale *int `json:"activeInitialScale,omitempty"`
+	// IdleMinScale/IdleInitialScale apply from Idle until Active next
+	// fires. Both default to 0 when unset.
+	// +optional
+	IdleMinScale *int `json:"idleMinScale,omitempty"`
+	// +optional
+	IdleInitialScale *int `json:"idleInitialScale,omitempty"`
+}
+
+// dailyCronTime parses a 5-field cron expression restricted to plain daily
+// schedules (dom, month, and dow all "*"), returning the hour/minute it
+// fires at. ScheduleSpec only needs a daily active/idle flip, so a full cron
+// implementation (specific days, months, step values, ranges) isn't
+// supported here.
+func dailyCronTime(expr string) (hour, minute int, err error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return 0, 0, fmt.Errorf("cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+	if fields[2] != "*" || fields[3] != "*" || fields[4] != "*" {
+		return 0, 0, fmt.Errorf("cron expression %q: only daily schedules (dom/month/dow = \"*\") are supported", expr)
+	}
+	minute, err = strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("cron expression %q: invalid minute field: %w", expr, err)
+	}
+	hour, err = strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("cron expression %q: invalid hour field: %w", expr, err)
+	}
+	return hour, minute, nil
+}
+
+// IsIdle reports whether schedule's Idle cron has most recently fired
+// relative to now, i.e. whether the graph should currently be in its idle
+// window. A nil schedule is never idle.
+func IsIdle(schedule *ScheduleSpec, now time.Time) (bool, error) {
+	if schedule == nil {
+		return false, nil
+	}
+	activeHour, activeMinute, err := dailyCronTime(schedule.Active)
+	if err != nil {
+		return false, err
+	}
+	idleHour, idleMinute, err := dailyCronTime(schedule.Idle)
+	if err != nil {
+		return false, err
+	}
+
+	nowMinutes := now.Hour()*60 + now.Minute()
+	activeMinutes := activeHour*60 + activeMinute
+	idleMinutes := idleHour*60 + idleMinute
+	if activeMinutes == idleMinutes {
+		return false, nil
+	}
+	if idleMinutes < activeMinutes {
+		// Idle fires earlier in the day than Active, e.g. idle=02:00,
+		// active=08:00: the idle window is same-day, [idle, active).
+		return nowMinutes >= idleMinutes && nowMinutes < activeMinutes, nil
+	}
+	// Idle fires later in the day than Active, e.g. idle=20:00,
+	// active=08:00: the idle window wraps past midnight, [idle, 24:00) union
+	// [00:00, active).
+	return nowMinutes >= idleMinutes || nowMinutes < activeMinutes, nil
+}
+
+// ApplyScheduleAnnotations sets the Knative min-scale/initial-scale
+// annotations for the active or idle half of schedule, defaulting
+// ActiveMinScale/ActiveInitialScale to 1 and IdleMinScale/IdleInitialScale
+// to 0 when unset, so a graph that only sets Active/Idle cron expressions
+// still zero-scales while idle.
+func ApplyScheduleAnnotations(annotations map[string]string, schedule *ScheduleSpec, idle bool) map[string]string {
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	minScale, initialScale := 1, 1
+	if idle {
+		minScale, initialScale = 0, 0
+	}
+	if idle {
+		if schedule.IdleMinScale != nil {
+			minScale = *schedule.IdleMinScale
+		}
+		if schedule.IdleInitialScale != nil {
+			initialScale = *schedule.IdleInitialScale
+		}
+	} else {
+		if schedule.ActiveMinScale != nil {
+			minScale = *schedule.ActiveMinScale
+		}
+		if schedule.ActiveInitialScale != nil {
+			initialScale = *schedule.ActiveInitialScale
+		}
+	}
+	annotations[constants.MinScaleAnnotationKey] = strconv.Itoa(minScale)
+	annotations[constants.InitialScaleAnnotationKey] = strconv.Itoa(initialScale)
+	return annotations
+}
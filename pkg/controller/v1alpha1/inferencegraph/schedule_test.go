@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestIsExpired(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	ttl := int64(3600)
+
+	if IsExpired(createdAt, nil, createdAt.Add(100*time.Hour)) {
+		t.Fatalf("expected a nil TTL to never expire")
+	}
+	if IsExpired(createdAt, &ttl, createdAt.Add(30*time.Minute)) {
+		t.Fatalf("expected not yet expired before the TTL elapses")
+	}
+	if !IsExpired(createdAt, &ttl, createdAt.Add(2*time.Hour)) {
+		t.Fatalf("expected expired once the TTL has elapsed")
+	}
+}
+
+func TestDailyCronTime(t *testing.T) {
+	hour, minute, err := dailyCronTime("30 8 * * *")
+	if err != nil || hour != 8 || minute != 30 {
+		t.Fatalf("dailyCronTime(\"30 8 * * *\") = %d,%d,%v; want 8,30,nil", hour, minute, err)
+	}
+
+	if _, _, err := dailyCronTime("30 8 1 * *"); err == nil {
+		t.Fatalf("expected an error for a non-daily (dom != \"*\") schedule")
+	}
+	if _, _, err := dailyCronTime("30 8 *"); err == nil {
+		t.Fatalf("expected an error for a cron expression with the wrong field count")
+	}
+	if _, _, err := dailyCronTime("abc 8 * * *"); err == nil {
+		t.Fatalf("expected an error for a non-numeric minute field")
+	}
+}
+
+func TestIsIdleNilSchedule(t *testing.T) {
+	idle, err := IsIdle(nil, time.Now())
+	if err != nil || idle {
+		t.Fatalf("expected a nil schedule to never be idle, got idle=%v err=%v", idle, err)
+	}
+}
+
+func TestIsIdleActiveAndIdleHours(t *testing.T) {
+	schedule := &ScheduleSpec{Active: "0 8 * * *", Idle: "0 20 * * *"}
+
+	cases := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{name: "during active hours", now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC), want: false},
+		{name: "during idle hours", now: time.Date(2026, 1, 1, 22, 0, 0, 0, time.UTC), want: true},
+		{name: "exactly at idle time", now: time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC), want: true},
+		{name: "exactly at active time", now: time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC), want: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := IsIdle(schedule, tc.now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("IsIdle() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsIdleWrapsPastMidnight(t *testing.T) {
+	// idle=20:00, active=08:00: idle window wraps across midnight.
+	schedule := &ScheduleSpec{Active: "0 8 * * *", Idle: "0 20 * * *"}
+	if idle, err := IsIdle(schedule, time.Date(2026, 1, 1, 23, 0, 0, 0, time.UTC)); err != nil || !idle {
+		t.Fatalf("expected idle after 20:00, got idle=%v err=%v", idle, err)
+	}
+	if idle, err := IsIdle(schedule, time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC)); err != nil || !idle {
+		t.Fatalf("expected idle before 08:00 (past-midnight wrap), got idle=%v err=%v", idle, err)
+	}
+}
+
+func TestIsIdleInvalidCronPropagatesError(t *testing.T) {
+	schedule := &ScheduleSpec{Active: "not a cron", Idle: "0 20 * * *"}
+	if _, err := IsIdle(schedule, time.Now()); err == nil {
+		t.Fatalf("expected an error from an invalid Active cron expression")
+	}
+}
+
+func TestApplyScheduleAnnotationsDefaults(t *testing.T) {
+	schedule := &ScheduleSpec{Active: "0 8 * * *", Idle: "0 20 * * *"}
+
+	active := ApplyScheduleAnnotations(nil, schedule, false)
+	if active[constants.MinScaleAnnotationKey] != "1" || active[constants.InitialScaleAnnotationKey] != "1" {
+		t.Fatalf("expected active defaults of min/initial scale 1, got %+v", active)
+	}
+
+	idle := ApplyScheduleAnnotations(nil, schedule, true)
+	if idle[constants.MinScaleAnnotationKey] != "0" || idle[constants.InitialScaleAnnotationKey] != "0" {
+		t.Fatalf("expected idle defaults of min/initial scale 0, got %+v", idle)
+	}
+}
+
+func TestApplyScheduleAnnotationsOverrides(t *testing.T) {
+	two, three := 2, 3
+	schedule := &ScheduleSpec{
+		Active:             "0 8 * * *",
+		Idle:               "0 20 * * *",
+		ActiveMinScale:     &two,
+		ActiveInitialScale: &three,
+	}
+	got := ApplyScheduleAnnotations(map[string]string{"keep": "me"}, schedule, false)
+	if got[constants.MinScaleAnnotationKey] != "2" || got[constants.InitialScaleAnnotationKey] != "3" {
+		t.Fatalf("expected overridden min/initial scale, got %+v", got)
+	}
+	if got["keep"] != "me" {
+		t.Fatalf("expected a pre-existing annotation to be preserved, got %+v", got)
+	}
+}
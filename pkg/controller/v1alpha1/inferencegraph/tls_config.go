@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// caBundleVolumeName is the volume/mount name used for both the default
+// OpenShift service-ca bundle and a user-supplied CA bundle, so switching
+// between them doesn't otherwise change the router PodSpec's shape.
+const caBundleVolumeName = "openshift-service-ca-bundle"
+
+// defaultCABundleMountPath is where the default OpenShift service-ca bundle
+// (and, for consistency, a BYO CA bundle) is mounted; SSL_CERT_FILE points
+// at the single file this directory is expected to contain.
+const defaultCABundleMountPath = "/etc/odh/openshift-service-ca-bundle"
+
+// TLSSpec lets a user point the InferenceGraph router at their own CA
+// bundle and serving certificate instead of the cluster's default
+// OpenShift service-ca ConfigMap, following the same BYO-CA pattern as
+// Cluster API's cluster CA support. InferenceGraphSpec has no field of this
+// type.
+type TLSSpec struct {
+	// CACertSecretRef names a Secret, in the InferenceGraph's namespace,
+	// whose "service-ca.crt" key replaces the default OpenShift
+	// service-ca ConfigMap as the router's SSL_CERT_FILE.
+	// +optional
+	CACertSecretRef *corev1.LocalObjectReference `json:"caCertSecretRef,omitempty"`
+	// ServingCertSecretRef names a Secret, in the InferenceGraph's
+	// namespace, with "tls.crt"/"tls.key" keys the router serves its own
+	// traffic with, passed to the router container as --tls-cert/--tls-key.
+	// +optional
+	ServingCertSecretRef *corev1.LocalObjectReference `json:"servingCertSecretRef,omitempty"`
+	// InsecureSkipVerify disables the router's verification of downstream
+	// ServiceURL certificates. Defaults to false; only meant for
+	// development clusters with self-signed step certificates.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// BuildCABundleVolume builds the router pod's CA bundle Volume: the default
+// OpenShift service-ca ConfigMap when tls is nil or sets no
+// CACertSecretRef, or a projection of the user's Secret otherwise.
+func BuildCABundleVolume(tls *TLSSpec) corev1.Volume {
+	if tls != nil && tls.CACertSecretRef != nil {
+		return corev1.Volume{
+			Name: caBundleVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: tls.CACertSecretRef.Name,
+				},
+			},
+		}
+	}
+	return corev1.Volume{
+		Name: caBundleVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: constants.OpenShiftServiceCaConfigMapName,
+				},
+			},
+		},
+	}
+}
+
+// BuildCABundleVolumeMount is the router container's mount of
+// BuildCABundleVolume, unchanged regardless of whether the volume's source
+// is the default ConfigMap or a BYO Secret.
+func BuildCABundleVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      caBundleVolumeName,
+		MountPath: defaultCABundleMountPath,
+	}
+}
+
+// SSLCertFileEnv builds the router container's SSL_CERT_FILE env var,
+// always pointing into BuildCABundleVolumeMount's mount path regardless of
+// the underlying volume source.
+func SSLCertFileEnv() corev1.EnvVar {
+	return corev1.EnvVar{
+		Name:  "SSL_CERT_FILE",
+		Value: defaultCABundleMountPath + "/service-ca.crt",
+	}
+}
+
+// BuildServingCertArgs returns the router container's --tls-cert/--tls-key
+// args and the VolumeMount/Volume projecting tls.ServingCertSecretRef, so
+// the router can terminate TLS with the user's own serving certificate
+// instead of only ever serving plaintext behind Knative's own TLS
+// termination. Returns zero values when tls is nil or sets no
+// ServingCertSecretRef.
+func BuildServingCertArgs(tls *TLSSpec) (args []string, mount corev1.VolumeMount, volume corev1.Volume, ok bool) {
+	if tls == nil || tls.ServingCertSecretRef == nil {
+		return nil, corev1.VolumeMount{}, corev1.Volume{}, false
+	}
+	const mountPath = "/etc/odh/router-serving-cert"
+	args = []string{
+		"--tls-cert", mountPath + "/tls.crt",
+		"--tls-key", mountPath + "/tls.key",
+	}
+	mount = corev1.VolumeMount{Name: "router-serving-cert", MountPath: mountPath}
+	volume = corev1.Volume{
+		Name: "router-serving-cert",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: tls.ServingCertSecretRef.Name},
+		},
+	}
+	return args, mount, volume, true
+}
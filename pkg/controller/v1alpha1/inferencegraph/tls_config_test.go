@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestBuildCABundleVolumeDefault(t *testing.T) {
+	for _, tls := range []*TLSSpec{nil, {}} {
+		vol := BuildCABundleVolume(tls)
+		if vol.Name != caBundleVolumeName {
+			t.Fatalf("unexpected volume name: %q", vol.Name)
+		}
+		if vol.ConfigMap == nil || vol.ConfigMap.Name != constants.OpenShiftServiceCaConfigMapName {
+			t.Fatalf("expected the default service-ca ConfigMap source, got %+v", vol)
+		}
+		if vol.Secret != nil {
+			t.Fatalf("expected no Secret source for the default volume, got %+v", vol.Secret)
+		}
+	}
+}
+
+func TestBuildCABundleVolumeUserSecret(t *testing.T) {
+	tls := &TLSSpec{CACertSecretRef: &corev1.LocalObjectReference{Name: "my-ca"}}
+	vol := BuildCABundleVolume(tls)
+	if vol.Secret == nil || vol.Secret.SecretName != "my-ca" {
+		t.Fatalf("expected a Secret source naming the user's CA secret, got %+v", vol)
+	}
+	if vol.ConfigMap != nil {
+		t.Fatalf("expected no ConfigMap source when a CACertSecretRef is set, got %+v", vol.ConfigMap)
+	}
+}
+
+func TestBuildCABundleVolumeMount(t *testing.T) {
+	mount := BuildCABundleVolumeMount()
+	if mount.Name != caBundleVolumeName || mount.MountPath != defaultCABundleMountPath {
+		t.Fatalf("unexpected mount: %+v", mount)
+	}
+}
+
+func TestSSLCertFileEnv(t *testing.T) {
+	env := SSLCertFileEnv()
+	if env.Name != "SSL_CERT_FILE" {
+		t.Fatalf("unexpected env var name: %q", env.Name)
+	}
+	if !strings.HasPrefix(env.Value, defaultCABundleMountPath) {
+		t.Fatalf("expected SSL_CERT_FILE to point into the CA bundle mount path, got %q", env.Value)
+	}
+}
+
+func TestBuildServingCertArgsUnset(t *testing.T) {
+	for _, tls := range []*TLSSpec{nil, {}} {
+		args, mount, volume, ok := BuildServingCertArgs(tls)
+		if ok {
+			t.Fatalf("expected ok=false with no ServingCertSecretRef")
+		}
+		if len(args) != 0 || mount.Name != "" || volume.Name != "" {
+			t.Fatalf("expected zero values when ok=false, got args=%v mount=%+v volume=%+v", args, mount, volume)
+		}
+	}
+}
+
+func TestBuildServingCertArgsSet(t *testing.T) {
+	tls := &TLSSpec{ServingCertSecretRef: &corev1.LocalObjectReference{Name: "router-cert"}}
+	args, mount, volume, ok := BuildServingCertArgs(tls)
+	if !ok {
+		t.Fatalf("expected ok=true when ServingCertSecretRef is set")
+	}
+	if len(args) != 4 || args[0] != "--tls-cert" || args[2] != "--tls-key" {
+		t.Fatalf("unexpected args: %v", args)
+	}
+	if mount.Name != volume.Name {
+		t.Fatalf("expected the mount and volume names to match, got mount=%q volume=%q", mount.Name, volume.Name)
+	}
+	if volume.Secret == nil || volume.Secret.SecretName != "router-cert" {
+		t.Fatalf("expected the volume to project the configured Secret, got %+v", volume)
+	}
+}
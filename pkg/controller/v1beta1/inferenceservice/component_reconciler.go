@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferenceservice
+
+import (
+	"context"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// ComponentReconciler reconciles exactly one component (predictor,
+// transformer, or explainer) of an InferenceService. Splitting the previously
+// monolithic Reconcile() into one ComponentReconciler per component lets each
+// component's Deployment/Service/HPA/Route be reconciled and requeued
+// independently: a failing explainer no longer blocks the predictor from
+// becoming ready, and a change to just the transformer only requeues the
+// transformer's ComponentReconciler rather than the whole InferenceService.
+type ComponentReconciler interface {
+	// Component identifies which component this reconciler is responsible for.
+	Component() constants.InferenceServiceComponent
+	// Reconcile reconciles this component's child resources and returns the
+	// component's status to be merged into the InferenceService's status by
+	// the coordinating reconciler.
+	Reconcile(ctx context.Context, isvc *v1beta1.InferenceService) (*v1beta1.ComponentStatusSpec, error)
+}
+
+// ComponentReconcilers fans the shared InferenceService out to one
+// ComponentReconciler per configured component. All three share the same
+// priority workqueue (see NewPriorityQueue) so backpressure and failure
+// backoff apply uniformly regardless of which component triggered the
+// requeue.
+type ComponentReconcilers struct {
+	reconcilers []ComponentReconciler
+}
+
+// NewComponentReconcilers builds the set of ComponentReconcilers to run for
+// an InferenceService, skipping components that aren't configured.
+func NewComponentReconcilers(isvc *v1beta1.InferenceService, build func(constants.InferenceServiceComponent) ComponentReconciler) *ComponentReconcilers {
+	components := []constants.InferenceServiceComponent{constants.Predictor}
+	if isvc.Spec.Transformer != nil {
+		components = append(components, constants.Transformer)
+	}
+	if isvc.Spec.Explainer != nil {
+		components = append(components, constants.Explainer)
+	}
+
+	reconcilers := make([]ComponentReconciler, 0, len(components))
+	for _, component := range components {
+		reconcilers = append(reconcilers, build(component))
+	}
+	return &ComponentReconcilers{reconcilers: reconcilers}
+}
+
+// ReconcileAll runs every configured ComponentReconciler and aggregates their
+// per-component statuses, continuing past a single component's error so one
+// failing component doesn't prevent the others from reconciling.
+func (c *ComponentReconcilers) ReconcileAll(ctx context.Context, isvc *v1beta1.InferenceService) (map[constants.InferenceServiceComponent]*v1beta1.ComponentStatusSpec, error) {
+	statuses := make(map[constants.InferenceServiceComponent]*v1beta1.ComponentStatusSpec, len(c.reconcilers))
+	var firstErr error
+	for _, reconciler := range c.reconcilers {
+		status, err := reconciler.Reconcile(ctx, isvc)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		statuses[reconciler.Component()] = status
+	}
+	return statuses, firstErr
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferenceservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+type stubComponentReconciler struct {
+	component constants.InferenceServiceComponent
+	status    *v1beta1.ComponentStatusSpec
+	err       error
+}
+
+func (s *stubComponentReconciler) Component() constants.InferenceServiceComponent {
+	return s.component
+}
+
+func (s *stubComponentReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService) (*v1beta1.ComponentStatusSpec, error) {
+	return s.status, s.err
+}
+
+func TestNewComponentReconcilersPredictorOnly(t *testing.T) {
+	isvc := &v1beta1.InferenceService{}
+
+	var built []constants.InferenceServiceComponent
+	reconcilers := NewComponentReconcilers(isvc, func(c constants.InferenceServiceComponent) ComponentReconciler {
+		built = append(built, c)
+		return &stubComponentReconciler{component: c}
+	})
+
+	if len(reconcilers.reconcilers) != 1 {
+		t.Fatalf("expected only the predictor to be built, got %+v", built)
+	}
+	if built[0] != constants.Predictor {
+		t.Fatalf("expected predictor, got %v", built[0])
+	}
+}
+
+func TestNewComponentReconcilersAllComponents(t *testing.T) {
+	isvc := &v1beta1.InferenceService{
+		Spec: v1beta1.InferenceServiceSpec{
+			Transformer: &v1beta1.TransformerSpec{},
+			Explainer:   &v1beta1.ExplainerSpec{},
+		},
+	}
+
+	var built []constants.InferenceServiceComponent
+	NewComponentReconcilers(isvc, func(c constants.InferenceServiceComponent) ComponentReconciler {
+		built = append(built, c)
+		return &stubComponentReconciler{component: c}
+	})
+
+	want := []constants.InferenceServiceComponent{constants.Predictor, constants.Transformer, constants.Explainer}
+	if len(built) != len(want) {
+		t.Fatalf("expected %v, got %v", want, built)
+	}
+	for i, c := range want {
+		if built[i] != c {
+			t.Fatalf("expected %v at position %d, got %v", c, i, built[i])
+		}
+	}
+}
+
+func TestReconcileAllAggregatesStatuses(t *testing.T) {
+	predictorStatus := &v1beta1.ComponentStatusSpec{}
+	transformerStatus := &v1beta1.ComponentStatusSpec{}
+	reconcilers := &ComponentReconcilers{reconcilers: []ComponentReconciler{
+		&stubComponentReconciler{component: constants.Predictor, status: predictorStatus},
+		&stubComponentReconciler{component: constants.Transformer, status: transformerStatus},
+	}}
+
+	statuses, err := reconcilers.ReconcileAll(context.Background(), &v1beta1.InferenceService{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statuses[constants.Predictor] != predictorStatus || statuses[constants.Transformer] != transformerStatus {
+		t.Fatalf("expected per-component statuses to be preserved, got %+v", statuses)
+	}
+}
+
+func TestReconcileAllContinuesPastAFailingComponentAndReturnsFirstError(t *testing.T) {
+	predictorErr := errors.New("predictor failed")
+	explainerErr := errors.New("explainer failed")
+	explainerStatus := &v1beta1.ComponentStatusSpec{}
+	reconcilers := &ComponentReconcilers{reconcilers: []ComponentReconciler{
+		&stubComponentReconciler{component: constants.Predictor, err: predictorErr},
+		&stubComponentReconciler{component: constants.Explainer, status: explainerStatus, err: explainerErr},
+	}}
+
+	statuses, err := reconcilers.ReconcileAll(context.Background(), &v1beta1.InferenceService{})
+	if !errors.Is(err, predictorErr) {
+		t.Fatalf("expected the first component's error to be returned, got %v", err)
+	}
+	if statuses[constants.Explainer] != explainerStatus {
+		t.Fatalf("expected the explainer to still reconcile and report its status despite the predictor's error")
+	}
+}
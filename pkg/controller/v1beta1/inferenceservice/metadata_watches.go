@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferenceservice
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress"
+)
+
+// watchOwnedMetadataOnly registers a metadata-only Owns() watch for a child
+// resource kind: the informer cache only stores ObjectMeta/TypeMeta for these
+// objects (no spec/status), which is sufficient since the reconciler only
+// reacts to create/update/delete events on objects it owns and always
+// re-derives the desired spec from the InferenceService rather than diffing
+// against the cached copy. This is the dominant source of controller memory
+// use in clusters with many Deployments/Services/HPAs, so trimming it for
+// owned-but-not-otherwise-read kinds materially lowers the controller's
+// resident memory.
+//
+// HTTPRoute is deliberately excluded here: unlike the other owned kinds, the
+// reconciler needs to read back Status.Parents to know whether the gateway
+// actually bound the route (see watchHTTPRouteStatus), which a metadata-only
+// cache entry strips.
+func watchOwnedMetadataOnly(bldr *builder.Builder, owner runtime.Object) *builder.Builder {
+	for _, child := range []runtime.Object{
+		&appsv1.Deployment{},
+		&corev1.Service{},
+		&autoscalingv2.HorizontalPodAutoscaler{},
+		&netv1.Ingress{},
+	} {
+		bldr = bldr.Watches(
+			child,
+			&handler.EnqueueRequestForOwner{OwnerType: owner, IsController: true},
+			builder.OnlyMetadata,
+		)
+	}
+	return bldr
+}
+
+// watchHTTPRouteStatus registers a full-object Owns()-equivalent watch for
+// HTTPRoute, gated by RouteParentStatusChangedPredicate so the controller
+// only re-reconciles when Status.Parents actually changed (e.g. the gateway
+// flips Accepted to False), not on every unrelated spec/annotation edit.
+func watchHTTPRouteStatus(bldr *builder.Builder, owner runtime.Object) *builder.Builder {
+	return bldr.Watches(
+		&gatewayapiv1.HTTPRoute{},
+		&handler.EnqueueRequestForOwner{OwnerType: owner, IsController: true},
+		builder.WithPredicates(ingress.RouteParentStatusChangedPredicate()),
+	)
+}
+
+// NewControllerBuilderWithMetadataOnlyWatches is the entry point the
+// InferenceService controller's SetupWithManager should use instead of
+// chaining `.Owns(...)` directly for Deployment/Service/HPA/HTTPRoute, so the
+// metadata-only-cacheable kinds go through the trimmed path while HTTPRoute
+// still gets its full status read back for IngressReady.
+func NewControllerBuilderWithMetadataOnlyWatches(mgr ctrl.Manager, owner runtime.Object) *builder.Builder {
+	bldr := watchOwnedMetadataOnly(ctrl.NewControllerManagedBy(mgr).For(owner), owner)
+	return watchHTTPRouteStatus(bldr, owner)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferenceservice
+
+import (
+	"testing"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+// NewControllerBuilderWithMetadataOnlyWatches only appends to the builder's
+// configuration (For/Owns/Watches don't touch the manager until Complete()),
+// so it can be exercised with a nil manager to assert it wires up every
+// expected child kind without panicking.
+func TestNewControllerBuilderWithMetadataOnlyWatches(t *testing.T) {
+	owner := &v1beta1.InferenceService{}
+
+	bldr := NewControllerBuilderWithMetadataOnlyWatches(nil, owner)
+
+	if bldr == nil {
+		t.Fatalf("expected a non-nil builder")
+	}
+}
+
+func TestWatchOwnedMetadataOnlyReturnsSameBuilder(t *testing.T) {
+	owner := &v1beta1.InferenceService{}
+	base := ctrl.NewControllerManagedBy(nil).For(owner)
+
+	bldr := watchOwnedMetadataOnly(base, owner)
+
+	if bldr != base {
+		t.Fatalf("expected watchOwnedMetadataOnly to return the same builder it chains onto")
+	}
+}
+
+func TestWatchHTTPRouteStatusReturnsSameBuilder(t *testing.T) {
+	owner := &v1beta1.InferenceService{}
+	base := ctrl.NewControllerManagedBy(nil).For(owner)
+
+	bldr := watchHTTPRouteStatus(base, owner)
+
+	if bldr != base {
+		t.Fatalf("expected watchHTTPRouteStatus to return the same builder it chains onto")
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2021 The KServe Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package readiness aggregates readiness across every child resource a raw
+// InferenceService reconciler owns (Deployment, Service, HPA, HTTPRoute, and
+// any user-declared auxiliary workloads), following the same
+// poll-until-ready approach Helm 3 uses for `helm install --wait`: each
+// resource kind has its own notion of "ready" rather than the reconciler
+// special-casing Deployment.Status.Conditions as it did before.
+package readiness
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// Strategy reports whether obj has reached a ready state, and a
+// human-readable reason to surface on the aggregated condition when it
+// hasn't.
+type Strategy interface {
+	Ready(obj runtime.Object) (bool, string, error)
+}
+
+// StrategyFunc adapts a plain function to a Strategy.
+type StrategyFunc func(obj runtime.Object) (bool, string, error)
+
+func (f StrategyFunc) Ready(obj runtime.Object) (bool, string, error) {
+	return f(obj)
+}
+
+// defaultStrategies is keyed by the same GroupVersionKind string
+// (apiVersion/kind) the unstructured ingress backends use, plus the core
+// typed kinds the raw reconciler owns directly.
+var defaultStrategies = map[string]Strategy{
+	"apps/v1, Kind=Deployment":                     StrategyFunc(deploymentReady),
+	"v1, Kind=Service":                             StrategyFunc(serviceReady),
+	"autoscaling/v2, Kind=HorizontalPodAutoscaler": StrategyFunc(hpaReady),
+	"gateway.networking.k8s.io/v1, Kind=HTTPRoute": StrategyFunc(httpRouteReady),
+}
+
+// Result is the outcome of checking one dependent resource's readiness.
+type Result struct {
+	Kind   string
+	Name   string
+	Ready  bool
+	Reason string
+}
+
+// Aggregator polls every registered dependent resource and reports whether
+// they're all ready.
+type Aggregator struct {
+	strategies map[string]Strategy
+}
+
+// NewAggregator builds an Aggregator seeded with the default strategies for
+// Deployment, Service, HorizontalPodAutoscaler, and HTTPRoute. Callers can
+// Register additional strategies for user-declared auxiliary workloads.
+func NewAggregator() *Aggregator {
+	strategies := make(map[string]Strategy, len(defaultStrategies))
+	for k, v := range defaultStrategies {
+		strategies[k] = v
+	}
+	return &Aggregator{strategies: strategies}
+}
+
+// Register adds or overrides the Strategy used for a given "apiVersion, Kind=X" key.
+func (a *Aggregator) Register(gvk string, strategy Strategy) {
+	a.strategies[gvk] = strategy
+}
+
+// Dependent pairs a resource with the GVK key used to look up its Strategy.
+type Dependent struct {
+	GVK    string
+	Name   string
+	Object runtime.Object
+}
+
+// Aggregate evaluates every dependent resource and returns the overall
+// readiness plus a Result per resource, the inputs to the
+// v1beta1.DependenciesReady condition.
+func (a *Aggregator) Aggregate(dependents []Dependent) (bool, []Result, error) {
+	results := make([]Result, 0, len(dependents))
+	allReady := true
+	for _, d := range dependents {
+		strategy, ok := a.strategies[d.GVK]
+		if !ok {
+			results = append(results, Result{Kind: d.GVK, Name: d.Name, Ready: false, Reason: "no readiness strategy registered for " + d.GVK})
+			allReady = false
+			continue
+		}
+		ready, reason, err := strategy.Ready(d.Object)
+		if err != nil {
+			return false, results, err
+		}
+		if !ready {
+			allReady = false
+		}
+		results = append(results, Result{Kind: d.GVK, Name: d.Name, Ready: ready, Reason: reason})
+	}
+	return allReady, results, nil
+}
+
+func deploymentReady(obj runtime.Object) (bool, string, error) {
+	deploy, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		return false, "object is not a Deployment", nil
+	}
+	desired := int32(1)
+	if deploy.Spec.Replicas != nil {
+		desired = *deploy.Spec.Replicas
+	}
+	if deploy.Status.UpdatedReplicas < desired {
+		return false, "waiting for updated replicas to roll out", nil
+	}
+	if deploy.Status.AvailableReplicas < desired {
+		return false, "waiting for replicas to become available", nil
+	}
+	return true, "", nil
+}
+
+func serviceReady(obj runtime.Object) (bool, string, error) {
+	svc, ok := obj.(*corev1.Service)
+	if !ok {
+		return false, "object is not a Service", nil
+	}
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer && len(svc.Status.LoadBalancer.Ingress) == 0 {
+		return false, "waiting for load balancer ingress to be assigned", nil
+	}
+	return true, "", nil
+}
+
+func hpaReady(obj runtime.Object) (bool, string, error) {
+	hpa, ok := obj.(*autoscalingv2.HorizontalPodAutoscaler)
+	if !ok {
+		return false, "object is not a HorizontalPodAutoscaler", nil
+	}
+	for _, cond := range hpa.Status.Conditions {
+		if cond.Type == autoscalingv2.ScalingActive && cond.Status != corev1.ConditionTrue {
+			return false, "HorizontalPodAutoscaler is not actively scaling: " + cond.Message, nil
+		}
+	}
+	return true, "", nil
+}
+
+func httpRouteReady(obj runtime.Object) (bool, string, error) {
+	route, ok := obj.(*gatewayapiv1.HTTPRoute)
+	if !ok {
+		return false, "object is not an HTTPRoute", nil
+	}
+	if len(route.Status.Parents) == 0 {
+		return false, "waiting for a parent Gateway to report status", nil
+	}
+	for _, parent := range route.Status.Parents {
+		accepted := false
+		for _, cond := range parent.Conditions {
+			if cond.Type == string(gatewayapiv1.RouteConditionAccepted) && cond.Status == "True" {
+				accepted = true
+			}
+		}
+		if !accepted {
+			return false, "waiting for parent Gateway to accept the route", nil
+		}
+	}
+	return true, "", nil
+}
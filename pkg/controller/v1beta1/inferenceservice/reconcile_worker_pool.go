@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferenceservice
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	reconcileQueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "kserve_reconcile_queue_depth",
+		Help: "Number of InferenceService keys currently pending on the reconcile workqueue.",
+	})
+	reconcileLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "kserve_reconcile_latency_seconds",
+		Help:    "Time spent reconciling a single InferenceService key, from Get() to Done().",
+		Buckets: prometheus.DefBuckets,
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(reconcileQueueDepth, reconcileLatency)
+}
+
+// ReconcileFunc is the per-key work a worker pool drains the queue into; it
+// mirrors reconcile.Reconciler's Reconcile but without the Result, since the
+// workqueue (not the caller) decides whether/when to retry.
+type ReconcileFunc func(ctx context.Context, key types.NamespacedName) error
+
+// dedupingWorkerPool drains a priorityQueue with a fixed pool of goroutines,
+// coalescing concurrent duplicate work: if two events for the same key are
+// queued close together (e.g. a status write racing a spec update), only one
+// of them is ever in flight at a time, and the other waits rather than
+// reconciling the same object concurrently in two workers.
+type dedupingWorkerPool struct {
+	queue     *priorityQueue
+	reconcile ReconcileFunc
+
+	mu       sync.Mutex
+	inFlight map[types.NamespacedName]struct{}
+}
+
+// StartWorkerPool launches workerCount goroutines draining queue until ctx is
+// canceled, each invoking reconcile for the keys it pops. Coalescing plus the
+// queue's own rate limiter (see NewRateLimiter) means a key that fails
+// repeatedly backs off exponentially instead of spinning the pool.
+func StartWorkerPool(ctx context.Context, queue *priorityQueue, workerCount int, reconcile ReconcileFunc) {
+	pool := &dedupingWorkerPool{
+		queue:     queue,
+		reconcile: reconcile,
+		inFlight:  make(map[types.NamespacedName]struct{}),
+	}
+	for i := 0; i < workerCount; i++ {
+		go pool.run(ctx)
+	}
+}
+
+func (p *dedupingWorkerPool) run(ctx context.Context) {
+	for {
+		item, shutdown := p.queue.Get()
+		if shutdown {
+			return
+		}
+		reconcileQueueDepth.Set(float64(p.queue.Len()))
+		p.process(ctx, item)
+	}
+}
+
+func (p *dedupingWorkerPool) process(ctx context.Context, item reconcileRequest) {
+	key := types.NamespacedName{Namespace: item.namespace, Name: item.name}
+	defer p.queue.Done(item)
+
+	if !p.claim(key) {
+		// Another worker already holds this key; re-enqueue at the same
+		// priority so the pending change is still picked up once it's free,
+		// rather than dropping it or blocking this worker on a lock.
+		p.queue.AddWithPriority(item.namespace, item.name, item.priority)
+		return
+	}
+	defer p.release(key)
+
+	start := time.Now()
+	err := p.reconcile(ctx, key)
+	reconcileLatency.Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		p.queue.AddRateLimited(item)
+		return
+	}
+	p.queue.Forget(item)
+}
+
+// claim reports whether key was not already in flight, marking it in flight
+// if so.
+func (p *dedupingWorkerPool) claim(key types.NamespacedName) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, busy := p.inFlight[key]; busy {
+		return false
+	}
+	p.inFlight[key] = struct{}{}
+	return true
+}
+
+func (p *dedupingWorkerPool) release(key types.NamespacedName) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.inFlight, key)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferenceservice
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newTestWorkerPool() *dedupingWorkerPool {
+	return &dedupingWorkerPool{
+		queue:    NewPriorityQueue("test"),
+		inFlight: make(map[types.NamespacedName]struct{}),
+	}
+}
+
+func TestClaimAndRelease(t *testing.T) {
+	pool := newTestWorkerPool()
+	key := types.NamespacedName{Namespace: "ns", Name: "isvc"}
+
+	if !pool.claim(key) {
+		t.Fatalf("expected the first claim to succeed")
+	}
+	if pool.claim(key) {
+		t.Fatalf("expected a second claim on the same key to fail while in flight")
+	}
+
+	pool.release(key)
+	if !pool.claim(key) {
+		t.Fatalf("expected the key to be claimable again after release")
+	}
+}
+
+func TestProcessSuccessForgetsItemAndReleasesKey(t *testing.T) {
+	pool := newTestWorkerPool()
+	pool.queue.AddWithPriority("ns", "isvc", 0)
+	item, _ := pool.queue.Get()
+
+	var called types.NamespacedName
+	pool.reconcile = func(ctx context.Context, key types.NamespacedName) error {
+		called = key
+		return nil
+	}
+
+	pool.process(context.Background(), item)
+
+	if called != (types.NamespacedName{Namespace: "ns", Name: "isvc"}) {
+		t.Fatalf("expected reconcile to be invoked with the item's key, got %+v", called)
+	}
+	if pool.queue.NumRequeues(item) != 0 {
+		t.Fatalf("expected a successful reconcile to forget the item")
+	}
+	key := types.NamespacedName{Namespace: "ns", Name: "isvc"}
+	if !pool.claim(key) {
+		t.Fatalf("expected the key to be released once processing finished")
+	}
+}
+
+func TestProcessErrorRequeuesWithRateLimiter(t *testing.T) {
+	pool := newTestWorkerPool()
+	pool.queue.AddWithPriority("ns", "isvc", 0)
+	item, _ := pool.queue.Get()
+
+	pool.reconcile = func(ctx context.Context, key types.NamespacedName) error {
+		return errors.New("boom")
+	}
+
+	pool.process(context.Background(), item)
+
+	if pool.queue.NumRequeues(item) != 1 {
+		t.Fatalf("expected a failed reconcile to be requeued with the rate limiter, got %d requeues", pool.queue.NumRequeues(item))
+	}
+}
+
+func TestProcessReEnqueuesWhenKeyAlreadyInFlight(t *testing.T) {
+	pool := newTestWorkerPool()
+	key := types.NamespacedName{Namespace: "ns", Name: "isvc"}
+	pool.claim(key)
+
+	called := false
+	pool.reconcile = func(ctx context.Context, key types.NamespacedName) error {
+		called = true
+		return nil
+	}
+
+	pool.queue.AddWithPriority("ns", "isvc", 5)
+	item, _ := pool.queue.Get()
+	pool.process(context.Background(), item)
+
+	if called {
+		t.Fatalf("expected reconcile to not run while the key is already in flight")
+	}
+	if pool.queue.Len() != 1 {
+		t.Fatalf("expected the item to be re-enqueued for another worker to pick up, queue length = %d", pool.queue.Len())
+	}
+}
+
+func TestStartWorkerPoolProcessesQueuedItems(t *testing.T) {
+	queue := NewPriorityQueue("test")
+	queue.AddWithPriority("ns", "isvc", 0)
+
+	var mu sync.Mutex
+	var processed []types.NamespacedName
+	done := make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	StartWorkerPool(ctx, queue, 1, func(ctx context.Context, key types.NamespacedName) error {
+		mu.Lock()
+		processed = append(processed, key)
+		mu.Unlock()
+		close(done)
+		return nil
+	})
+
+	<-done
+	mu.Lock()
+	defer mu.Unlock()
+	if len(processed) != 1 || processed[0] != (types.NamespacedName{Namespace: "ns", Name: "isvc"}) {
+		t.Fatalf("expected the queued item to be reconciled exactly once, got %+v", processed)
+	}
+}
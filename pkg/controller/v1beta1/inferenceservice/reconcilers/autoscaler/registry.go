@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package autoscaler decouples the raw-deployment reconciler from any single
+// autoscaling implementation. Each backend (HPA, KEDA, external metrics
+// adapters, ...) registers a Reconciler under its AutoscalerClass so new
+// backends can be added without changing the call site in the main
+// reconciler.
+package autoscaler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// Reconciler is implemented by every autoscaler backend (HPA, KEDA, ...).
+type Reconciler interface {
+	// Reconcile creates/updates/deletes the backend's scaling object(s) and
+	// returns the object that was reconciled, for status propagation.
+	Reconcile(ctx context.Context) (client.Object, error)
+	// SetControllerReferences sets the InferenceService as the controller
+	// owner of the reconciled object(s).
+	SetControllerReferences(owner metav1.Object, scheme *runtime.Scheme) error
+}
+
+// Factory constructs a Reconciler for one component's autoscaler.
+type Factory func(client client.Client, scheme *runtime.Scheme, componentMeta metav1.ObjectMeta, componentExt *v1beta1.ComponentExtensionSpec) Reconciler
+
+var (
+	mu       sync.RWMutex
+	registry = map[constants.AutoscalerClassType]Factory{}
+)
+
+// Register associates an AutoscalerClass with the Factory that builds its
+// Reconciler. Intended to be called from each backend's package init().
+func Register(class constants.AutoscalerClassType, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	registry[class] = factory
+}
+
+// Get looks up the Factory registered for an AutoscalerClass.
+func Get(class constants.AutoscalerClassType) (Factory, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	factory, ok := registry[class]
+	if !ok {
+		return nil, fmt.Errorf("no autoscaler backend registered for class %q", class)
+	}
+	return factory, nil
+}
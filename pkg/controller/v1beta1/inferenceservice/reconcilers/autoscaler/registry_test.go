@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+type stubReconciler struct{}
+
+func (stubReconciler) Reconcile(ctx context.Context) (client.Object, error) { return nil, nil }
+func (stubReconciler) SetControllerReferences(owner metav1.Object, scheme *runtime.Scheme) error {
+	return nil
+}
+
+func TestRegisterAndGet(t *testing.T) {
+	const class constants.AutoscalerClassType = "test-backend"
+	factory := func(client.Client, *runtime.Scheme, metav1.ObjectMeta, *v1beta1.ComponentExtensionSpec) Reconciler {
+		return stubReconciler{}
+	}
+
+	Register(class, factory)
+
+	got, err := Get(class)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got == nil {
+		t.Fatalf("expected a non-nil factory")
+	}
+	if _, ok := got(nil, nil, metav1.ObjectMeta{}, nil).(stubReconciler); !ok {
+		t.Fatalf("expected the registered factory to be returned")
+	}
+}
+
+func TestGetUnregisteredClassReturnsError(t *testing.T) {
+	if _, err := Get(constants.AutoscalerClassType("never-registered")); err == nil {
+		t.Fatalf("expected an error for an unregistered autoscaler class")
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpuallocator
+
+import (
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// PolicyHeadFirstBinPacking is the "fill the head before spinning up any
+// worker" alternative: if the head's own per-pod GPU limit already covers
+// TensorParallelSize, no worker replica is created at all. Only once the
+// head falls short does it start adding worker replicas, one GPU shard's
+// worth of workerGPU at a time.
+const PolicyHeadFirstBinPacking = "headFirstBinPacking"
+
+type headFirstBinPackingPolicy struct{}
+
+func (headFirstBinPackingPolicy) Allocate(spec v1beta1.WorkerSpec, headGPU, workerGPU resource.Quantity, _ corev1.ResourceName) (AllocationPlan, error) {
+	tensor := int64(1)
+	if spec.TensorParallelSize != nil && *spec.TensorParallelSize > 0 {
+		tensor = int64(*spec.TensorParallelSize)
+	}
+
+	if headGPU.Value() >= tensor {
+		return AllocationPlan{NodeCount: 1, WorkerReplicas: 0}, nil
+	}
+
+	if workerGPU.Value() <= 0 {
+		return AllocationPlan{}, ErrInsufficientGPUs
+	}
+
+	remaining := tensor - headGPU.Value()
+	workerReplicas := ceilDiv(remaining, workerGPU.Value())
+	return AllocationPlan{
+		NodeCount:      int32(1 + workerReplicas), // #nosec G115
+		WorkerReplicas: int32(workerReplicas),     // #nosec G115
+	}, nil
+}
+
+// ceilDiv returns ceil(a / b) for positive a, b.
+func ceilDiv(a, b int64) int64 {
+	return (a + b - 1) / b
+}
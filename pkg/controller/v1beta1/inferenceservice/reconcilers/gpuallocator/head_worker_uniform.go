@@ -0,0 +1,43 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpuallocator
+
+import (
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// PolicyHeadWorkerUniform is the allocation behavior the reconciler has
+// always used: one Ray node per PipelineParallelSize step, the head
+// supplying the first node and a worker replica supplying each of the rest,
+// regardless of how many GPUs the head itself happens to have spare.
+const PolicyHeadWorkerUniform = "headWorkerUniform"
+
+type headWorkerUniformPolicy struct{}
+
+func (headWorkerUniformPolicy) Allocate(spec v1beta1.WorkerSpec, _, _ resource.Quantity, _ corev1.ResourceName) (AllocationPlan, error) {
+	pipeline := int32(1)
+	if spec.PipelineParallelSize != nil && *spec.PipelineParallelSize > 0 {
+		pipeline = int32(*spec.PipelineParallelSize) // #nosec G115
+	}
+	workerReplicas := pipeline - 1
+	if workerReplicas < 0 {
+		workerReplicas = 0
+	}
+	return AllocationPlan{NodeCount: pipeline, WorkerReplicas: workerReplicas}, nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gpuallocator decouples "how many Ray nodes/worker replicas does
+// this WorkerSpec need" from any single allocation strategy, so the
+// head+worker-uniform behavior the raw-deployment reconciler has always used
+// can be swapped for a different one (e.g. bin-packing GPUs onto the head
+// before creating any worker replica) without changing the reconciler's call
+// site. Mirrors the reconcilers/autoscaler registry's own decoupling of
+// autoscaler backends.
+package gpuallocator
+
+import (
+	"errors"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// AllocationPlan is the result of applying a Policy to a WorkerSpec: how many
+// Ray nodes the replica group needs in total and how many worker replicas
+// the controller should create to supply the rest once the head's own node
+// is accounted for.
+type AllocationPlan struct {
+	NodeCount      int32
+	WorkerReplicas int32
+}
+
+// ErrInsufficientGPUs is returned when no worker replica count, however
+// large, could satisfy the requested parallelism against the given per-pod
+// GPU quantities (e.g. workerGPU is zero).
+var ErrInsufficientGPUs = errors.New("gpuallocator: insufficient GPUs to satisfy the requested parallelism")
+
+// Policy turns a WorkerSpec's parallelism plus the head/worker per-pod GPU
+// limit into an AllocationPlan.
+type Policy interface {
+	Allocate(spec v1beta1.WorkerSpec, headGPU, workerGPU resource.Quantity, vendor corev1.ResourceName) (AllocationPlan, error)
+}
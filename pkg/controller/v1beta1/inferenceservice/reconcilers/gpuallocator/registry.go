@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpuallocator
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// DefaultPolicy is used when neither the inferenceservice-config ConfigMap
+// nor a per-ISVC annotation names one.
+const DefaultPolicy = PolicyHeadWorkerUniform
+
+var (
+	mu       sync.RWMutex
+	registry = map[string]Policy{
+		PolicyHeadWorkerUniform:   headWorkerUniformPolicy{},
+		PolicyHeadFirstBinPacking: headFirstBinPackingPolicy{},
+	}
+)
+
+// Register adds or replaces the Policy registered under name, so a
+// deployment can ship its own policy alongside the two built in here.
+func Register(name string, policy Policy) {
+	mu.Lock()
+	defer mu.Unlock()
+	registry[name] = policy
+}
+
+// Get looks up a registered Policy by name.
+func Get(name string) (Policy, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	policy, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("no gpu allocation policy registered under name %q", name)
+	}
+	return policy, nil
+}
+
+// DryRun resolves the named policy and applies it, without creating or
+// modifying any object. It's the same computation Allocate performs; the
+// separate name exists so a `kubectl` plugin (or any other preview tooling)
+// has an obviously side-effect-free entry point to call.
+func DryRun(policyName string, spec v1beta1.WorkerSpec, headGPU, workerGPU resource.Quantity, vendor corev1.ResourceName) (AllocationPlan, error) {
+	policy, err := Get(policyName)
+	if err != nil {
+		return AllocationPlan{}, err
+	}
+	return policy.Allocate(spec, headGPU, workerGPU, vendor)
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gpudetect identifies which accelerator resource a container is
+// requesting instead of assuming constants.NvidiaGPUResourceType, and maps
+// that resource to the vendor-specific visible-devices env var its device
+// plugin expects. The resource name list itself is admin-overridable (the
+// inferenceservice-config ConfigMap's GPUResourceTypes field, when set,
+// replaces constants.GPUResourceTypeList), so a cluster running an
+// accelerator not yet known to this package can still be detected.
+package gpudetect
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// acceleratorRequestsDetected counts how often each accelerator resource
+// type is found on a reconciled container, labelled by vendor so dashboards
+// can track mixed-fleet adoption instead of assuming every GPU is Nvidia.
+var acceleratorRequestsDetected = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "kserve_accelerator_requests_detected_total",
+	Help: "Number of container accelerator resource requests detected, labelled by resource type.",
+}, []string{"resource_type"})
+
+func init() {
+	metrics.Registry.MustRegister(acceleratorRequestsDetected)
+}
+
+// visibleDevicesEnvVar maps an accelerator resource type to the env var its
+// device plugin/runtime reads to restrict which devices a container can see.
+// Resource types absent from this map (including ones an admin adds to
+// GPUResourceTypes that this package doesn't yet know about) get no env var
+// injected; the container still receives the device via the Kubernetes
+// resource request/limit itself.
+var visibleDevicesEnvVar = map[string]string{
+	constants.NvidiaGPUResourceType:    "NVIDIA_VISIBLE_DEVICES",
+	constants.GaudiGPUResourceType:     "HABANA_VISIBLE_DEVICES",
+	constants.AWSNeuronResourceType:    "NEURON_RT_VISIBLE_CORES",
+	constants.IntelI915GPUResourceType: "GPU_DEVICE_ORDINAL",
+}
+
+// DetectResourceType returns the first resource name in resourceTypes that
+// container requests or limits, and reports whether one was found.
+// resourceTypes is normally constants.GPUResourceTypeList, but callers pass
+// the ConfigMap-configured list so an admin-added resource name is detected
+// too. Checking Limits as well as Requests matters because GPU resources are
+// typically requested only as a Limit (Kubernetes requires Requests ==
+// Limits for extended resources, but some manifests only set the latter).
+func DetectResourceType(container *corev1.Container, resourceTypes []string) (string, bool) {
+	for _, resourceType := range resourceTypes {
+		name := corev1.ResourceName(resourceType)
+		if _, ok := container.Resources.Requests[name]; ok {
+			acceleratorRequestsDetected.WithLabelValues(resourceType).Inc()
+			return resourceType, true
+		}
+		if _, ok := container.Resources.Limits[name]; ok {
+			acceleratorRequestsDetected.WithLabelValues(resourceType).Inc()
+			return resourceType, true
+		}
+	}
+	return "", false
+}
+
+// VisibleDevicesEnvVar builds the vendor-appropriate visible-devices env var
+// for resourceType, analogous to how NVIDIA_VISIBLE_DEVICES is set for
+// Nvidia GPUs. value is normally "all" (let the device plugin's runtime
+// decide placement) or a specific device index/UUID list. Returns a
+// zero-value EnvVar and false when resourceType has no known visible-devices
+// convention.
+func VisibleDevicesEnvVar(resourceType, value string) (corev1.EnvVar, bool) {
+	name, ok := visibleDevicesEnvVar[resourceType]
+	if !ok {
+		return corev1.EnvVar{}, false
+	}
+	return corev1.EnvVar{Name: name, Value: value}, true
+}
+
+// InjectVisibleDevicesEnv appends the vendor-appropriate visible-devices env
+// var for resourceType to container's Env, if one is known. It's a no-op for
+// an unrecognized resourceType, leaving device visibility to the Kubernetes
+// resource request alone.
+func InjectVisibleDevicesEnv(container *corev1.Container, resourceType, value string) {
+	envVar, ok := VisibleDevicesEnvVar(resourceType, value)
+	if !ok {
+		return
+	}
+	container.Env = append(container.Env, envVar)
+}
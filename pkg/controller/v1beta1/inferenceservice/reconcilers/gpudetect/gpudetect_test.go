@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpudetect
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestDetectResourceTypeFromRequests(t *testing.T) {
+	container := &corev1.Container{
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceName(constants.NvidiaGPUResourceType): resource.MustParse("1")},
+		},
+	}
+	got, found := DetectResourceType(container, constants.GPUResourceTypeList)
+	if !found || got != constants.NvidiaGPUResourceType {
+		t.Fatalf("expected to detect %q, got %q found=%v", constants.NvidiaGPUResourceType, got, found)
+	}
+}
+
+func TestDetectResourceTypeFromLimitsOnly(t *testing.T) {
+	container := &corev1.Container{
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceName(constants.GaudiGPUResourceType): resource.MustParse("1")},
+		},
+	}
+	got, found := DetectResourceType(container, constants.GPUResourceTypeList)
+	if !found || got != constants.GaudiGPUResourceType {
+		t.Fatalf("expected to detect %q from Limits, got %q found=%v", constants.GaudiGPUResourceType, got, found)
+	}
+}
+
+func TestDetectResourceTypeNoneRequested(t *testing.T) {
+	container := &corev1.Container{}
+	got, found := DetectResourceType(container, constants.GPUResourceTypeList)
+	if found || got != "" {
+		t.Fatalf("expected no resource type to be detected, got %q found=%v", got, found)
+	}
+}
+
+func TestVisibleDevicesEnvVarKnownVendor(t *testing.T) {
+	env, ok := VisibleDevicesEnvVar(constants.NvidiaGPUResourceType, "all")
+	if !ok || env.Name != "NVIDIA_VISIBLE_DEVICES" || env.Value != "all" {
+		t.Fatalf("unexpected env var: %+v ok=%v", env, ok)
+	}
+}
+
+func TestVisibleDevicesEnvVarUnknownVendor(t *testing.T) {
+	_, ok := VisibleDevicesEnvVar("some.vendor/unknown", "all")
+	if ok {
+		t.Fatalf("expected an unknown resource type to report false")
+	}
+}
+
+func TestInjectVisibleDevicesEnvAppendsKnownVendor(t *testing.T) {
+	container := &corev1.Container{}
+	InjectVisibleDevicesEnv(container, constants.AWSNeuronResourceType, "0,1")
+	if len(container.Env) != 1 || container.Env[0].Name != "NEURON_RT_VISIBLE_CORES" || container.Env[0].Value != "0,1" {
+		t.Fatalf("unexpected env: %+v", container.Env)
+	}
+}
+
+func TestInjectVisibleDevicesEnvNoopForUnknownVendor(t *testing.T) {
+	container := &corev1.Container{}
+	InjectVisibleDevicesEnv(container, "some.vendor/unknown", "all")
+	if len(container.Env) != 0 {
+		t.Fatalf("expected no env var to be injected for an unknown resource type, got %+v", container.Env)
+	}
+}
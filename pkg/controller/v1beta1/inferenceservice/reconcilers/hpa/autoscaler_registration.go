@@ -0,0 +1,45 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hpa
+
+import (
+	"context"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/autoscaler"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func init() {
+	autoscaler.Register(constants.AutoscalerClassHPA, func(c client.Client, scheme *runtime.Scheme, componentMeta metav1.ObjectMeta, componentExt *v1beta1.ComponentExtensionSpec) autoscaler.Reconciler {
+		return &reconcilerAdapter{NewHPAReconciler(c, scheme, componentMeta, componentExt)}
+	})
+}
+
+// reconcilerAdapter adapts HPAReconciler's concretely-typed Reconcile to the
+// autoscaler.Reconciler interface so the registry can hand back a
+// client.Object without every backend needing to know about each other.
+type reconcilerAdapter struct {
+	*HPAReconciler
+}
+
+func (a *reconcilerAdapter) Reconcile(ctx context.Context) (client.Object, error) {
+	return a.HPAReconciler.Reconcile()
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hpa
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/autoscaler"
+)
+
+// TestHPARegistersWithAutoscalerRegistry confirms this package's init()
+// registered itself with the shared autoscaler registry under
+// constants.AutoscalerClassHPA, and that the registered factory builds an
+// adapter wrapping a real *HPAReconciler.
+func TestHPARegistersWithAutoscalerRegistry(t *testing.T) {
+	factory, err := autoscaler.Get(constants.AutoscalerClassHPA)
+	if err != nil {
+		t.Fatalf("expected the hpa package's init() to have registered itself: %v", err)
+	}
+
+	reconciler := factory(nil, nil, metav1.ObjectMeta{Name: "test"}, &v1beta1.ComponentExtensionSpec{})
+	adapter, ok := reconciler.(*reconcilerAdapter)
+	if !ok {
+		t.Fatalf("expected the registered factory to return a *reconcilerAdapter, got %T", reconciler)
+	}
+	if adapter.HPAReconciler == nil {
+		t.Fatalf("expected the adapter to wrap a non-nil HPAReconciler")
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The KServe Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hpa
+
+import (
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/utils/ptr"
+)
+
+// presetBehaviors expands each AutoScalingPreset into a concrete
+// HorizontalPodAutoscalerBehavior, so BuildBehavior's preset path and a
+// user's hand-written Behavior block produce the exact same type and can be
+// asserted equal in a table-driven test.
+var presetBehaviors = map[v1beta1.AutoScalingPreset]autoscalingv2.HorizontalPodAutoscalerBehavior{
+	v1beta1.AutoScalingPresetLLMBurst: {
+		ScaleUp: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: ptr.To(int32(0)),
+			SelectPolicy:               ptr.To(autoscalingv2.MaxChangePolicySelect),
+			Policies: []autoscalingv2.HPAScalingPolicy{
+				{Type: autoscalingv2.PodsScalingPolicy, Value: 4, PeriodSeconds: 15},
+				{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+			},
+		},
+		ScaleDown: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: ptr.To(int32(300)),
+			SelectPolicy:               ptr.To(autoscalingv2.MinChangePolicySelect),
+			Policies: []autoscalingv2.HPAScalingPolicy{
+				{Type: autoscalingv2.PercentScalingPolicy, Value: 25, PeriodSeconds: 60},
+			},
+		},
+	},
+	v1beta1.AutoScalingPresetAggressive: {
+		ScaleUp: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: ptr.To(int32(0)),
+			SelectPolicy:               ptr.To(autoscalingv2.MaxChangePolicySelect),
+			Policies: []autoscalingv2.HPAScalingPolicy{
+				{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+			},
+		},
+		ScaleDown: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: ptr.To(int32(15)),
+			SelectPolicy:               ptr.To(autoscalingv2.MaxChangePolicySelect),
+			Policies: []autoscalingv2.HPAScalingPolicy{
+				{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+			},
+		},
+	},
+	v1beta1.AutoScalingPresetBalanced: {
+		ScaleUp: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: ptr.To(int32(0)),
+			SelectPolicy:               ptr.To(autoscalingv2.MaxChangePolicySelect),
+			Policies: []autoscalingv2.HPAScalingPolicy{
+				{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+			},
+		},
+		ScaleDown: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: ptr.To(int32(120)),
+			SelectPolicy:               ptr.To(autoscalingv2.MaxChangePolicySelect),
+			Policies: []autoscalingv2.HPAScalingPolicy{
+				{Type: autoscalingv2.PercentScalingPolicy, Value: 50, PeriodSeconds: 60},
+			},
+		},
+	},
+	v1beta1.AutoScalingPresetConservative: {
+		ScaleUp: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: ptr.To(int32(60)),
+			SelectPolicy:               ptr.To(autoscalingv2.MinChangePolicySelect),
+			Policies: []autoscalingv2.HPAScalingPolicy{
+				{Type: autoscalingv2.PodsScalingPolicy, Value: 1, PeriodSeconds: 60},
+			},
+		},
+		ScaleDown: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: ptr.To(int32(600)),
+			SelectPolicy:               ptr.To(autoscalingv2.MinChangePolicySelect),
+			Policies: []autoscalingv2.HPAScalingPolicy{
+				{Type: autoscalingv2.PodsScalingPolicy, Value: 1, PeriodSeconds: 120},
+			},
+		},
+	},
+	v1beta1.AutoScalingPresetBatchSteady: {
+		ScaleUp: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: ptr.To(int32(60)),
+			SelectPolicy:               ptr.To(autoscalingv2.MaxChangePolicySelect),
+			Policies: []autoscalingv2.HPAScalingPolicy{
+				{Type: autoscalingv2.PodsScalingPolicy, Value: 1, PeriodSeconds: 60},
+			},
+		},
+		ScaleDown: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: ptr.To(int32(300)),
+			SelectPolicy:               ptr.To(autoscalingv2.MinChangePolicySelect),
+			Policies: []autoscalingv2.HPAScalingPolicy{
+				{Type: autoscalingv2.PodsScalingPolicy, Value: 1, PeriodSeconds: 120},
+			},
+		},
+	},
+	v1beta1.AutoScalingPresetExplainerCheap: {
+		ScaleDown: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: ptr.To(int32(30)),
+			SelectPolicy:               ptr.To(autoscalingv2.MaxChangePolicySelect),
+			Policies: []autoscalingv2.HPAScalingPolicy{
+				{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+			},
+		},
+	},
+}
+
+// BuildBehavior resolves an AutoScalingBehaviorSpec into the
+// HorizontalPodAutoscalerBehavior createHPA should set: an explicit Behavior
+// block always wins, otherwise a named Preset expands via presetBehaviors,
+// and an unset spec falls back to the empty behavior createHPA already used.
+func BuildBehavior(spec *v1beta1.AutoScalingBehaviorSpec) *autoscalingv2.HorizontalPodAutoscalerBehavior {
+	if spec == nil {
+		return &autoscalingv2.HorizontalPodAutoscalerBehavior{}
+	}
+	if spec.Behavior != nil {
+		return spec.Behavior
+	}
+	if preset, ok := presetBehaviors[spec.Preset]; ok {
+		return &preset
+	}
+	return &autoscalingv2.HorizontalPodAutoscalerBehavior{}
+}
+
+// ResolvePresetAnnotation falls back to the v1beta1.AutoScalingPresetAnnotationKey
+// annotation (set either directly on the component or via the ingress/autoscaler
+// ConfigMap's default annotations) when spec didn't already select a preset or
+// an explicit Behavior, so a cluster-wide default preset doesn't require every
+// InferenceService to repeat it.
+func ResolvePresetAnnotation(spec *v1beta1.AutoScalingBehaviorSpec, annotations map[string]string) *v1beta1.AutoScalingBehaviorSpec {
+	if spec != nil && (spec.Preset != "" || spec.Behavior != nil) {
+		return spec
+	}
+	preset, ok := annotations[v1beta1.AutoScalingPresetAnnotationKey]
+	if !ok {
+		return spec
+	}
+	return &v1beta1.AutoScalingBehaviorSpec{Preset: v1beta1.AutoScalingPreset(preset)}
+}
+
+// MergeBehavior layers user-provided Behavior fields over a preset's defaults,
+// field by field (ScaleUp and ScaleDown independently), so a user can override
+// just e.g. ScaleDown.StabilizationWindowSeconds without having to copy the
+// rest of the preset's policies by hand. Either argument may be nil.
+func MergeBehavior(user, preset *autoscalingv2.HorizontalPodAutoscalerBehavior) *autoscalingv2.HorizontalPodAutoscalerBehavior {
+	if user == nil {
+		return preset
+	}
+	if preset == nil {
+		return user
+	}
+	merged := &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleUp:   preset.ScaleUp,
+		ScaleDown: preset.ScaleDown,
+	}
+	if user.ScaleUp != nil {
+		merged.ScaleUp = user.ScaleUp
+	}
+	if user.ScaleDown != nil {
+		merged.ScaleDown = user.ScaleDown
+	}
+	return merged
+}
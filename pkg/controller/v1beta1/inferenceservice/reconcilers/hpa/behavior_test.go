@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The KServe Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hpa
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildBehaviorNilSpec(t *testing.T) {
+	got := BuildBehavior(nil)
+	if got == nil || !reflect.DeepEqual(*got, autoscalingv2.HorizontalPodAutoscalerBehavior{}) {
+		t.Fatalf("expected an empty behavior for a nil spec, got %+v", got)
+	}
+}
+
+func TestBuildBehaviorExplicitBehaviorWins(t *testing.T) {
+	explicit := &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleUp: &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: ptr.To(int32(42))},
+	}
+	spec := &v1beta1.AutoScalingBehaviorSpec{Preset: v1beta1.AutoScalingPresetAggressive, Behavior: explicit}
+	got := BuildBehavior(spec)
+	if got != explicit {
+		t.Fatalf("expected the explicit Behavior to take precedence over the preset")
+	}
+}
+
+func TestBuildBehaviorExpandsPreset(t *testing.T) {
+	spec := &v1beta1.AutoScalingBehaviorSpec{Preset: v1beta1.AutoScalingPresetLLMBurst}
+	got := BuildBehavior(spec)
+	want := presetBehaviors[v1beta1.AutoScalingPresetLLMBurst]
+	if !reflect.DeepEqual(*got, want) {
+		t.Fatalf("expected the llm-burst preset, got %+v", got)
+	}
+}
+
+func TestBuildBehaviorUnknownPresetFallsBackToEmpty(t *testing.T) {
+	spec := &v1beta1.AutoScalingBehaviorSpec{Preset: v1beta1.AutoScalingPreset("not-a-preset")}
+	got := BuildBehavior(spec)
+	if got == nil || !reflect.DeepEqual(*got, autoscalingv2.HorizontalPodAutoscalerBehavior{}) {
+		t.Fatalf("expected an empty behavior for an unrecognized preset, got %+v", got)
+	}
+}
+
+func TestResolvePresetAnnotationSpecAlreadySet(t *testing.T) {
+	spec := &v1beta1.AutoScalingBehaviorSpec{Preset: v1beta1.AutoScalingPresetBalanced}
+	got := ResolvePresetAnnotation(spec, map[string]string{v1beta1.AutoScalingPresetAnnotationKey: "aggressive"})
+	if got != spec {
+		t.Fatalf("expected the spec's own preset to take precedence over the annotation")
+	}
+}
+
+func TestResolvePresetAnnotationSpecHasExplicitBehavior(t *testing.T) {
+	spec := &v1beta1.AutoScalingBehaviorSpec{Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{}}
+	got := ResolvePresetAnnotation(spec, map[string]string{v1beta1.AutoScalingPresetAnnotationKey: "aggressive"})
+	if got != spec {
+		t.Fatalf("expected an explicit Behavior to take precedence over the annotation")
+	}
+}
+
+func TestResolvePresetAnnotationFallsBackToAnnotation(t *testing.T) {
+	got := ResolvePresetAnnotation(nil, map[string]string{v1beta1.AutoScalingPresetAnnotationKey: "aggressive"})
+	if got == nil || got.Preset != v1beta1.AutoScalingPresetAggressive {
+		t.Fatalf("expected the preset to be resolved from the annotation, got %+v", got)
+	}
+}
+
+func TestResolvePresetAnnotationNoAnnotationNoSpec(t *testing.T) {
+	if got := ResolvePresetAnnotation(nil, nil); got != nil {
+		t.Fatalf("expected nil when neither the spec nor the annotation select a preset, got %+v", got)
+	}
+}
+
+func TestMergeBehaviorNilUser(t *testing.T) {
+	preset := &autoscalingv2.HorizontalPodAutoscalerBehavior{ScaleUp: &autoscalingv2.HPAScalingRules{}}
+	if got := MergeBehavior(nil, preset); got != preset {
+		t.Fatalf("expected the preset to be returned unchanged when user is nil")
+	}
+}
+
+func TestMergeBehaviorNilPreset(t *testing.T) {
+	user := &autoscalingv2.HorizontalPodAutoscalerBehavior{ScaleUp: &autoscalingv2.HPAScalingRules{}}
+	if got := MergeBehavior(user, nil); got != user {
+		t.Fatalf("expected the user behavior to be returned unchanged when preset is nil")
+	}
+}
+
+func TestMergeBehaviorOverridesFieldByField(t *testing.T) {
+	preset := &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleUp:   &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: ptr.To(int32(0))},
+		ScaleDown: &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: ptr.To(int32(300))},
+	}
+	user := &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleDown: &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: ptr.To(int32(60))},
+	}
+	got := MergeBehavior(user, preset)
+	if got.ScaleUp != preset.ScaleUp {
+		t.Fatalf("expected ScaleUp to fall back to the preset")
+	}
+	if got.ScaleDown != user.ScaleDown {
+		t.Fatalf("expected ScaleDown to be overridden by the user")
+	}
+}
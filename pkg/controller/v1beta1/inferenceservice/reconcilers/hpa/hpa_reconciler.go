@@ -23,6 +23,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -57,6 +58,32 @@ func NewHPAReconciler(client client.Client,
 
 func getHPAMetrics(metadata metav1.ObjectMeta, componentExt *v1beta1.ComponentExtensionSpec) []autoscalingv2.MetricSpec {
 	var metrics []autoscalingv2.MetricSpec
+
+	if !hasResourceMetric(componentExt.Metrics) {
+		metrics = append(metrics, legacyResourceMetric(metadata, componentExt))
+	}
+	metrics = append(metrics, buildScaleMetrics(componentExt.Metrics)...)
+	return metrics
+}
+
+// hasResourceMetric reports whether metrics already declares a Resource
+// entry, so getHPAMetrics doesn't also emit the legacy
+// ScaleMetric/ScaleTarget-derived Resource metric and end up with two
+// Resource entries for the same resource name (which the HPA API rejects).
+func hasResourceMetric(metrics []v1beta1.ScaleMetricSpec) bool {
+	for _, m := range metrics {
+		if m.Type == v1beta1.ResourceScaleMetricSourceType && m.Resource != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// legacyResourceMetric builds the single CPU/memory Resource metric from
+// ComponentExtensionSpec's older singular ScaleMetric/ScaleTarget fields, so
+// a component that hasn't migrated to the list-shaped Metrics field keeps
+// its current behavior unchanged.
+func legacyResourceMetric(metadata metav1.ObjectMeta, componentExt *v1beta1.ComponentExtensionSpec) autoscalingv2.MetricSpec {
 	var utilization int32
 	annotations := metadata.Annotations
 	resourceName := corev1.ResourceCPU
@@ -76,20 +103,131 @@ func getHPAMetrics(metadata metav1.ObjectMeta, componentExt *v1beta1.ComponentEx
 		resourceName = corev1.ResourceName(*componentExt.ScaleMetric)
 	}
 
-	metricTarget := autoscalingv2.MetricTarget{
-		Type:               "Utilization",
-		AverageUtilization: &utilization,
-	}
-
-	ms := autoscalingv2.MetricSpec{
+	return autoscalingv2.MetricSpec{
 		Type: autoscalingv2.ResourceMetricSourceType,
 		Resource: &autoscalingv2.ResourceMetricSource{
-			Name:   resourceName,
-			Target: metricTarget,
+			Name: resourceName,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: &utilization,
+			},
 		},
 	}
-	metrics = append(metrics, ms)
-	return metrics
+}
+
+// buildScaleMetrics translates each ComponentExtensionSpec.Metrics entry
+// into the matching autoscalingv2.MetricSpec, so a component using
+// AutoscalerClassHPA can scale on any mix of resource, custom per-pod,
+// external, and object metrics instead of only the single CPU/memory
+// metric legacyResourceMetric produces.
+func buildScaleMetrics(metrics []v1beta1.ScaleMetricSpec) []autoscalingv2.MetricSpec {
+	var out []autoscalingv2.MetricSpec
+	for _, m := range metrics {
+		switch m.Type {
+		case v1beta1.ResourceScaleMetricSourceType:
+			if m.Resource == nil {
+				continue
+			}
+			out = append(out, autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name:   corev1.ResourceName(m.Resource.Name),
+					Target: resourceMetricTarget(m.Resource),
+				},
+			})
+		case v1beta1.PodsScaleMetricSourceType:
+			if m.Pods == nil {
+				continue
+			}
+			qty := parseQuantityOrZero("pods.averageValue", m.Pods.AverageValue)
+			out = append(out, autoscalingv2.MetricSpec{
+				Type: autoscalingv2.PodsMetricSourceType,
+				Pods: &autoscalingv2.PodsMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{
+						Name:     m.Pods.Name,
+						Selector: m.Pods.Selector,
+					},
+					Target: autoscalingv2.MetricTarget{
+						Type:         autoscalingv2.AverageValueMetricType,
+						AverageValue: &qty,
+					},
+				},
+			})
+		case v1beta1.ExternalScaleMetricSourceType:
+			if m.External == nil {
+				continue
+			}
+			out = append(out, autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ExternalMetricSourceType,
+				External: &autoscalingv2.ExternalMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{
+						Name:     m.External.Name,
+						Selector: m.External.Selector,
+					},
+					Target: metricTargetFromThreshold(m.External.Threshold, ""),
+				},
+			})
+		case v1beta1.ObjectScaleMetricSourceType:
+			if m.Object == nil {
+				continue
+			}
+			out = append(out, autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ObjectMetricSourceType,
+				Object: &autoscalingv2.ObjectMetricSource{
+					DescribedObject: m.Object.DescribedObject,
+					Metric: autoscalingv2.MetricIdentifier{
+						Name:     m.Object.Name,
+						Selector: m.Object.Selector,
+					},
+					Target: metricTargetFromThreshold(m.Object.Threshold, m.Object.AverageValue),
+				},
+			})
+		}
+	}
+	return out
+}
+
+// resourceMetricTarget builds the autoscalingv2.MetricTarget for a
+// ResourceMetricSource, defaulting to Utilization when TargetType is unset
+// so an entry that only sets Utilization doesn't also need to set TargetType.
+func resourceMetricTarget(r *v1beta1.ResourceMetricSource) autoscalingv2.MetricTarget {
+	if r.TargetType == v1beta1.AverageValueScaleMetricTargetType {
+		qty := parseQuantityOrZero("resource.averageValue", r.AverageValue)
+		return autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: &qty}
+	}
+	utilization := int32(constants.DefaultCPUUtilization)
+	if r.Utilization != nil {
+		utilization = *r.Utilization
+	}
+	return autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: &utilization}
+}
+
+// metricTargetFromThreshold builds an autoscalingv2.MetricTarget: an
+// AverageValue target when averageValue is set (scaling on a per-pod
+// average), otherwise a Value target against threshold (scaling on the
+// metric's raw value).
+func metricTargetFromThreshold(threshold, averageValue string) autoscalingv2.MetricTarget {
+	if averageValue != "" {
+		qty := parseQuantityOrZero("averageValue", averageValue)
+		return autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: &qty}
+	}
+	qty := parseQuantityOrZero("threshold", threshold)
+	return autoscalingv2.MetricTarget{Type: autoscalingv2.ValueMetricType, Value: &qty}
+}
+
+// parseQuantityOrZero parses s as a resource.Quantity, logging and falling
+// back to the zero quantity instead of panicking (as resource.MustParse
+// would) if s is malformed. The validating webhook
+// (v1beta1.validateScaleMetrics) already rejects a malformed quantity at
+// admission; this is defense-in-depth for specs persisted before that
+// validation existed.
+func parseQuantityOrZero(field, s string) resource.Quantity {
+	qty, err := resource.ParseQuantity(s)
+	if err != nil {
+		log.Error(err, "invalid resource quantity in ScaleMetricSpec, defaulting to zero", "field", field, "value", s)
+		return resource.Quantity{}
+	}
+	return qty
 }
 
 func createHPA(componentMeta metav1.ObjectMeta,
@@ -106,23 +244,44 @@ func createHPA(componentMeta metav1.ObjectMeta,
 		maxReplicas = minReplicas
 	}
 	metrics := getHPAMetrics(componentMeta, componentExt)
+	autoScaling := ResolvePresetAnnotation(componentExt.AutoScaling, componentMeta.Annotations)
 	hpa := &autoscalingv2.HorizontalPodAutoscaler{
 		ObjectMeta: componentMeta,
 		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
-			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
-				APIVersion: "apps/v1",
-				Kind:       "Deployment",
-				Name:       componentMeta.Name,
-			},
-			MinReplicas: &minReplicas,
-			MaxReplicas: maxReplicas,
-			Metrics:     metrics,
-			Behavior:    &autoscalingv2.HorizontalPodAutoscalerBehavior{},
+			ScaleTargetRef: scaleTargetRef(componentMeta, componentExt),
+			MinReplicas:    &minReplicas,
+			MaxReplicas:    maxReplicas,
+			Metrics:        metrics,
+			Behavior:       BuildBehavior(autoScaling),
 		},
 	}
 	return hpa
 }
 
+// scaleTargetRef builds the HPA's ScaleTargetRef from componentExt.WorkloadRef
+// when set, so a predictor deployed as a StatefulSet (or any other workload
+// implementing the scale subresource) can be autoscaled the same way as the
+// default Deployment. Falls back to that default Deployment reference, named
+// after the component, when WorkloadRef is unset.
+func scaleTargetRef(componentMeta metav1.ObjectMeta, componentExt *v1beta1.ComponentExtensionSpec) autoscalingv2.CrossVersionObjectReference {
+	if componentExt.WorkloadRef != nil {
+		name := componentExt.WorkloadRef.Name
+		if name == "" {
+			name = componentMeta.Name
+		}
+		return autoscalingv2.CrossVersionObjectReference{
+			APIVersion: componentExt.WorkloadRef.APIVersion,
+			Kind:       componentExt.WorkloadRef.Kind,
+			Name:       name,
+		}
+	}
+	return autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       componentMeta.Name,
+	}
+}
+
 // checkHPAExist checks if the hpa exists?
 func (r *HPAReconciler) checkHPAExist(client client.Client) (constants.CheckResultType, *autoscalingv2.HorizontalPodAutoscaler, error) {
 	// get hpa
@@ -152,6 +311,9 @@ func (r *HPAReconciler) checkHPAExist(client client.Client) (constants.CheckResu
 	return constants.CheckResultUpdate, existingHPA, nil
 }
 
+// semanticHPAEquals compares the full Spec, so a changed ScaleTargetRef
+// (e.g. switching WorkloadRef to point at a different workload) is already
+// caught by the DeepEqual below without needing its own check.
 func semanticHPAEquals(desired, existing *autoscalingv2.HorizontalPodAutoscaler) bool {
 	desiredAutoscalerClass, hasDesiredAutoscalerClass := desired.Annotations[constants.AutoscalerClass]
 	existingAutoscalerClass, hasExistingAutoscalerClass := existing.Annotations[constants.AutoscalerClass]
@@ -172,7 +334,11 @@ func shouldDeleteHPA(desired *autoscalingv2.HorizontalPodAutoscaler) bool {
 	}
 
 	desiredAutoscalerClass, hasDesiredAutoscalerClass := desired.Annotations[constants.AutoscalerClass]
-	return hasDesiredAutoscalerClass && constants.AutoscalerClassType(desiredAutoscalerClass) == constants.AutoscalerClassExternal
+	if !hasDesiredAutoscalerClass {
+		return false
+	}
+	autoscalerClass := constants.AutoscalerClassType(desiredAutoscalerClass)
+	return autoscalerClass == constants.AutoscalerClassExternal || autoscalerClass == constants.AutoscalerClassKeda
 }
 
 func shouldCreateHPA(desired *autoscalingv2.HorizontalPodAutoscaler) bool {
@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package imagerewrite rewrites container images to an airgapped/mirror
+// registry, modeled on the k8s-image-swapper approach: a list of
+// source-regex to destination-template rules, applied to every
+// container/initContainer image the InferenceService/ServingRuntime
+// reconcilers generate, so an enterprise mirror can be configured once in
+// the inferenceservice-config ConfigMap instead of editing every
+// ServingRuntime and ClusterServingRuntime.
+//
+// This package only implements rule matching/rewriting and the copy-policy
+// enum: it copies no image into the destination registry, and no
+// InferenceService or ServingRuntime reconciler calls Rewriter, so generated
+// Deployments and Pods keep their original images.
+package imagerewrite
+
+import (
+	"fmt"
+	"regexp"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// CopyPolicy controls whether a rewritten image is proactively mirrored into
+// its destination registry before the workload that references it starts.
+type CopyPolicy string
+
+const (
+	// CopyPolicyNone never copies; the destination registry is assumed to
+	// already mirror the source image out of band.
+	CopyPolicyNone CopyPolicy = "None"
+	// CopyPolicyIfNotPresent copies only when the destination registry
+	// doesn't already have the tag.
+	CopyPolicyIfNotPresent CopyPolicy = "IfNotPresent"
+	// CopyPolicyAlways copies on every rewrite, overwriting any existing tag
+	// in the destination registry.
+	CopyPolicyAlways CopyPolicy = "Always"
+)
+
+// Rule rewrites an image reference matching Source into Destination.
+// Destination may reference Source's capture groups the same way
+// regexp.ReplaceAll does, e.g. Source `^docker.io/kserve/(.*)$` and
+// Destination `mirror.internal/kserve/$1`.
+type Rule struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+}
+
+// compiledRule is a Rule with its Source pre-compiled, so Rewriter.Rewrite
+// doesn't recompile a regexp on every call.
+type compiledRule struct {
+	source      *regexp.Regexp
+	destination string
+}
+
+// Rewriter applies an ordered list of rewrite rules to container images. The
+// first rule whose Source matches wins; rules are otherwise independent of
+// one another.
+type Rewriter struct {
+	rules      []compiledRule
+	copyPolicy CopyPolicy
+}
+
+// NewRewriter compiles rules in order and returns a Rewriter that applies
+// copyPolicy to every image it rewrites. It returns an error if any rule's
+// Source isn't a valid regexp.
+func NewRewriter(rules []Rule, copyPolicy CopyPolicy) (*Rewriter, error) {
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, rule := range rules {
+		source, err := regexp.Compile(rule.Source)
+		if err != nil {
+			return nil, fmt.Errorf("compiling image rewrite rule %q: %w", rule.Source, err)
+		}
+		compiled = append(compiled, compiledRule{source: source, destination: rule.Destination})
+	}
+	return &Rewriter{rules: compiled, copyPolicy: copyPolicy}, nil
+}
+
+// Rewrite returns the image rewritten by the first matching rule, and
+// whether any rule matched. An unmatched image is returned unchanged.
+func (r *Rewriter) Rewrite(image string) (string, bool) {
+	for _, rule := range r.rules {
+		if rule.source.MatchString(image) {
+			return rule.source.ReplaceAllString(image, rule.destination), true
+		}
+	}
+	return image, false
+}
+
+// RewriteAll rewrites every entry in images in place, returning the subset
+// that were actually changed by a rule (the set a copy job, when
+// r.CopyPolicy() isn't CopyPolicyNone, would need to mirror).
+func (r *Rewriter) RewriteAll(images []string) []string {
+	var rewritten []string
+	for i, image := range images {
+		if out, matched := r.Rewrite(image); matched {
+			images[i] = out
+			rewritten = append(rewritten, out)
+		}
+	}
+	return rewritten
+}
+
+// CopyPolicy reports the policy Rewrite's caller should apply to the images
+// RewriteAll reports as rewritten.
+func (r *Rewriter) CopyPolicy() CopyPolicy {
+	return r.copyPolicy
+}
+
+// MergeImagePullSecrets merges swapperSecret (the pull secret granting
+// access to the mirror registry) into existing, the user's
+// PodSpec.ImagePullSecrets, without duplicating an entry the user already
+// listed. existing is left untouched when swapperSecret is already present.
+func MergeImagePullSecrets(existing []corev1.LocalObjectReference, swapperSecret corev1.LocalObjectReference) []corev1.LocalObjectReference {
+	for _, secret := range existing {
+		if secret.Name == swapperSecret.Name {
+			return existing
+		}
+	}
+	return append(existing, swapperSecret)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// IngressReadyConditionType is the top-level condition aggregating every
+// parentRef binding result for a given InferenceService's generated routes.
+const IngressReadyConditionType apis.ConditionType = "IngressReady"
+
+// ApplyRouteBindingConditions upserts the per-parent conditions produced by
+// PerParentConditions, plus the aggregated IngressReady condition, into status.
+// Stale per-parent conditions (e.g. left over from a gateway that was removed
+// from config) are left untouched here; callers that prune parentRefs are
+// responsible for removing the corresponding conditions explicitly.
+func ApplyRouteBindingConditions(status *duckv1.Status, results []RouteBindResult) {
+	for _, condition := range PerParentConditions(results) {
+		upsertCondition(status, condition)
+	}
+	aggregated := AggregateIngressReady(results)
+	aggregated.Type = IngressReadyConditionType
+	upsertCondition(status, aggregated)
+}
+
+func upsertCondition(status *duckv1.Status, condition apis.Condition) {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == condition.Type {
+			status.Conditions[i] = condition
+			return
+		}
+	}
+	status.Conditions = append(status.Conditions, condition)
+}
+
+// RemoveParentCondition drops the per-parent condition for a parentRef that is
+// no longer configured, e.g. after a gateway is removed from the ingress config.
+func RemoveParentCondition(status *duckv1.Status, conditionType apis.ConditionType) {
+	kept := status.Conditions[:0]
+	for _, c := range status.Conditions {
+		if c.Type != conditionType {
+			kept = append(kept, c)
+		}
+	}
+	status.Conditions = kept
+}
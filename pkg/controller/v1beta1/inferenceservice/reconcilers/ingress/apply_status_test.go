@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"testing"
+
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestApplyRouteBindingConditionsAddsPerParentAndAggregateConditions(t *testing.T) {
+	status := &duckv1.Status{}
+	results := []RouteBindResult{
+		{ParentRef: gatewayapiv1.ParentReference{Name: "gw-a"}, Accepted: true},
+		{ParentRef: gatewayapiv1.ParentReference{Name: "gw-b"}, Accepted: false, Reason: "Pending"},
+	}
+	ApplyRouteBindingConditions(status, results)
+
+	if len(status.Conditions) != 3 {
+		t.Fatalf("expected 2 per-parent conditions plus the aggregated IngressReady condition, got %+v", status.Conditions)
+	}
+	foundAggregate := false
+	for _, c := range status.Conditions {
+		if c.Type == IngressReadyConditionType {
+			foundAggregate = true
+		}
+	}
+	if !foundAggregate {
+		t.Fatalf("expected an IngressReady condition, got %+v", status.Conditions)
+	}
+}
+
+func TestApplyRouteBindingConditionsUpsertsExistingCondition(t *testing.T) {
+	status := &duckv1.Status{}
+	parentRef := gatewayapiv1.ParentReference{Name: "gw-a"}
+	ApplyRouteBindingConditions(status, []RouteBindResult{{ParentRef: parentRef, Accepted: false, Reason: "Pending"}})
+	ApplyRouteBindingConditions(status, []RouteBindResult{{ParentRef: parentRef, Accepted: true}})
+
+	conditionType := PerParentConditionType(parentRef)
+	var matches int
+	for _, c := range status.Conditions {
+		if c.Type == conditionType {
+			matches++
+			if c.Status != "True" {
+				t.Fatalf("expected the condition to be upserted to True, got %v", c.Status)
+			}
+		}
+	}
+	if matches != 1 {
+		t.Fatalf("expected exactly one condition of type %q after upsert, got %d", conditionType, matches)
+	}
+}
+
+func TestRemoveParentCondition(t *testing.T) {
+	status := &duckv1.Status{}
+	parentRef := gatewayapiv1.ParentReference{Name: "gw-a"}
+	ApplyRouteBindingConditions(status, []RouteBindResult{{ParentRef: parentRef, Accepted: true}})
+
+	conditionType := PerParentConditionType(parentRef)
+	RemoveParentCondition(status, conditionType)
+
+	for _, c := range status.Conditions {
+		if c.Type == conditionType {
+			t.Fatalf("expected the per-parent condition to be removed, still found %+v", c)
+		}
+	}
+}
+
+func TestRemoveParentConditionLeavesOthersUntouched(t *testing.T) {
+	status := &duckv1.Status{}
+	keep := gatewayapiv1.ParentReference{Name: "gw-keep"}
+	remove := gatewayapiv1.ParentReference{Name: "gw-remove"}
+	ApplyRouteBindingConditions(status, []RouteBindResult{{ParentRef: keep, Accepted: true}, {ParentRef: remove, Accepted: true}})
+
+	RemoveParentCondition(status, PerParentConditionType(remove))
+
+	var foundKeep bool
+	for _, c := range status.Conditions {
+		if c.Type == PerParentConditionType(keep) {
+			foundKeep = true
+		}
+		if c.Type == PerParentConditionType(remove) {
+			t.Fatalf("expected the removed parent's condition to be gone, still found %+v", c)
+		}
+	}
+	if !foundKeep {
+		t.Fatalf("expected the untouched parent's condition to remain")
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IngressBackend is one pluggable way of exposing an InferenceService
+// (Gateway API HTTPRoute, Istio VirtualService, OpenShift Route, or a noop
+// backend for disableIngressCreation). The raw reconciler owns whatever
+// client.Objects each registered backend returns rather than hard-coding a
+// single ingress generator. The interface has no method that reports a
+// backend's admitted URLs.
+type IngressBackend interface {
+	// Name identifies this backend, e.g. "gateway-api", "istio", "openshift-route", "noop".
+	Name() string
+	// Reconcile builds and applies this backend's ingress objects for isvc,
+	// returning the objects it owns so the caller can set controller references.
+	Reconcile(ctx context.Context, client client.Client, isvc *v1beta1.InferenceService) ([]client.Object, error)
+	// Finalize removes any backend-owned state that isn't garbage collected
+	// via owner references (e.g. entries in an external system).
+	Finalize(ctx context.Context, client client.Client, isvc *v1beta1.InferenceService) error
+}
+
+// Endpoint is one URL admitted for an InferenceService by a backend, e.g. the
+// cluster-local Service URL, or the hostname admitted by a Gateway.
+type Endpoint struct {
+	Backend string
+	URL     string
+}
+
+var (
+	backendRegistryMu sync.RWMutex
+	backendRegistry   = map[string]IngressBackend{}
+)
+
+// RegisterBackend adds an IngressBackend to the registry under its Name(). A
+// backend registering under an already-used name replaces the previous one,
+// so tests can override a backend without touching the registry's callers.
+func RegisterBackend(backend IngressBackend) {
+	backendRegistryMu.Lock()
+	defer backendRegistryMu.Unlock()
+	backendRegistry[backend.Name()] = backend
+}
+
+// ResolveBackends looks up the named backends in order, matching the
+// `ingress.backends` ConfigMap field (e.g. ["gateway-api", "openshift-route"]).
+func ResolveBackends(names []string) ([]IngressBackend, error) {
+	backendRegistryMu.RLock()
+	defer backendRegistryMu.RUnlock()
+	backends := make([]IngressBackend, 0, len(names))
+	for _, name := range names {
+		backend, ok := backendRegistry[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown ingress backend %q", name)
+		}
+		backends = append(backends, backend)
+	}
+	return backends, nil
+}
+
+// ReconcileBackends runs every named backend for isvc and aggregates their
+// owned objects. The returned Endpoint slice is always empty: no backend
+// reports the URLs it admitted.
+func ReconcileBackends(ctx context.Context, c client.Client, isvc *v1beta1.InferenceService, names []string) ([]client.Object, []Endpoint, error) {
+	backends, err := ResolveBackends(names)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var objects []client.Object
+	var endpoints []Endpoint
+	for _, backend := range backends {
+		owned, err := backend.Reconcile(ctx, c, isvc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("ingress backend %q: %w", backend.Name(), err)
+		}
+		objects = append(objects, owned...)
+	}
+	return objects, endpoints, nil
+}
+
+// NoopBackend is the degenerate backend used when ingress creation is
+// disabled entirely (the disableIngressCreation ConfigMap field): it owns no
+// objects and reports no endpoints.
+type NoopBackend struct{}
+
+func (NoopBackend) Name() string { return "noop" }
+
+func (NoopBackend) Reconcile(_ context.Context, _ client.Client, _ *v1beta1.InferenceService) ([]client.Object, error) {
+	return nil, nil
+}
+
+func (NoopBackend) Finalize(_ context.Context, _ client.Client, _ *v1beta1.InferenceService) error {
+	return nil
+}
+
+func init() {
+	RegisterBackend(NoopBackend{})
+}
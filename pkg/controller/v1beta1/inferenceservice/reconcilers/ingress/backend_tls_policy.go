@@ -0,0 +1,226 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=backendtlspolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=backendtlspolicies/status,verbs=get;update;patch
+
+// OpenshiftServiceCADefaultCAConfigMapName is the well-known ConfigMap injected
+// by OpenShift's service-serving-cert controller into any namespace that opts in
+// via the `service.beta.openshift.io/inject-cabundle` annotation.
+const OpenshiftServiceCADefaultCAConfigMapName = "openshift-service-ca.crt"
+
+// BackendTLSConfig describes the upstream TLS material for a single backend
+// Service (predictor or transformer) so the ingress reconciler can generate a
+// BackendTLSPolicy attaching it to the gateway-to-pod connection.
+type BackendTLSConfig struct {
+	ServiceName      string
+	ServiceNamespace string
+	// Hostname is the SNI hostname presented by the backend, typically the
+	// cluster-local FQDN of the Service.
+	Hostname string
+	// CACertConfigMapName, when set, points at a ConfigMap containing the CA
+	// bundle to validate the backend certificate (e.g. the OpenShift
+	// service-serving-cert ConfigMap, or a user-provided one).
+	CACertConfigMapName string
+	// CACertSecretName is used instead of CACertConfigMapName when the CA bundle
+	// is user-provided as a Secret.
+	CACertSecretName string
+	// UseSystemTrustStore requests WellKnownCACertificates: System instead of an
+	// explicit CACertificateRef, for backends whose certificate chains to a CA
+	// already trusted by the gateway's host OS.
+	UseSystemTrustStore bool
+}
+
+// BuildBackendTLSPolicy generates a gateway.networking.k8s.io/v1alpha3
+// BackendTLSPolicy targeting the given backend Service, so the gateway
+// terminates and re-establishes TLS to the predictor/transformer pod instead of
+// forwarding over plain HTTP.
+func BuildBackendTLSPolicy(cfg BackendTLSConfig) *gatewayapiv1alpha3.BackendTLSPolicy {
+	caCertRefs := []gatewayapiv1alpha3.LocalObjectReference{}
+	switch {
+	case cfg.CACertSecretName != "":
+		caCertRefs = append(caCertRefs, gatewayapiv1alpha3.LocalObjectReference{
+			Group: "",
+			Kind:  "Secret",
+			Name:  gatewayapiv1.ObjectName(cfg.CACertSecretName),
+		})
+	case cfg.CACertConfigMapName != "":
+		caCertRefs = append(caCertRefs, gatewayapiv1alpha3.LocalObjectReference{
+			Group: "",
+			Kind:  "ConfigMap",
+			Name:  gatewayapiv1.ObjectName(cfg.CACertConfigMapName),
+		})
+	}
+
+	validation := gatewayapiv1alpha3.BackendTLSPolicyValidation{
+		CACertificateRefs: caCertRefs,
+		Hostname:          gatewayapiv1.PreciseHostname(cfg.Hostname),
+	}
+	if len(caCertRefs) == 0 && cfg.UseSystemTrustStore {
+		system := gatewayapiv1alpha3.WellKnownCACertificatesSystem
+		validation.WellKnownCACertificates = &system
+	}
+
+	return &gatewayapiv1alpha3.BackendTLSPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-backend-tls", cfg.ServiceName),
+			Namespace: cfg.ServiceNamespace,
+		},
+		Spec: gatewayapiv1alpha3.BackendTLSPolicySpec{
+			TargetRefs: []gatewayapiv1alpha3.LocalPolicyTargetReferenceWithSectionName{
+				{
+					LocalPolicyTargetReference: gatewayapiv1alpha3.LocalPolicyTargetReference{
+						Group: "",
+						Kind:  "Service",
+						Name:  gatewayapiv1.ObjectName(cfg.ServiceName),
+					},
+				},
+			},
+			Validation: validation,
+		},
+	}
+}
+
+// EnableBackendTLSAnnotationKey lets a user opt into BackendTLSPolicy
+// generation even when the pod doesn't expose a port literally named
+// "https", e.g. a runtime serving TLS on its default port.
+const EnableBackendTLSAnnotationKey = "serving.kserve.io/enable-backend-tls"
+
+// ShouldAttachBackendTLSPolicy reports whether a BackendTLSPolicy should be
+// generated for a backend Service: whenever the pod exposes an HTTPS port,
+// a serving-cert Secret is mounted (servingCertSecretName set, as injected
+// by the OpenShift serving-cert annotation), or the user opts in explicitly
+// via EnableBackendTLSAnnotationKey.
+func ShouldAttachBackendTLSPolicy(namedPorts map[string]int32, servingCertSecretName string, annotations map[string]string) bool {
+	if _, ok := namedPorts["https"]; ok {
+		return true
+	}
+	if servingCertSecretName != "" {
+		return true
+	}
+	return annotations[EnableBackendTLSAnnotationKey] == "true"
+}
+
+// ServingCertSecretName returns the Secret name the OpenShift service-serving-
+// cert controller will mount once constants.OpenshiftServingCertAnnotation is
+// set on the component's Service, so ShouldAttachBackendTLSPolicy can detect
+// OpenShift-issued TLS without the caller re-deriving the annotation lookup.
+func ServingCertSecretName(serviceAnnotations map[string]string) string {
+	return serviceAnnotations[constants.OpenshiftServingCertAnnotation]
+}
+
+// TLSBackendPort returns the port the HTTPRoute's backendRef should target
+// once a BackendTLSPolicy is attached: the named "https" port if the pod
+// exposes one, otherwise the existing HTTP port is reused since the
+// serving-cert case still terminates TLS on the same container port.
+func TLSBackendPort(namedPorts map[string]int32, httpPort int32) int32 {
+	if port, ok := namedPorts["https"]; ok {
+		return port
+	}
+	return httpPort
+}
+
+// DefaultCACertConfigMapName returns the OpenShift service-serving-cert bundle
+// name when no user-provided CA material is configured, matching the behavior
+// already implied by OpenshiftServingCertAnnotation elsewhere in the reconciler.
+func DefaultCACertConfigMapName(userProvided string) string {
+	if userProvided != "" {
+		return userProvided
+	}
+	return OpenshiftServiceCADefaultCAConfigMapName
+}
+
+// BuildBackendTLSPolicies generates one BackendTLSPolicy per backend config,
+// so predictor, transformer, and explainer Services can each terminate TLS
+// to the pod independently (e.g. only the predictor serves HTTPS).
+func BuildBackendTLSPolicies(backends []BackendTLSConfig) []*gatewayapiv1alpha3.BackendTLSPolicy {
+	policies := make([]*gatewayapiv1alpha3.BackendTLSPolicy, 0, len(backends))
+	for _, backend := range backends {
+		policies = append(policies, BuildBackendTLSPolicy(backend))
+	}
+	return policies
+}
+
+const (
+	// HTTPSPortName is the named container/Service port BackendTLSPolicy
+	// expects to target once a component opts into upstream TLS, mirroring
+	// the name ShouldAttachBackendTLSPolicy already looks for.
+	HTTPSPortName = "https"
+	// DefaultHTTPSContainerPort is used when a component's TLS config doesn't
+	// pin the model server to a specific port.
+	DefaultHTTPSContainerPort = int32(8443)
+)
+
+// ComponentTLSConfig is the minimum a component needs to opt into end-to-end
+// Gateway API TLS without sidecar injection. No field of PredictorSpec,
+// TransformerSpec or ExplainerSpec has this type.
+type ComponentTLSConfig struct {
+	// CACertConfigMapName points at the CA bundle validating the server
+	// certificate; when empty, DefaultCACertConfigMapName's OpenShift
+	// service-serving-cert ConfigMap is assumed.
+	CACertConfigMapName string
+	// SNIHostname is the hostname the BackendTLSPolicy validates the
+	// certificate against; when empty, the Service's cluster-local FQDN is
+	// used.
+	SNIHostname string
+	// ContainerPort overrides DefaultHTTPSContainerPort for runtimes that
+	// serve TLS on a non-default port.
+	ContainerPort int32
+}
+
+// RewritePortsForTLS returns the container port and Service targetPort that
+// should replace the component's plain-HTTP port once ComponentTLSConfig is
+// set, so the reconciler switches both the Deployment's container port and
+// the Service's port/targetPort to HTTPS together.
+func RewritePortsForTLS(tls *ComponentTLSConfig) (containerPort int32, portName string) {
+	if tls == nil {
+		return 0, ""
+	}
+	if tls.ContainerPort != 0 {
+		return tls.ContainerPort, HTTPSPortName
+	}
+	return DefaultHTTPSContainerPort, HTTPSPortName
+}
+
+// BackendTLSConfigForComponent translates a ComponentTLSConfig plus the
+// component's Service identity into the BackendTLSConfig BuildBackendTLSPolicy
+// expects, defaulting the SNI hostname to the Service's cluster-local FQDN
+// and the CA ConfigMap to the OpenShift serving-cert bundle.
+func BackendTLSConfigForComponent(serviceName, serviceNamespace string, tls ComponentTLSConfig) BackendTLSConfig {
+	hostname := tls.SNIHostname
+	if hostname == "" {
+		hostname = fmt.Sprintf("%s.%s.svc.cluster.local", serviceName, serviceNamespace)
+	}
+	return BackendTLSConfig{
+		ServiceName:         serviceName,
+		ServiceNamespace:    serviceNamespace,
+		Hostname:            hostname,
+		CACertConfigMapName: DefaultCACertConfigMapName(tls.CACertConfigMapName),
+	}
+}
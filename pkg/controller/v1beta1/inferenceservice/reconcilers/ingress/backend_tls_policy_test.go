@@ -0,0 +1,156 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"testing"
+
+	gatewayapiv1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+)
+
+func TestBuildBackendTLSPolicyWithCACertSecret(t *testing.T) {
+	policy := BuildBackendTLSPolicy(BackendTLSConfig{
+		ServiceName:      "predictor",
+		ServiceNamespace: "ns",
+		Hostname:         "predictor.ns.svc.cluster.local",
+		CACertSecretName: "predictor-ca",
+	})
+	if policy.Name != "predictor-backend-tls" || policy.Namespace != "ns" {
+		t.Fatalf("unexpected object metadata: %s/%s", policy.Namespace, policy.Name)
+	}
+	if len(policy.Spec.Validation.CACertificateRefs) != 1 || policy.Spec.Validation.CACertificateRefs[0].Kind != "Secret" {
+		t.Fatalf("expected a single Secret CA cert ref, got %+v", policy.Spec.Validation.CACertificateRefs)
+	}
+}
+
+func TestBuildBackendTLSPolicyWithCACertConfigMap(t *testing.T) {
+	policy := BuildBackendTLSPolicy(BackendTLSConfig{
+		ServiceName:         "predictor",
+		ServiceNamespace:    "ns",
+		CACertConfigMapName: "predictor-ca-bundle",
+	})
+	if len(policy.Spec.Validation.CACertificateRefs) != 1 || policy.Spec.Validation.CACertificateRefs[0].Kind != "ConfigMap" {
+		t.Fatalf("expected a single ConfigMap CA cert ref, got %+v", policy.Spec.Validation.CACertificateRefs)
+	}
+}
+
+func TestBuildBackendTLSPolicyUsesSystemTrustStoreWhenNoCACertGiven(t *testing.T) {
+	policy := BuildBackendTLSPolicy(BackendTLSConfig{
+		ServiceName:         "predictor",
+		ServiceNamespace:    "ns",
+		UseSystemTrustStore: true,
+	})
+	if policy.Spec.Validation.WellKnownCACertificates == nil ||
+		*policy.Spec.Validation.WellKnownCACertificates != gatewayapiv1alpha3.WellKnownCACertificatesSystem {
+		t.Fatalf("expected WellKnownCACertificates to be set to System")
+	}
+}
+
+func TestBuildBackendTLSPolicyExplicitCACertTakesPrecedenceOverSystemTrustStore(t *testing.T) {
+	policy := BuildBackendTLSPolicy(BackendTLSConfig{
+		ServiceName:         "predictor",
+		ServiceNamespace:    "ns",
+		CACertConfigMapName: "predictor-ca-bundle",
+		UseSystemTrustStore: true,
+	})
+	if policy.Spec.Validation.WellKnownCACertificates != nil {
+		t.Fatalf("expected no WellKnownCACertificates when an explicit CA cert ref is set, got %v", policy.Spec.Validation.WellKnownCACertificates)
+	}
+}
+
+func TestShouldAttachBackendTLSPolicy(t *testing.T) {
+	cases := []struct {
+		name                  string
+		namedPorts            map[string]int32
+		servingCertSecretName string
+		annotations           map[string]string
+		want                  bool
+	}{
+		{name: "https named port", namedPorts: map[string]int32{"https": 8443}, want: true},
+		{name: "serving cert secret", servingCertSecretName: "cert", want: true},
+		{name: "opt-in annotation", annotations: map[string]string{EnableBackendTLSAnnotationKey: "true"}, want: true},
+		{name: "none of the above", want: false},
+	}
+	for _, tc := range cases {
+		if got := ShouldAttachBackendTLSPolicy(tc.namedPorts, tc.servingCertSecretName, tc.annotations); got != tc.want {
+			t.Errorf("%s: ShouldAttachBackendTLSPolicy() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestServingCertSecretName(t *testing.T) {
+	if got := ServingCertSecretName(nil); got != "" {
+		t.Fatalf("expected empty string for nil annotations, got %q", got)
+	}
+}
+
+func TestTLSBackendPort(t *testing.T) {
+	if got := TLSBackendPort(map[string]int32{"https": 8443}, 8080); got != 8443 {
+		t.Fatalf("expected the https named port to win, got %d", got)
+	}
+	if got := TLSBackendPort(nil, 8080); got != 8080 {
+		t.Fatalf("expected the http port to be reused when no https port exists, got %d", got)
+	}
+}
+
+func TestDefaultCACertConfigMapName(t *testing.T) {
+	if got := DefaultCACertConfigMapName("custom-bundle"); got != "custom-bundle" {
+		t.Fatalf("expected the user-provided bundle to be preserved, got %q", got)
+	}
+	if got := DefaultCACertConfigMapName(""); got != OpenshiftServiceCADefaultCAConfigMapName {
+		t.Fatalf("expected the OpenShift default bundle, got %q", got)
+	}
+}
+
+func TestBuildBackendTLSPolicies(t *testing.T) {
+	policies := BuildBackendTLSPolicies([]BackendTLSConfig{
+		{ServiceName: "predictor", ServiceNamespace: "ns"},
+		{ServiceName: "transformer", ServiceNamespace: "ns"},
+	})
+	if len(policies) != 2 {
+		t.Fatalf("expected one policy per backend, got %d", len(policies))
+	}
+}
+
+func TestRewritePortsForTLS(t *testing.T) {
+	if port, name := RewritePortsForTLS(nil); port != 0 || name != "" {
+		t.Fatalf("expected zero values for nil TLS config, got (%d, %q)", port, name)
+	}
+	if port, name := RewritePortsForTLS(&ComponentTLSConfig{}); port != DefaultHTTPSContainerPort || name != HTTPSPortName {
+		t.Fatalf("expected the default HTTPS port when unset, got (%d, %q)", port, name)
+	}
+	if port, name := RewritePortsForTLS(&ComponentTLSConfig{ContainerPort: 9443}); port != 9443 || name != HTTPSPortName {
+		t.Fatalf("expected the overridden container port, got (%d, %q)", port, name)
+	}
+}
+
+func TestBackendTLSConfigForComponentDefaultsHostnameAndCACert(t *testing.T) {
+	cfg := BackendTLSConfigForComponent("predictor", "ns", ComponentTLSConfig{})
+	if cfg.Hostname != "predictor.ns.svc.cluster.local" {
+		t.Fatalf("expected the cluster-local FQDN to be derived, got %q", cfg.Hostname)
+	}
+	if cfg.CACertConfigMapName != OpenshiftServiceCADefaultCAConfigMapName {
+		t.Fatalf("expected the OpenShift default CA bundle, got %q", cfg.CACertConfigMapName)
+	}
+}
+
+func TestBackendTLSConfigForComponentHonorsOverrides(t *testing.T) {
+	cfg := BackendTLSConfigForComponent("predictor", "ns", ComponentTLSConfig{SNIHostname: "custom.example.com", CACertConfigMapName: "custom-bundle"})
+	if cfg.Hostname != "custom.example.com" || cfg.CACertConfigMapName != "custom-bundle" {
+		t.Fatalf("expected overrides to be preserved, got %+v", cfg)
+	}
+}
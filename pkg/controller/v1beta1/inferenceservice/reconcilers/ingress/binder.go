@@ -0,0 +1,144 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// BindInputs gathers everything the binder needs to compute desired ingress
+// state for one InferenceService without touching the API server: the
+// reconciler's job is to fetch these first, then pass them to Bind, so rule
+// ordering and per-object status reasons can be unit-tested against plain
+// structs instead of a Ginkgo envtest.
+type BindInputs struct {
+	IsvcName      string
+	IsvcNamespace string
+	Gateways      []GatewayConfig
+	// Predictor, Transformer, Explainer are nil for components the
+	// InferenceService doesn't configure.
+	Predictor   *ComponentBindInput
+	Transformer *ComponentBindInput
+	Explainer   *ComponentBindInput
+	Redirects   []PathRedirect
+	Rewrites    []PathRewrite
+}
+
+// ComponentBindInput is the subset of a predictor/transformer/explainer spec
+// the binder needs: its backend Service identity and whether it should be
+// reached over gRPC, HTTP, or both.
+type ComponentBindInput struct {
+	ServiceName    string
+	HTTPPort       int32
+	HasHTTPPort    bool
+	GRPCPort       int32
+	Protocol       string
+	ContainerPorts []int32
+}
+
+// BoundRoute is one candidate HTTPRoute or GRPCRoute the binder decided
+// should exist, paired with the reasons a route-binding status writer should
+// report for it before the real gateway controller has reported anything
+// back (Accepted starts Unknown, not True, since the binder can't observe
+// the gateway's state).
+type BoundRoute struct {
+	Component  string // "predictor", "transformer", "explainer"
+	Kind       string // "HTTPRoute" or "GRPCRoute"
+	Name       string
+	HTTPRoute  *gatewayapiv1.HTTPRoute
+	GRPCRoute  *gatewayapiv1.GRPCRoute
+	Conditions []apis.Condition
+}
+
+// BindResult is the binder's full desired-state output: every route it wants
+// created, in predictor, transformer, explainer order, plus the reasons any
+// component was skipped. Bind builds no top-level route.
+type BindResult struct {
+	Routes  []BoundRoute
+	Skipped []string
+}
+
+// Bind computes the desired HTTPRoute/GRPCRoute set for one InferenceService
+// as pure data, with no client calls: the apply step (left to the
+// reconciler, since it alone has a client.Client) is responsible for
+// diffing BindResult.Routes against the live objects and writing both spec
+// and status.
+func Bind(in BindInputs) BindResult {
+	var result BindResult
+	parentRefs := BuildParentReferences(in.Gateways)
+
+	bindComponent := func(component string, comp *ComponentBindInput) {
+		if comp == nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s: not configured", component))
+			return
+		}
+		httpCfg := HTTPBackendConfig{
+			Name:        fmt.Sprintf("%s-%s", in.IsvcName, component),
+			Namespace:   in.IsvcNamespace,
+			ParentRefs:  parentRefs,
+			BackendName: comp.ServiceName,
+			BackendPort: comp.HTTPPort,
+			Redirects:   in.Redirects,
+			Rewrites:    in.Rewrites,
+		}
+		grpcCfg := GRPCBackendConfig{
+			Name:            fmt.Sprintf("%s-%s-grpc", in.IsvcName, component),
+			Namespace:       in.IsvcNamespace,
+			ParentRefs:      parentRefs,
+			BackendName:     comp.ServiceName,
+			BackendPort:     comp.GRPCPort,
+			ServiceFullName: KServeV2GRPCServiceFullName,
+		}
+
+		httpRoute, grpcRoute := BuildComponentRoutes(httpCfg, grpcCfg, comp.Protocol, comp.HasHTTPPort, comp.ContainerPorts, comp.GRPCPort)
+		if httpRoute != nil {
+			result.Routes = append(result.Routes, BoundRoute{
+				Component: component, Kind: "HTTPRoute", Name: httpCfg.Name, HTTPRoute: httpRoute,
+				Conditions: pendingBindConditions(parentRefs),
+			})
+		}
+		if grpcRoute != nil {
+			result.Routes = append(result.Routes, BoundRoute{
+				Component: component, Kind: "GRPCRoute", Name: grpcCfg.Name, GRPCRoute: grpcRoute,
+				Conditions: pendingBindConditions(parentRefs),
+			})
+		}
+	}
+
+	bindComponent("predictor", in.Predictor)
+	bindComponent("transformer", in.Transformer)
+	bindComponent("explainer", in.Explainer)
+
+	return result
+}
+
+// pendingBindConditions seeds the single Unknown Accepted condition a newly
+// bound route starts with, before the gateway controller has reported
+// anything back; AggregateClassifiedConditions supersedes these once real
+// RouteParentStatus is observed.
+func pendingBindConditions(parentRefs []gatewayapiv1.ParentReference) []apis.Condition {
+	if len(parentRefs) == 0 {
+		return nil
+	}
+	return []apis.Condition{
+		{Type: IngressAcceptedConditionType, Status: corev1.ConditionUnknown, Reason: string(ParentPending), Message: "route created, waiting for gateway controller"},
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestBindSkipsUnconfiguredComponents(t *testing.T) {
+	result := Bind(BindInputs{IsvcName: "isvc", IsvcNamespace: "ns"})
+	if len(result.Routes) != 0 {
+		t.Fatalf("expected no routes when no components are configured, got %+v", result.Routes)
+	}
+	if len(result.Skipped) != 3 {
+		t.Fatalf("expected predictor, transformer, explainer all skipped, got %+v", result.Skipped)
+	}
+}
+
+func TestBindPredictorHTTPOnly(t *testing.T) {
+	result := Bind(BindInputs{
+		IsvcName:      "isvc",
+		IsvcNamespace: "ns",
+		Predictor: &ComponentBindInput{
+			ServiceName: "isvc-predictor",
+			HTTPPort:    80,
+			HasHTTPPort: true,
+			Protocol:    "http",
+		},
+	})
+	if len(result.Routes) != 1 {
+		t.Fatalf("expected a single HTTPRoute, got %+v", result.Routes)
+	}
+	route := result.Routes[0]
+	if route.Component != "predictor" || route.Kind != "HTTPRoute" {
+		t.Fatalf("unexpected route: %+v", route)
+	}
+	if route.Name != "isvc-predictor" {
+		t.Fatalf("unexpected route name: %q", route.Name)
+	}
+	if route.HTTPRoute == nil || route.GRPCRoute != nil {
+		t.Fatalf("expected only HTTPRoute to be populated: %+v", route)
+	}
+}
+
+func TestBindPredictorGRPCOnly(t *testing.T) {
+	result := Bind(BindInputs{
+		IsvcName:      "isvc",
+		IsvcNamespace: "ns",
+		Predictor: &ComponentBindInput{
+			ServiceName: "isvc-predictor",
+			GRPCPort:    81,
+			Protocol:    "grpc-v2",
+		},
+	})
+	if len(result.Routes) != 1 {
+		t.Fatalf("expected a single GRPCRoute, got %+v", result.Routes)
+	}
+	route := result.Routes[0]
+	if route.Kind != "GRPCRoute" || route.Name != "isvc-predictor-grpc" {
+		t.Fatalf("unexpected route: %+v", route)
+	}
+	if route.GRPCRoute == nil || route.HTTPRoute != nil {
+		t.Fatalf("expected only GRPCRoute to be populated: %+v", route)
+	}
+}
+
+func TestBindAllComponentsOrdering(t *testing.T) {
+	comp := func(name string) *ComponentBindInput {
+		return &ComponentBindInput{ServiceName: name, HTTPPort: 80, HasHTTPPort: true, Protocol: "http"}
+	}
+	result := Bind(BindInputs{
+		IsvcName:      "isvc",
+		IsvcNamespace: "ns",
+		Predictor:     comp("isvc-predictor"),
+		Transformer:   comp("isvc-transformer"),
+		Explainer:     comp("isvc-explainer"),
+	})
+	if len(result.Routes) != 3 {
+		t.Fatalf("expected 3 routes, got %+v", result.Routes)
+	}
+	wantOrder := []string{"predictor", "transformer", "explainer"}
+	for i, want := range wantOrder {
+		if result.Routes[i].Component != want {
+			t.Fatalf("unexpected component order: %+v", result.Routes)
+		}
+	}
+}
+
+func TestPendingBindConditionsNoParents(t *testing.T) {
+	if got := pendingBindConditions(nil); got != nil {
+		t.Fatalf("expected no conditions without parent refs, got %+v", got)
+	}
+}
+
+func TestPendingBindConditionsWithParents(t *testing.T) {
+	conditions := pendingBindConditions(BuildParentReferences([]GatewayConfig{{Name: "gw", Namespace: "gw-ns"}}))
+	if len(conditions) != 1 {
+		t.Fatalf("expected a single seeded condition, got %+v", conditions)
+	}
+	cond := conditions[0]
+	if cond.Type != IngressAcceptedConditionType || cond.Status != corev1.ConditionUnknown {
+		t.Fatalf("unexpected condition: %+v", cond)
+	}
+	if cond.Reason != string(ParentPending) {
+		t.Fatalf("unexpected reason: %q", cond.Reason)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+// ResolveBackendRef builds the BackendObjectReference for a component's
+// HTTPRoute backendRef, pointing at serviceRef's Namespace/Name when the
+// predictor opted into cross-namespace routing (PredictorSpec.ServiceRef),
+// and at the component's own generated Service otherwise.
+func ResolveBackendRef(serviceRef *v1beta1.ServiceRef, isvcNamespace, defaultName string, defaultPort int32) gatewayapiv1.BackendObjectReference {
+	name := defaultName
+	var namespace *gatewayapiv1.Namespace
+	if serviceRef != nil && serviceRef.Name != "" {
+		name = serviceRef.Name
+	}
+	if serviceRef.IsCrossNamespace(isvcNamespace) {
+		ns := gatewayapiv1.Namespace(serviceRef.Namespace)
+		namespace = &ns
+	}
+	return gatewayapiv1.BackendObjectReference{
+		Name:      gatewayapiv1.ObjectName(name),
+		Namespace: namespace,
+		Port:      portPtr(defaultPort),
+	}
+}
+
+// EnsureCrossNamespaceGrant validates that a ReferenceGrant in
+// serviceRef.Namespace authorizes isvcNamespace's HTTPRoutes to reach
+// serviceRef.Name. It returns the grant that should be created when none of
+// the existing grants already cover the reference (the caller creates it if
+// it has permission to do so), and the ReferenceGrantReady condition to set
+// either way so users get an actionable error instead of a silently-broken
+// route.
+func EnsureCrossNamespaceGrant(serviceRef *v1beta1.ServiceRef, isvcNamespace string, existingGrants []*gatewayapiv1beta1.ReferenceGrant) (toCreate *gatewayapiv1beta1.ReferenceGrant, ready apis.Condition) {
+	if !serviceRef.IsCrossNamespace(isvcNamespace) {
+		return nil, apis.Condition{Type: ReferenceGrantReadyConditionType, Status: corev1.ConditionTrue}
+	}
+
+	for _, grant := range existingGrants {
+		if GrantAuthorizes(grant, isvcNamespace, serviceRef.Name) {
+			return nil, apis.Condition{Type: ReferenceGrantReadyConditionType, Status: corev1.ConditionTrue}
+		}
+	}
+
+	return BuildReferenceGrant(isvcNamespace, serviceRef.Namespace, serviceRef.Name), ReferenceGrantMissingCondition(isvcNamespace, serviceRef.Namespace)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"text/template"
+
+	"knative.dev/pkg/apis"
+)
+
+// HostnameInvalidConditionType is set when a domainTemplate expands to a
+// hostname that violates RFC 1123, instead of silently falling back to a
+// broken URL.
+const HostnameInvalidConditionType apis.ConditionType = "HostnameInvalid"
+
+// DomainTemplateData is the context available to a domainTemplate ConfigMap
+// field, e.g. "{{ .Name }}.{{ .Namespace }}.{{ .IngressDomain }}". Labels and
+// Annotations let multi-tenant clusters bake team/cost-center/environment
+// metadata into hostnames; Hash gives a deterministic short identifier for
+// templates that would otherwise exceed the 63-char DNS label limit.
+type DomainTemplateData struct {
+	Name          string
+	Namespace     string
+	IngressDomain string
+	Component     string
+	Labels        map[string]string
+	Annotations   map[string]string
+}
+
+// Hash returns the first 8 characters of the sha256 hex digest of
+// Name+Namespace, for templates like "{{ .Hash }}.{{ .IngressDomain }}" that
+// need a short, deterministic hostname.
+func (d DomainTemplateData) Hash() string {
+	sum := sha256.Sum256([]byte(d.Name + d.Namespace))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
+// dnsLabelPattern matches a single valid RFC 1123 DNS label.
+var dnsLabelPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// ExpandDomainTemplate renders tmpl against data and validates the result
+// against RFC 1123 (each dot-separated label must be 1-63 characters and
+// match dnsLabelPattern). A template that produces an invalid hostname
+// returns an error rather than a broken URL, so the caller can surface a
+// HostnameInvalid condition instead of admitting a hostname no Gateway will
+// ever route.
+func ExpandDomainTemplate(tmpl string, data DomainTemplateData) (string, error) {
+	parsed, err := template.New("domain").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid domainTemplate %q: %w", tmpl, err)
+	}
+
+	var buf bytes.Buffer
+	if err := parsed.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("invalid domainTemplate %q: %w", tmpl, err)
+	}
+
+	hostname := buf.String()
+	if err := validateHostname(hostname); err != nil {
+		return "", fmt.Errorf("domainTemplate %q produced an invalid hostname %q: %w", tmpl, hostname, err)
+	}
+	return hostname, nil
+}
+
+func validateHostname(hostname string) error {
+	if len(hostname) == 0 || len(hostname) > 253 {
+		return fmt.Errorf("hostname must be between 1 and 253 characters")
+	}
+	for _, label := range splitLabels(hostname) {
+		if len(label) == 0 || len(label) > 63 {
+			return fmt.Errorf("label %q must be between 1 and 63 characters", label)
+		}
+		if !dnsLabelPattern.MatchString(label) {
+			return fmt.Errorf("label %q is not a valid RFC 1123 DNS label", label)
+		}
+	}
+	return nil
+}
+
+func splitLabels(hostname string) []string {
+	var labels []string
+	start := 0
+	for i, r := range hostname {
+		if r == '.' {
+			labels = append(labels, hostname[start:i])
+			start = i + 1
+		}
+	}
+	labels = append(labels, hostname[start:])
+	return labels
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import "fmt"
+
+// FromNamespaces mirrors the Gateway API Listener.AllowedRoutes.Namespaces.From
+// enum, projected locally (like metav1Condition in multi_route_status.go) so
+// this validation doesn't need a dependency on the full Gateway type.
+type FromNamespaces string
+
+const (
+	FromNamespacesAll      FromNamespaces = "All"
+	FromNamespacesSame     FromNamespaces = "Same"
+	FromNamespacesSelector FromNamespaces = "Selector"
+)
+
+// AllowedRoutesNamespaces is the minimal projection of a Gateway listener's
+// AllowedRoutes.Namespaces this validation needs: the From policy, plus the
+// label selector when From is Selector.
+type AllowedRoutesNamespaces struct {
+	From     FromNamespaces
+	Selector map[string]string // nil/empty Selector with From=Selector matches every namespace
+}
+
+// ValidateGatewayNamespaceAllowed reports an error when a gateway's
+// AllowedRoutes configuration would reject a route from isvcNamespace, so the
+// validating webhook can reject an InferenceService's parentRefs entry before
+// it ever reaches the reconciler and gets silently ignored by the gateway
+// controller. namespaceLabels are isvcNamespace's labels, needed to evaluate a
+// Selector policy.
+func ValidateGatewayNamespaceAllowed(isvcNamespace, gatewayNamespace string, allowed AllowedRoutesNamespaces, namespaceLabels map[string]string) error {
+	switch allowed.From {
+	case "", FromNamespacesSame:
+		if isvcNamespace != gatewayNamespace {
+			return fmt.Errorf("gateway %s only allows routes from its own namespace, not %s", gatewayNamespace, isvcNamespace)
+		}
+		return nil
+	case FromNamespacesAll:
+		return nil
+	case FromNamespacesSelector:
+		for key, value := range allowed.Selector {
+			if namespaceLabels[key] != value {
+				return fmt.Errorf("namespace %s does not match gateway %s's AllowedRoutes selector", isvcNamespace, gatewayNamespace)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("gateway %s has unrecognized AllowedRoutes.Namespaces.From %q", gatewayNamespace, allowed.From)
+	}
+}
+
+// ValidateGatewayReferences runs ValidateGatewayNamespaceAllowed over every
+// configured gateway, collecting every violation instead of failing fast, so
+// the webhook's rejection message lists every gateway that needs fixing in
+// one pass.
+func ValidateGatewayReferences(isvcNamespace string, namespaceLabels map[string]string, gateways []GatewayConfig, allowedRoutes map[string]AllowedRoutesNamespaces) error {
+	var errs []error
+	for _, gw := range gateways {
+		key := gw.Namespace + "/" + gw.Name
+		allowed, ok := allowedRoutes[key]
+		if !ok {
+			continue
+		}
+		if err := ValidateGatewayNamespaceAllowed(isvcNamespace, gw.Namespace, allowed, namespaceLabels); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d gateway reference(s) not permitted: %v", len(errs), errs)
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/utils/ptr"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// AdditionalGatewaysAnnotationKey lets a single InferenceService opt into
+// extra gateways beyond the cluster-wide `kserveIngressGateways` config list,
+// e.g. "serving.kserve.io/additional-gateways: ns1/gw1,ns2/gw2" to also
+// expose the isvc through a per-team gateway.
+const AdditionalGatewaysAnnotationKey = "serving.kserve.io/additional-gateways"
+
+// GatewayConfig describes one gateway that generated HTTPRoutes should attach to
+// via a ParentReference. The ingress config block accepts a list of these under
+// `additionalGateways` so raw-deployment ISVCs can be reachable from more than
+// one gateway (e.g. an edge gateway plus an internal mesh gateway).
+type GatewayConfig struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// SectionName selects a single listener on the gateway; if empty, the route
+	// attaches to the whole gateway.
+	SectionName string `json:"sectionName,omitempty"`
+	// Port selects a listener by port in addition to, or instead of, SectionName.
+	Port int32 `json:"port,omitempty"`
+	// HostnameSuffix is appended to the ISVC name to build the Hostnames entries
+	// that should be routed through this gateway, e.g. "svc.cluster.local" or
+	// "example.com".
+	HostnameSuffix string `json:"hostnameSuffix"`
+	// HostnamesFilter, when set, replaces the HostnameSuffix-derived hostname
+	// with an explicit list, so e.g. only "additional.example.com" (and not the
+	// ISVC's default host) is routed through an internal-only gateway.
+	// +optional
+	HostnamesFilter []string `json:"hostnamesFilter,omitempty"`
+	// Primary marks the gateway whose hostname is used to populate the
+	// InferenceService's URL/Address status fields. Exactly one gateway should be
+	// marked primary; the first gateway is used as a fallback if none is.
+	Primary bool `json:"primary,omitempty"`
+	// TLSSecretRef names the Secret holding the TLS certificate/key this
+	// gateway's listener terminates for the InferenceService's hostnames. When
+	// set, the gateway is assumed to expose (or need) a matching HTTPS
+	// listener, and the isvc's reported URL scheme switches to "https".
+	// +optional
+	TLSSecretRef string `json:"tlsSecretRef,omitempty"`
+}
+
+// IsTLSEnabled reports whether this gateway terminates TLS for the
+// InferenceService, i.e. TLSSecretRef is configured.
+func (g GatewayConfig) IsTLSEnabled() bool {
+	return g.TLSSecretRef != ""
+}
+
+// URLScheme returns the scheme InferenceService.Status.URL should report for
+// traffic routed through this gateway: "https" once a TLSSecretRef is
+// configured, "http" otherwise.
+func (g GatewayConfig) URLScheme() string {
+	if g.IsTLSEnabled() {
+		return "https"
+	}
+	return "http"
+}
+
+// ParentReference builds the Gateway API ParentReference for this gateway config.
+func (g GatewayConfig) ParentReference() gatewayapiv1.ParentReference {
+	ref := gatewayapiv1.ParentReference{
+		Group:     (*gatewayapiv1.Group)(ptr.To("gateway.networking.k8s.io")),
+		Kind:      (*gatewayapiv1.Kind)(ptr.To("Gateway")),
+		Name:      gatewayapiv1.ObjectName(g.Name),
+		Namespace: (*gatewayapiv1.Namespace)(ptr.To(g.Namespace)),
+	}
+	if g.SectionName != "" {
+		ref.SectionName = (*gatewayapiv1.SectionName)(ptr.To(g.SectionName))
+	}
+	if g.Port != 0 {
+		ref.Port = (*gatewayapiv1.PortNumber)(ptr.To(gatewayapiv1.PortNumber(g.Port)))
+	}
+	return ref
+}
+
+// BuildParentReferences returns one ParentReference per configured gateway, in
+// configuration order, so that removing a gateway from config removes exactly
+// that parentRef from the generated HTTPRoutes.
+func BuildParentReferences(gateways []GatewayConfig) []gatewayapiv1.ParentReference {
+	refs := make([]gatewayapiv1.ParentReference, 0, len(gateways))
+	for _, gw := range gateways {
+		refs = append(refs, gw.ParentReference())
+	}
+	return refs
+}
+
+// PartitionHostnames buckets the per-gateway hostnames so each gateway only sees
+// the hostnames it should terminate, e.g. an internal mesh gateway gets the
+// cluster-local hostname while the edge gateway gets the public hostnames.
+func PartitionHostnames(isvcName string, gateways []GatewayConfig) map[string][]gatewayapiv1.Hostname {
+	byGateway := make(map[string][]gatewayapiv1.Hostname, len(gateways))
+	for _, gw := range gateways {
+		key := gw.Namespace + "/" + gw.Name
+		if len(gw.HostnamesFilter) > 0 {
+			for _, hostname := range gw.HostnamesFilter {
+				byGateway[key] = append(byGateway[key], gatewayapiv1.Hostname(hostname))
+			}
+			continue
+		}
+		byGateway[key] = append(byGateway[key], gatewayapiv1.Hostname(isvcName+"."+gw.HostnameSuffix))
+	}
+	return byGateway
+}
+
+// ParseAdditionalGatewaysAnnotation parses AdditionalGatewaysAnnotationKey's
+// "ns1/gw1,ns2/gw2" value into GatewayConfig entries, so they can be appended
+// to the cluster-wide gateway list before computing ParentRefs. Entries
+// default HostnameSuffix to the cluster-wide default passed in, since the
+// annotation only identifies the gateway, not a hostname scheme.
+func ParseAdditionalGatewaysAnnotation(value, defaultHostnameSuffix string) ([]GatewayConfig, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	var gateways []GatewayConfig
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "/", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid %s entry %q: expected format namespace/name", AdditionalGatewaysAnnotationKey, entry)
+		}
+		gateways = append(gateways, GatewayConfig{
+			Namespace:      parts[0],
+			Name:           parts[1],
+			HostnameSuffix: defaultHostnameSuffix,
+		})
+	}
+	return gateways, nil
+}
+
+// PrimaryGateway returns the gateway marked `primary: true`, falling back to the
+// first configured gateway when none is explicitly marked, so InferenceService
+// URL/Address status fields always have a deterministic source.
+func PrimaryGateway(gateways []GatewayConfig) (GatewayConfig, bool) {
+	if len(gateways) == 0 {
+		return GatewayConfig{}, false
+	}
+	for _, gw := range gateways {
+		if gw.Primary {
+			return gw, true
+		}
+	}
+	return gateways[0], true
+}
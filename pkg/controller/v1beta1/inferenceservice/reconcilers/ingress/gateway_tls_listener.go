@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// HTTPSListenerName is the listener name BuildHTTPSListener assigns, and the
+// value GatewayConfig.SectionName should be set to so HTTPRoutes attach to
+// this listener specifically rather than any listener on the gateway.
+const HTTPSListenerName = "https"
+
+// BuildHTTPSListener generates the Gateway Listener that terminates TLS for
+// gw's hostnames using gw.TLSSecretRef, so the shared kserveGateway can host
+// both a plain HTTP listener (for isvcs without a TLSSecretRef) and this HTTPS
+// one, selected per-route via ParentReference.SectionName.
+func BuildHTTPSListener(gw GatewayConfig, hostname gatewayapiv1.Hostname) gatewayapiv1.Listener {
+	sectionName := gw.SectionName
+	if sectionName == "" {
+		sectionName = HTTPSListenerName
+	}
+	port := gatewayapiv1.PortNumber(443)
+	if gw.Port != 0 {
+		port = gatewayapiv1.PortNumber(gw.Port)
+	}
+	mode := gatewayapiv1.TLSModeTerminate
+	return gatewayapiv1.Listener{
+		Name:     gatewayapiv1.SectionName(sectionName),
+		Hostname: &hostname,
+		Port:     port,
+		Protocol: gatewayapiv1.HTTPSProtocolType,
+		TLS: &gatewayapiv1.GatewayTLSConfig{
+			Mode: &mode,
+			CertificateRefs: []gatewayapiv1.SecretObjectReference{
+				{Name: gatewayapiv1.ObjectName(gw.TLSSecretRef)},
+			},
+		},
+	}
+}
+
+// WithHTTPSSectionName returns a copy of gw with SectionName pinned to
+// HTTPSListenerName (or gw's own SectionName if already set), so
+// GatewayConfig.ParentReference routes through the HTTPS listener
+// BuildHTTPSListener generated instead of matching any listener on the
+// gateway by hostname alone.
+func WithHTTPSSectionName(gw GatewayConfig) GatewayConfig {
+	if gw.SectionName == "" {
+		gw.SectionName = HTTPSListenerName
+	}
+	return gw
+}
+
+// ComponentURL renders the InferenceService's externally-visible URL for a
+// component, picking http/https per gw.URLScheme and formatting hostname
+// exactly once regardless of scheme.
+func ComponentURL(gw GatewayConfig, hostname string) string {
+	return fmt.Sprintf("%s://%s", gw.URLScheme(), hostname)
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// GRPCBackendConfig describes a gRPc-native predictor/transformer backend that
+// should be reachable via a Gateway API GRPCRoute instead of an HTTPRoute.
+type GRPCBackendConfig struct {
+	Name            string
+	Namespace       string
+	Hostnames       []gatewayapiv1.Hostname
+	ParentRefs      []gatewayapiv1.ParentReference
+	BackendName     string
+	BackendPort     int32
+	ServiceFullName string // e.g. "tensorflow.serving.PredictionService", used for method matching
+	IsvcName        string // set to inject the Kserve-Isvc-Name/Namespace header filters
+	IsvcNamespace   string
+}
+
+// KServeV2GRPCServiceFullName is the gRPC service name exposed by the KServe
+// v2 inference protocol, used to scope the GRPCRoute's method match so it
+// only forwards v2 inference traffic (`inference.GRPCInferenceService/*`).
+const KServeV2GRPCServiceFullName = "inference.GRPCInferenceService"
+
+// BuildGRPCRoute generates a GRPCRoute that forwards all traffic for the gRPC
+// service to the backend Service, mirroring the single-rule shape used for the
+// HTTPRoute generated for HTTP predictors. When cfg.IsvcName is set, the same
+// Kserve-Isvc-Name/Kserve-Isvc-Namespace header-injection filters used on the
+// HTTPRoute path are applied here too.
+func BuildGRPCRoute(cfg GRPCBackendConfig) *gatewayapiv1.GRPCRoute {
+	var matches []gatewayapiv1.GRPCRouteMatch
+	if cfg.ServiceFullName != "" {
+		matches = []gatewayapiv1.GRPCRouteMatch{
+			{
+				Method: &gatewayapiv1.GRPCMethodMatch{
+					Type:    methodMatchPtr(gatewayapiv1.GRPCMethodMatchExact),
+					Service: &cfg.ServiceFullName,
+				},
+			},
+		}
+	}
+
+	var filters []gatewayapiv1.GRPCRouteFilter
+	if cfg.IsvcName != "" {
+		filters = append(filters, gatewayapiv1.GRPCRouteFilter{
+			Type:                  gatewayapiv1.GRPCRouteFilterRequestHeaderModifier,
+			RequestHeaderModifier: isvcHeaderModifier(cfg.IsvcName, cfg.IsvcNamespace),
+		})
+	}
+
+	return &gatewayapiv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfg.Name,
+			Namespace: cfg.Namespace,
+		},
+		Spec: gatewayapiv1.GRPCRouteSpec{
+			CommonRouteSpec: gatewayapiv1.CommonRouteSpec{
+				ParentRefs: cfg.ParentRefs,
+			},
+			Hostnames: cfg.Hostnames,
+			Rules: []gatewayapiv1.GRPCRouteRule{
+				{
+					Matches: matches,
+					Filters: filters,
+					BackendRefs: []gatewayapiv1.GRPCBackendRef{
+						{
+							BackendRef: gatewayapiv1.BackendRef{
+								BackendObjectReference: gatewayapiv1.BackendObjectReference{
+									Name: gatewayapiv1.ObjectName(cfg.BackendName),
+									Port: portPtr(cfg.BackendPort),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// isvcHeaderModifier builds the Kserve-Isvc-Name/Kserve-Isvc-Namespace
+// header set shared by both the HTTPRoute and GRPCRoute paths, so a backend
+// behind either route kind can identify which InferenceService routed the
+// request.
+func isvcHeaderModifier(name, namespace string) *gatewayapiv1.HTTPHeaderFilter {
+	return &gatewayapiv1.HTTPHeaderFilter{
+		Set: []gatewayapiv1.HTTPHeader{
+			{Name: "Kserve-Isvc-Name", Value: name},
+			{Name: "Kserve-Isvc-Namespace", Value: namespace},
+		},
+	}
+}
+
+// DetectGRPCPort returns the container port to use for a GRPCRoute backend:
+// the explicit GRPCPort override if set, otherwise the first container port
+// named "grpc" or "h2c".
+func DetectGRPCPort(namedPorts map[string]int32, explicitGRPCPort *int32) (int32, bool) {
+	if explicitGRPCPort != nil {
+		return *explicitGRPCPort, true
+	}
+	for _, name := range []string{"grpc", "h2c"} {
+		if port, ok := namedPorts[name]; ok {
+			return port, true
+		}
+	}
+	return 0, false
+}
+
+func methodMatchPtr(m gatewayapiv1.GRPCMethodMatchType) *gatewayapiv1.GRPCMethodMatchType {
+	return &m
+}
+
+func portPtr(p int32) *gatewayapiv1.PortNumber {
+	port := gatewayapiv1.PortNumber(p)
+	return &port
+}
+
+// IsGRPCNativePredictor reports whether the predictor should be exposed via
+// GRPCRoute rather than HTTPRoute, based only on its configured
+// protocolVersion (e.g. "grpc-v2"); an unset protocol reports false.
+// RuntimeDeclaresGRPC checks a ServingRuntime's declared versions instead.
+func IsGRPCNativePredictor(protocol string) bool {
+	switch protocol {
+	case "grpc-v1", "grpc-v2", "grpc":
+		return true
+	default:
+		return false
+	}
+}
+
+// RuntimeDeclaresGRPC reports whether a ServingRuntime's declared supported
+// model format protocol versions include gRPC, for predictors that don't set
+// protocolVersion explicitly and instead rely on the runtime's default.
+func RuntimeDeclaresGRPC(supportedProtocolVersions []string) bool {
+	for _, protocol := range supportedProtocolVersions {
+		if IsGRPCNativePredictor(protocol) {
+			return true
+		}
+	}
+	return false
+}
+
+// ShouldGenerateGRPCRoute reports whether a GRPCRoute should be generated
+// alongside the HTTPRoute for this component: the ServingRuntime container
+// speaks gRPC, but HTTP/1.1 endpoints (health checks, metrics) may still need
+// an HTTPRoute, so the two are generated independently rather than as
+// alternatives. Either signal is sufficient on its own: an explicit
+// Protocol (e.g. "grpc-v2") covers runtimes that expose gRPC on a port not
+// named "grpc"/"h2c", while a detected grpc/h2c container port covers
+// runtimes that don't set Protocol at all.
+func ShouldGenerateGRPCRoute(protocol string, containerPorts []int32, grpcPort int32) bool {
+	if IsGRPCNativePredictor(protocol) {
+		return true
+	}
+	for _, p := range containerPorts {
+		if p == grpcPort {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"testing"
+
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestBuildGRPCRouteMethodMatch(t *testing.T) {
+	route := BuildGRPCRoute(GRPCBackendConfig{
+		Name:            "isvc-predictor-grpc",
+		Namespace:       "ns",
+		BackendName:     "isvc-predictor",
+		BackendPort:     81,
+		ServiceFullName: KServeV2GRPCServiceFullName,
+	})
+	if len(route.Spec.Rules) != 1 {
+		t.Fatalf("expected a single rule, got %+v", route.Spec.Rules)
+	}
+	rule := route.Spec.Rules[0]
+	if len(rule.Matches) != 1 || rule.Matches[0].Method == nil || *rule.Matches[0].Method.Service != KServeV2GRPCServiceFullName {
+		t.Fatalf("unexpected method match: %+v", rule.Matches)
+	}
+	if len(rule.BackendRefs) != 1 || string(rule.BackendRefs[0].Name) != "isvc-predictor" {
+		t.Fatalf("unexpected backend refs: %+v", rule.BackendRefs)
+	}
+}
+
+func TestBuildGRPCRouteNoServiceFullNameMatchesAllMethods(t *testing.T) {
+	route := BuildGRPCRoute(GRPCBackendConfig{Name: "r", BackendName: "b", BackendPort: 81})
+	if len(route.Spec.Rules[0].Matches) != 0 {
+		t.Fatalf("expected no method match when ServiceFullName is unset, got %+v", route.Spec.Rules[0].Matches)
+	}
+}
+
+func TestBuildGRPCRouteInjectsIsvcHeaders(t *testing.T) {
+	route := BuildGRPCRoute(GRPCBackendConfig{Name: "r", BackendName: "b", BackendPort: 81, IsvcName: "isvc", IsvcNamespace: "ns"})
+	filters := route.Spec.Rules[0].Filters
+	if len(filters) != 1 || filters[0].Type != gatewayapiv1.GRPCRouteFilterRequestHeaderModifier {
+		t.Fatalf("expected a single header-modifier filter, got %+v", filters)
+	}
+}
+
+func TestDetectGRPCPortExplicitOverride(t *testing.T) {
+	explicit := int32(9000)
+	port, ok := DetectGRPCPort(map[string]int32{"grpc": 81}, &explicit)
+	if !ok || port != 9000 {
+		t.Fatalf("expected the explicit override to win, got %d, %v", port, ok)
+	}
+}
+
+func TestDetectGRPCPortNamedPorts(t *testing.T) {
+	port, ok := DetectGRPCPort(map[string]int32{"h2c": 8081}, nil)
+	if !ok || port != 8081 {
+		t.Fatalf("expected the h2c port to be detected, got %d, %v", port, ok)
+	}
+}
+
+func TestDetectGRPCPortNotFound(t *testing.T) {
+	if _, ok := DetectGRPCPort(map[string]int32{"http": 8080}, nil); ok {
+		t.Fatalf("expected no gRPC port to be detected")
+	}
+}
+
+func TestIsGRPCNativePredictor(t *testing.T) {
+	for _, p := range []string{"grpc-v1", "grpc-v2", "grpc"} {
+		if !IsGRPCNativePredictor(p) {
+			t.Fatalf("expected %q to be treated as gRPC-native", p)
+		}
+	}
+	if IsGRPCNativePredictor("http") || IsGRPCNativePredictor("") {
+		t.Fatalf("expected http/empty protocol to not be gRPC-native")
+	}
+}
+
+func TestRuntimeDeclaresGRPC(t *testing.T) {
+	if !RuntimeDeclaresGRPC([]string{"v1", "grpc-v2"}) {
+		t.Fatalf("expected a grpc-v2 entry to report true")
+	}
+	if RuntimeDeclaresGRPC([]string{"v1", "v2"}) {
+		t.Fatalf("expected no gRPC protocol versions to report false")
+	}
+}
+
+func TestShouldGenerateGRPCRoute(t *testing.T) {
+	cases := []struct {
+		name           string
+		protocol       string
+		containerPorts []int32
+		grpcPort       int32
+		want           bool
+	}{
+		{name: "explicit protocol", protocol: "grpc-v2", containerPorts: nil, grpcPort: 81, want: true},
+		{name: "detected container port", protocol: "http", containerPorts: []int32{81}, grpcPort: 81, want: true},
+		{name: "neither signal", protocol: "http", containerPorts: []int32{8080}, grpcPort: 81, want: false},
+	}
+	for _, tc := range cases {
+		if got := ShouldGenerateGRPCRoute(tc.protocol, tc.containerPorts, tc.grpcPort); got != tc.want {
+			t.Fatalf("%s: ShouldGenerateGRPCRoute() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// HTTPBackendConfig describes the HTTP(S) backend for a predictor,
+// transformer, or explainer component. It mirrors GRPCBackendConfig so the
+// two builders can share the same caller-supplied naming/hostname/parentRef
+// inputs when a component needs both routes.
+type HTTPBackendConfig struct {
+	Name          string
+	Namespace     string
+	Hostnames     []gatewayapiv1.Hostname
+	ParentRefs    []gatewayapiv1.ParentReference
+	BackendName   string
+	BackendPort   int32
+	IsvcName      string // set to inject the Kserve-Isvc-Name/Namespace header filters
+	IsvcNamespace string
+	// Redirects and Rewrites mirror InferenceService.Spec.Redirects/Rewrites;
+	// their rules are emitted ahead of the catch-all predict rule so a
+	// redirect or rewrite always takes precedence over the default route.
+	Redirects []PathRedirect
+	Rewrites  []PathRewrite
+	// Mirror, when set, adds a RequestMirror filter to the catch-all predict
+	// rule so a copy of production traffic is shadowed to a canary backend.
+	Mirror *RequestMirror
+}
+
+// RequestMirror is one entry of InferenceService.Spec.Routing.Mirror: a
+// fraction of requests forwarded to the primary backend are also sent to
+// BackendName, with responses discarded, for offline canary evaluation.
+type RequestMirror struct {
+	BackendName string
+	BackendPort int32
+	// PercentInt mirrors gatewayapiv1.HTTPRequestMirrorFilter's Fraction:
+	// nil means mirror every request, matching the Gateway API default.
+	PercentInt *int32
+}
+
+// BuildMirrorFilter renders a RequestMirror as an HTTPRouteFilterRequestMirror
+// filter targeting mirror.BackendName.
+func BuildMirrorFilter(mirror RequestMirror) gatewayapiv1.HTTPRouteFilter {
+	filter := &gatewayapiv1.HTTPRequestMirrorFilter{
+		BackendRef: gatewayapiv1.BackendObjectReference{
+			Name: gatewayapiv1.ObjectName(mirror.BackendName),
+			Port: portPtr(mirror.BackendPort),
+		},
+	}
+	if mirror.PercentInt != nil {
+		filter.Fraction = &gatewayapiv1.Fraction{Numerator: *mirror.PercentInt, Denominator: ptr.To(int32(100))}
+	}
+	return gatewayapiv1.HTTPRouteFilter{
+		Type:          gatewayapiv1.HTTPRouteFilterRequestMirror,
+		RequestMirror: filter,
+	}
+}
+
+// BuildHTTPRoute generates an HTTPRoute with one rule per cfg.Redirects
+// entry, then one per cfg.Rewrites entry, then a catch-all rule that
+// forwards all remaining traffic to the component's backend Service.
+func BuildHTTPRoute(cfg HTTPBackendConfig) *gatewayapiv1.HTTPRoute {
+	var filters []gatewayapiv1.HTTPRouteFilter
+	if cfg.IsvcName != "" {
+		filters = append(filters, gatewayapiv1.HTTPRouteFilter{
+			Type:                  gatewayapiv1.HTTPRouteFilterRequestHeaderModifier,
+			RequestHeaderModifier: isvcHeaderModifier(cfg.IsvcName, cfg.IsvcNamespace),
+		})
+	}
+	if cfg.Mirror != nil {
+		filters = append(filters, BuildMirrorFilter(*cfg.Mirror))
+	}
+
+	rules := make([]gatewayapiv1.HTTPRouteRule, 0, len(cfg.Redirects)+len(cfg.Rewrites)+1)
+	for _, redirect := range cfg.Redirects {
+		rules = append(rules, BuildRedirectRule(redirect))
+	}
+	for _, rewrite := range cfg.Rewrites {
+		rules = append(rules, BuildRewriteRule(rewrite, cfg.BackendName, cfg.BackendPort))
+	}
+	rules = append(rules, gatewayapiv1.HTTPRouteRule{
+		Filters: filters,
+		BackendRefs: []gatewayapiv1.HTTPBackendRef{
+			{
+				BackendRef: gatewayapiv1.BackendRef{
+					BackendObjectReference: gatewayapiv1.BackendObjectReference{
+						Name: gatewayapiv1.ObjectName(cfg.BackendName),
+						Port: portPtr(cfg.BackendPort),
+					},
+				},
+			},
+		},
+	})
+
+	return &gatewayapiv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfg.Name,
+			Namespace: cfg.Namespace,
+		},
+		Spec: gatewayapiv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayapiv1.CommonRouteSpec{
+				ParentRefs: cfg.ParentRefs,
+			},
+			Hostnames: cfg.Hostnames,
+			Rules:     rules,
+		},
+	}
+}
+
+// PathRedirect is a single entry of InferenceService.Spec.Redirects: requests
+// matching the From path prefix get an HTTPRouteFilterRequestRedirect
+// response instead of being forwarded, e.g. for OIP migration
+// ("/v1/models/foo:predict" -> "/v2/models/foo/infer").
+type PathRedirect struct {
+	From       string
+	To         string
+	StatusCode int
+}
+
+// PathRewrite is a single entry of InferenceService.Spec.Rewrites: requests
+// matching the PathPrefix are forwarded with their path rewritten to
+// ReplacePrefix, e.g. exposing a stable "/predict" path independent of the
+// runtime's native protocol path.
+type PathRewrite struct {
+	PathPrefix    string
+	ReplacePrefix string
+}
+
+// BuildRedirectFilter renders one PathRedirect as an
+// HTTPRouteFilterRequestRedirect rule, defaulting StatusCode to 302 like
+// RFC 7231 when unset.
+func BuildRedirectFilter(redirect PathRedirect) gatewayapiv1.HTTPRouteFilter {
+	statusCode := redirect.StatusCode
+	if statusCode == 0 {
+		statusCode = 302
+	}
+	return gatewayapiv1.HTTPRouteFilter{
+		Type: gatewayapiv1.HTTPRouteFilterRequestRedirect,
+		RequestRedirect: &gatewayapiv1.HTTPRequestRedirectFilter{
+			Path: &gatewayapiv1.HTTPPathModifier{
+				Type:            gatewayapiv1.FullPathHTTPPathModifier,
+				ReplaceFullPath: ptr.To(redirect.To),
+			},
+			StatusCode: ptr.To(statusCode),
+		},
+	}
+}
+
+// BuildRewriteFilter renders one PathRewrite as an HTTPRouteFilterURLRewrite
+// rule using ReplacePrefixMatch, so only the matched prefix is replaced and
+// the remainder of the request path is preserved.
+func BuildRewriteFilter(rewrite PathRewrite) gatewayapiv1.HTTPRouteFilter {
+	return gatewayapiv1.HTTPRouteFilter{
+		Type: gatewayapiv1.HTTPRouteFilterURLRewrite,
+		URLRewrite: &gatewayapiv1.HTTPURLRewriteFilter{
+			Path: &gatewayapiv1.HTTPPathModifier{
+				Type:               gatewayapiv1.PrefixMatchHTTPPathModifier,
+				ReplacePrefixMatch: ptr.To(rewrite.ReplacePrefix),
+			},
+		},
+	}
+}
+
+// BuildRedirectRule generates a standalone HTTPRouteRule matching redirect.From
+// as a path prefix and applying BuildRedirectFilter, for callers that add it
+// ahead of the catch-all predict rule so redirects take precedence.
+func BuildRedirectRule(redirect PathRedirect) gatewayapiv1.HTTPRouteRule {
+	return gatewayapiv1.HTTPRouteRule{
+		Matches: []gatewayapiv1.HTTPRouteMatch{
+			{Path: &gatewayapiv1.HTTPPathMatch{Type: ptr.To(gatewayapiv1.PathMatchPathPrefix), Value: ptr.To(redirect.From)}},
+		},
+		Filters: []gatewayapiv1.HTTPRouteFilter{BuildRedirectFilter(redirect)},
+	}
+}
+
+// BuildRewriteRule generates a standalone HTTPRouteRule matching
+// rewrite.PathPrefix, applying BuildRewriteFilter ahead of forwarding to
+// backendRef, so the same rule both rewrites the path and routes the request.
+func BuildRewriteRule(rewrite PathRewrite, backendName string, backendPort int32) gatewayapiv1.HTTPRouteRule {
+	return gatewayapiv1.HTTPRouteRule{
+		Matches: []gatewayapiv1.HTTPRouteMatch{
+			{Path: &gatewayapiv1.HTTPPathMatch{Type: ptr.To(gatewayapiv1.PathMatchPathPrefix), Value: ptr.To(rewrite.PathPrefix)}},
+		},
+		Filters: []gatewayapiv1.HTTPRouteFilter{BuildRewriteFilter(rewrite)},
+		BackendRefs: []gatewayapiv1.HTTPBackendRef{
+			{
+				BackendRef: gatewayapiv1.BackendRef{
+					BackendObjectReference: gatewayapiv1.BackendObjectReference{
+						Name: gatewayapiv1.ObjectName(backendName),
+						Port: portPtr(backendPort),
+					},
+				},
+			},
+		},
+	}
+}
+
+// BuildComponentRoutes returns the HTTPRoute and/or GRPCRoute for a
+// component, picking between them the same way the Gateway API GRPC
+// conformance profile expects: a gRPC-only predictor (no HTTP container
+// port) gets only a GRPCRoute, an HTTP-only predictor gets only an
+// HTTPRoute, and a predictor exposing both ports gets both routes bound to
+// the same parent gateway, since gRPC-native predictors still need the
+// HTTPRoute for HTTP/1.1 endpoints like health checks and metrics.
+func BuildComponentRoutes(httpCfg HTTPBackendConfig, grpcCfg GRPCBackendConfig, protocol string, hasHTTPPort bool, containerPorts []int32, grpcPort int32) (*gatewayapiv1.HTTPRoute, *gatewayapiv1.GRPCRoute) {
+	// grpcPort == 0 means no grpc/h2c container port was ever detected, so
+	// even a predictor with an explicit gRPC Protocol has nothing valid to
+	// route to yet (e.g. the container spec hasn't been reconciled).
+	needGRPC := grpcPort != 0 && ShouldGenerateGRPCRoute(protocol, containerPorts, grpcPort)
+
+	var httpRoute *gatewayapiv1.HTTPRoute
+	if hasHTTPPort {
+		httpRoute = BuildHTTPRoute(httpCfg)
+	}
+
+	var grpcRoute *gatewayapiv1.GRPCRoute
+	if needGRPC {
+		grpcRoute = BuildGRPCRoute(grpcCfg)
+	}
+	return httpRoute, grpcRoute
+}
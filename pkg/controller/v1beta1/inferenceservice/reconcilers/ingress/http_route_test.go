@@ -0,0 +1,158 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"testing"
+
+	"k8s.io/utils/ptr"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestBuildMirrorFilterDefaultFraction(t *testing.T) {
+	filter := BuildMirrorFilter(RequestMirror{BackendName: "canary", BackendPort: 80})
+	if filter.Type != gatewayapiv1.HTTPRouteFilterRequestMirror || filter.RequestMirror == nil {
+		t.Fatalf("unexpected filter: %+v", filter)
+	}
+	if filter.RequestMirror.Fraction != nil {
+		t.Fatalf("expected no Fraction when PercentInt is unset, got %+v", filter.RequestMirror.Fraction)
+	}
+	if string(filter.RequestMirror.BackendRef.Name) != "canary" {
+		t.Fatalf("unexpected backend: %+v", filter.RequestMirror.BackendRef)
+	}
+}
+
+func TestBuildMirrorFilterExplicitFraction(t *testing.T) {
+	filter := BuildMirrorFilter(RequestMirror{BackendName: "canary", BackendPort: 80, PercentInt: ptr.To(int32(25))})
+	if filter.RequestMirror.Fraction == nil || filter.RequestMirror.Fraction.Numerator != 25 || *filter.RequestMirror.Fraction.Denominator != 100 {
+		t.Fatalf("unexpected fraction: %+v", filter.RequestMirror.Fraction)
+	}
+}
+
+func TestBuildRedirectFilterDefaultsStatusCode(t *testing.T) {
+	filter := BuildRedirectFilter(PathRedirect{From: "/v1", To: "/v2"})
+	if filter.RequestRedirect == nil || *filter.RequestRedirect.StatusCode != 302 {
+		t.Fatalf("expected a default 302 status code, got %+v", filter.RequestRedirect)
+	}
+	if *filter.RequestRedirect.Path.ReplaceFullPath != "/v2" {
+		t.Fatalf("unexpected replace path: %+v", filter.RequestRedirect.Path)
+	}
+}
+
+func TestBuildRedirectFilterExplicitStatusCode(t *testing.T) {
+	filter := BuildRedirectFilter(PathRedirect{From: "/v1", To: "/v2", StatusCode: 301})
+	if *filter.RequestRedirect.StatusCode != 301 {
+		t.Fatalf("expected the explicit status code to be preserved, got %d", *filter.RequestRedirect.StatusCode)
+	}
+}
+
+func TestBuildRewriteFilter(t *testing.T) {
+	filter := BuildRewriteFilter(PathRewrite{PathPrefix: "/predict", ReplacePrefix: "/v2/models/foo/infer"})
+	if filter.URLRewrite == nil || *filter.URLRewrite.Path.ReplacePrefixMatch != "/v2/models/foo/infer" {
+		t.Fatalf("unexpected rewrite filter: %+v", filter.URLRewrite)
+	}
+}
+
+func TestBuildRedirectRuleMatchesFromPrefix(t *testing.T) {
+	rule := BuildRedirectRule(PathRedirect{From: "/old", To: "/new"})
+	if len(rule.Matches) != 1 || *rule.Matches[0].Path.Value != "/old" {
+		t.Fatalf("unexpected matches: %+v", rule.Matches)
+	}
+	if len(rule.Filters) != 1 {
+		t.Fatalf("expected a single redirect filter, got %+v", rule.Filters)
+	}
+}
+
+func TestBuildRewriteRuleForwardsToBackend(t *testing.T) {
+	rule := BuildRewriteRule(PathRewrite{PathPrefix: "/predict", ReplacePrefix: "/infer"}, "backend-svc", 80)
+	if len(rule.BackendRefs) != 1 || string(rule.BackendRefs[0].Name) != "backend-svc" {
+		t.Fatalf("unexpected backend refs: %+v", rule.BackendRefs)
+	}
+	if int32(*rule.BackendRefs[0].Port) != 80 {
+		t.Fatalf("unexpected backend port: %v", rule.BackendRefs[0].Port)
+	}
+}
+
+func TestBuildHTTPRouteOrdersRedirectsRewritesThenCatchAll(t *testing.T) {
+	route := BuildHTTPRoute(HTTPBackendConfig{
+		Name:        "isvc-predictor",
+		Namespace:   "ns",
+		BackendName: "isvc-predictor",
+		BackendPort: 80,
+		Redirects:   []PathRedirect{{From: "/old", To: "/new"}},
+		Rewrites:    []PathRewrite{{PathPrefix: "/predict", ReplacePrefix: "/infer"}},
+	})
+	if len(route.Spec.Rules) != 3 {
+		t.Fatalf("expected redirect + rewrite + catch-all rules, got %+v", route.Spec.Rules)
+	}
+	if *route.Spec.Rules[0].Matches[0].Path.Value != "/old" {
+		t.Fatalf("expected the redirect rule first, got %+v", route.Spec.Rules[0])
+	}
+	if *route.Spec.Rules[1].Matches[0].Path.Value != "/predict" {
+		t.Fatalf("expected the rewrite rule second, got %+v", route.Spec.Rules[1])
+	}
+	catchAll := route.Spec.Rules[2]
+	if len(catchAll.Matches) != 0 || len(catchAll.BackendRefs) != 1 {
+		t.Fatalf("expected an unconditional catch-all rule last, got %+v", catchAll)
+	}
+}
+
+func TestBuildHTTPRouteInjectsIsvcHeadersAndMirror(t *testing.T) {
+	route := BuildHTTPRoute(HTTPBackendConfig{
+		Name:          "isvc-predictor",
+		Namespace:     "ns",
+		BackendName:   "isvc-predictor",
+		BackendPort:   80,
+		IsvcName:      "isvc",
+		IsvcNamespace: "ns",
+		Mirror:        &RequestMirror{BackendName: "canary", BackendPort: 80},
+	})
+	catchAll := route.Spec.Rules[0]
+	if len(catchAll.Filters) != 2 {
+		t.Fatalf("expected a header-modifier filter and a mirror filter, got %+v", catchAll.Filters)
+	}
+	if catchAll.Filters[0].Type != gatewayapiv1.HTTPRouteFilterRequestHeaderModifier {
+		t.Fatalf("expected the header modifier first, got %+v", catchAll.Filters[0])
+	}
+	if catchAll.Filters[1].Type != gatewayapiv1.HTTPRouteFilterRequestMirror {
+		t.Fatalf("expected the mirror filter second, got %+v", catchAll.Filters[1])
+	}
+}
+
+func TestBuildComponentRoutesHTTPOnly(t *testing.T) {
+	httpRoute, grpcRoute := BuildComponentRoutes(HTTPBackendConfig{Name: "r", BackendName: "b", BackendPort: 80}, GRPCBackendConfig{}, "http", true, nil, 0)
+	if httpRoute == nil || grpcRoute != nil {
+		t.Fatalf("expected only an HTTPRoute, got http=%v grpc=%v", httpRoute, grpcRoute)
+	}
+}
+
+func TestBuildComponentRoutesGRPCOnly(t *testing.T) {
+	httpRoute, grpcRoute := BuildComponentRoutes(HTTPBackendConfig{}, GRPCBackendConfig{Name: "r", BackendName: "b", BackendPort: 81}, "grpc-v2", false, []int32{81}, 81)
+	if grpcRoute == nil || httpRoute != nil {
+		t.Fatalf("expected only a GRPCRoute, got http=%v grpc=%v", httpRoute, grpcRoute)
+	}
+}
+
+func TestBuildComponentRoutesNoGRPCPortDetected(t *testing.T) {
+	httpRoute, grpcRoute := BuildComponentRoutes(HTTPBackendConfig{Name: "r", BackendName: "b", BackendPort: 80}, GRPCBackendConfig{}, "grpc-v2", true, nil, 0)
+	if httpRoute == nil {
+		t.Fatalf("expected an HTTPRoute")
+	}
+	if grpcRoute != nil {
+		t.Fatalf("expected no GRPCRoute when grpcPort is 0 even with a gRPC protocol, got %+v", grpcRoute)
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// RouteDriftRevertedEventReason is the Kubernetes Event reason emitted when
+// HasDrift finds an out-of-band edit to a generated HTTPRoute and the
+// caller reverts it, so `kubectl describe httproute` shows why the object
+// changed without the caller having made the edit itself.
+const RouteDriftRevertedEventReason = "RouteDriftReverted"
+
+// Canonicalize returns a deep copy of route with every order-insensitive
+// field sorted into a deterministic order: Hostnames, each rule's
+// BackendRefs, each rule's RequestHeaderModifier.Set headers, and the Rules
+// themselves. This lets HasDrift compare an existing HTTPRoute against the
+// freshly-built desired one without false positives from Gateway API
+// controllers (or kubectl edits) that preserve semantics but reorder slices.
+func Canonicalize(route *gatewayapiv1.HTTPRoute) *gatewayapiv1.HTTPRoute {
+	canon := route.DeepCopy()
+
+	hostnames := make([]string, len(canon.Spec.Hostnames))
+	for i, h := range canon.Spec.Hostnames {
+		hostnames[i] = string(h)
+	}
+	sort.Strings(hostnames)
+	canon.Spec.Hostnames = make([]gatewayapiv1.Hostname, len(hostnames))
+	for i, h := range hostnames {
+		canon.Spec.Hostnames[i] = gatewayapiv1.Hostname(h)
+	}
+
+	for i := range canon.Spec.Rules {
+		canonicalizeRule(&canon.Spec.Rules[i])
+	}
+	sort.SliceStable(canon.Spec.Rules, func(i, j int) bool {
+		return ruleSortKey(canon.Spec.Rules[i]) < ruleSortKey(canon.Spec.Rules[j])
+	})
+
+	return canon
+}
+
+func canonicalizeRule(rule *gatewayapiv1.HTTPRouteRule) {
+	sort.SliceStable(rule.BackendRefs, func(i, j int) bool {
+		return string(rule.BackendRefs[i].Name) < string(rule.BackendRefs[j].Name)
+	})
+	for i := range rule.Filters {
+		if modifier := rule.Filters[i].RequestHeaderModifier; modifier != nil {
+			sort.SliceStable(modifier.Set, func(a, b int) bool {
+				return modifier.Set[a].Name < modifier.Set[b].Name
+			})
+		}
+	}
+}
+
+// ruleSortKey renders a rule's matches and backends as a single comparable
+// string, good enough to give SortStable a stable total order without
+// depending on field values that vary between environments (ports, creation
+// timestamps aren't part of HTTPRouteRule).
+func ruleSortKey(rule gatewayapiv1.HTTPRouteRule) string {
+	key := ""
+	for _, match := range rule.Matches {
+		if match.Path != nil && match.Path.Value != nil {
+			key += "path=" + *match.Path.Value + ";"
+		}
+		if match.Method != nil {
+			key += "method=" + string(*match.Method) + ";"
+		}
+	}
+	for _, backend := range rule.BackendRefs {
+		key += "backend=" + string(backend.Name) + ";"
+	}
+	return key
+}
+
+// HasDrift reports whether desired's canonical form differs from existing's,
+// ignoring order-only differences in Hostnames/Rules/BackendRefs/header sets.
+func HasDrift(existing, desired *gatewayapiv1.HTTPRoute) bool {
+	return !equality.Semantic.DeepEqual(Canonicalize(existing).Spec, Canonicalize(desired).Spec)
+}
+
+// BuildDriftSummary renders a compact, human-readable summary of what changed
+// between existing and desired, suitable as the Kubernetes Event message for
+// RouteDriftRevertedEventReason.
+func BuildDriftSummary(existing, desired *gatewayapiv1.HTTPRoute) string {
+	existingHostnames := Canonicalize(existing).Spec.Hostnames
+	desiredHostnames := Canonicalize(desired).Spec.Hostnames
+	existingRuleCount := len(existing.Spec.Rules)
+	desiredRuleCount := len(desired.Spec.Rules)
+	return fmt.Sprintf("hostnames %v -> %v, rules %d -> %d", existingHostnames, desiredHostnames, existingRuleCount, desiredRuleCount)
+}
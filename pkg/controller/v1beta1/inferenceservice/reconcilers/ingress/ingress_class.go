@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// IngressClassAnnotationKey lets a single InferenceService override the
+// cluster-wide ingress config's class for raw deployments. Any value other
+// than IngressClassGateway keeps the existing netv1.Ingress output.
+const IngressClassAnnotationKey = "serving.kserve.io/ingress-class"
+
+// IngressClassGateway selects the Gateway API HTTPRoute output path in place
+// of a netv1.Ingress for a raw-deployment component.
+const IngressClassGateway = "gateway"
+
+// ShouldUseGatewayIngress reports whether a raw-deployment component should
+// be exposed via HTTPRoute instead of netv1.Ingress: either the
+// InferenceService's own IngressClassAnnotationKey says so, or (absent a
+// per-isvc override) the cluster-wide ingress config's configuredClass does.
+// This mirrors BuildComponentRoutes' "both independently available, not
+// mutually exclusive defaults" approach: once selected, the reconciler owns
+// switching between the two and must garbage-collect whichever kind lost.
+func ShouldUseGatewayIngress(annotations map[string]string, configuredClass string) bool {
+	if class, ok := annotations[IngressClassAnnotationKey]; ok {
+		return class == IngressClassGateway
+	}
+	return configuredClass == IngressClassGateway
+}
+
+// AddressFromGatewayStatus returns the first address reported on a Gateway's
+// status, so InferenceService.Status.URL/Address can be derived from it in
+// Gateway mode the same way they're derived from netv1.Ingress.Status in
+// Ingress mode. Returns false if the Gateway hasn't reported any address yet.
+func AddressFromGatewayStatus(addresses []gatewayapiv1.GatewayStatusAddress) (string, bool) {
+	if len(addresses) == 0 {
+		return "", false
+	}
+	return addresses[0].Value, true
+}
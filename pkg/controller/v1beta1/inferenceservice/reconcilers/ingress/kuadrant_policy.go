@@ -0,0 +1,242 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+// Kuadrant's AuthPolicy/RateLimitPolicy CRDs are not vendored as typed Go
+// clients in this repo, so policies are built as unstructured objects (the
+// same approach the controller already uses for other optional CRDs that may
+// not be installed in every cluster) and only applied when the CRD is present.
+
+// +kubebuilder:rbac:groups=kuadrant.io,resources=authpolicies;ratelimitpolicies;dnspolicies,verbs=get;list;watch;create;update;patch;delete
+
+const (
+	kuadrantAPIVersion    = "kuadrant.io/v1beta2"
+	kuadrantDNSAPIVersion = "kuadrant.io/v1alpha1"
+	authPolicyKind        = "AuthPolicy"
+	rateLimitPolicyKind   = "RateLimitPolicy"
+	dnsPolicyKind         = "DNSPolicy"
+	gatewayAPIGroup       = "gateway.networking.k8s.io"
+	httpRouteKind         = "HTTPRoute"
+)
+
+// DNSRoutingStrategy selects how a Kuadrant DNSPolicy load-balances a
+// hostname across multiple gateway clusters.
+type DNSRoutingStrategy string
+
+const (
+	DNSRoutingStrategySimple             DNSRoutingStrategy = "simple"
+	DNSRoutingStrategyLoadBalancedGeo    DNSRoutingStrategy = "loadbalanced-geo"
+	DNSRoutingStrategyLoadBalancedWeight DNSRoutingStrategy = "loadbalanced-weighted"
+)
+
+// KuadrantCRDGroupVersionKinds lists the GVKs the reconciler probes for
+// before attempting to create any Kuadrant policy, so clusters without the
+// Kuadrant operator installed are skipped rather than erroring.
+var KuadrantCRDGroupVersionKinds = []schema.GroupVersionKind{
+	{Group: "kuadrant.io", Version: "v1beta2", Kind: authPolicyKind},
+	{Group: "kuadrant.io", Version: "v1beta2", Kind: rateLimitPolicyKind},
+	{Group: "kuadrant.io", Version: "v1alpha1", Kind: dnsPolicyKind},
+}
+
+// IsKuadrantCRDInstalled reports whether the given Kuadrant CRD is registered
+// with the cluster's RESTMapper, so the caller can skip Kuadrant policy
+// reconciliation gracefully instead of failing the whole InferenceService
+// reconcile when the Kuadrant operator isn't installed.
+func IsKuadrantCRDInstalled(c client.Client, gvk schema.GroupVersionKind) bool {
+	_, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	return !meta.IsNoMatchError(err)
+}
+
+// AuthPolicyConfig configures authentication requirements to attach to the
+// generated HTTPRoute via a Kuadrant AuthPolicy.
+type AuthPolicyConfig struct {
+	RouteName      string
+	Namespace      string
+	RequireJWT     bool
+	JWTIssuerURL   string
+	JWTAudiences   []string
+	AllowAnonymous bool
+	// AuthConfigRefName, when set, references an existing Kuadrant AuthConfig
+	// by name instead of (or in addition to) the inline JWT rule above.
+	AuthConfigRefName string
+}
+
+// BuildAuthPolicy constructs the unstructured Kuadrant AuthPolicy targeting the
+// HTTPRoute generated for the InferenceService.
+func BuildAuthPolicy(cfg AuthPolicyConfig) *unstructured.Unstructured {
+	rules := map[string]interface{}{}
+	if cfg.RequireJWT {
+		rules["authentication"] = map[string]interface{}{
+			"jwt": map[string]interface{}{
+				"jwt": map[string]interface{}{
+					"issuerUrl": cfg.JWTIssuerURL,
+					"audiences": toInterfaceSlice(cfg.JWTAudiences),
+				},
+			},
+		}
+	}
+
+	if cfg.AuthConfigRefName != "" {
+		rules["authorization"] = map[string]interface{}{
+			"authConfigRef": map[string]interface{}{
+				"name": cfg.AuthConfigRefName,
+			},
+		}
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(kuadrantAPIVersion)
+	obj.SetKind(authPolicyKind)
+	obj.SetNamespace(cfg.Namespace)
+	obj.SetName(cfg.RouteName + "-auth")
+	_ = unstructured.SetNestedMap(obj.Object, targetRef(cfg.RouteName), "spec", "targetRef")
+	if len(rules) > 0 {
+		_ = unstructured.SetNestedMap(obj.Object, rules, "spec", "rules")
+	}
+	return obj
+}
+
+// RateLimitPolicyConfig configures request-rate limiting to attach to the
+// generated HTTPRoute via a Kuadrant RateLimitPolicy.
+type RateLimitPolicyConfig struct {
+	RouteName       string
+	Namespace       string
+	RequestsPerUnit int64
+	Unit            string // "second", "minute", "hour", "day"
+	// HeaderDimension, when set, scopes the limit per distinct value of this
+	// request header (e.g. "x-user-id") instead of applying it globally to
+	// all traffic through the route.
+	HeaderDimension string
+}
+
+// BuildRateLimitPolicy constructs the unstructured Kuadrant RateLimitPolicy
+// targeting the HTTPRoute generated for the InferenceService.
+func BuildRateLimitPolicy(cfg RateLimitPolicyConfig) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(kuadrantAPIVersion)
+	obj.SetKind(rateLimitPolicyKind)
+	obj.SetNamespace(cfg.Namespace)
+	obj.SetName(cfg.RouteName + "-ratelimit")
+	_ = unstructured.SetNestedMap(obj.Object, targetRef(cfg.RouteName), "spec", "targetRef")
+
+	limit := map[string]interface{}{
+		"rates": []interface{}{
+			map[string]interface{}{
+				"limit":  cfg.RequestsPerUnit,
+				"window": rateWindow(cfg.Unit),
+			},
+		},
+	}
+	if cfg.HeaderDimension != "" {
+		limit["counters"] = []interface{}{fmt.Sprintf("request.headers.%s", cfg.HeaderDimension)}
+	}
+	_ = unstructured.SetNestedMap(obj.Object, map[string]interface{}{"default": limit}, "spec", "limits")
+	return obj
+}
+
+// DNSPolicyConfig configures DNS load-balancing to attach to the generated
+// HTTPRoute via a Kuadrant DNSPolicy.
+type DNSPolicyConfig struct {
+	RouteName string
+	Namespace string
+	Strategy  DNSRoutingStrategy
+}
+
+// BuildDNSPolicy constructs the unstructured Kuadrant DNSPolicy targeting the
+// HTTPRoute generated for the InferenceService.
+func BuildDNSPolicy(cfg DNSPolicyConfig) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(kuadrantDNSAPIVersion)
+	obj.SetKind(dnsPolicyKind)
+	obj.SetNamespace(cfg.Namespace)
+	obj.SetName(cfg.RouteName + "-dns")
+	_ = unstructured.SetNestedMap(obj.Object, targetRef(cfg.RouteName), "spec", "targetRef")
+	strategy := cfg.Strategy
+	if strategy == "" {
+		strategy = DNSRoutingStrategySimple
+	}
+	_ = unstructured.SetNestedField(obj.Object, string(strategy), "spec", "routingStrategy")
+	return obj
+}
+
+// BuildPolicyAttachmentsForIngressPolicy translates an IngressPolicy's
+// AuthPolicyName/RateLimitPolicyName references into the corresponding
+// Kuadrant policy objects targeting routeName, so a component's retry/timeout
+// configuration and its auth/rate-limit configuration can be declared
+// together on ComponentExtensionSpec.IngressPolicy instead of requiring a
+// separate AuthPolicy/RateLimitPolicy config struct per call site. Returns an
+// empty slice when policy is nil or references neither.
+func BuildPolicyAttachmentsForIngressPolicy(policy *v1beta1.IngressPolicy, routeName, namespace string) []*unstructured.Unstructured {
+	if policy == nil {
+		return nil
+	}
+	var attachments []*unstructured.Unstructured
+	if policy.AuthPolicyName != "" {
+		attachments = append(attachments, BuildAuthPolicy(AuthPolicyConfig{
+			RouteName:         routeName,
+			Namespace:         namespace,
+			AuthConfigRefName: policy.AuthPolicyName,
+		}))
+	}
+	if policy.RateLimitPolicyName != "" {
+		attachments = append(attachments, BuildRateLimitPolicy(RateLimitPolicyConfig{
+			RouteName: routeName,
+			Namespace: namespace,
+		}))
+	}
+	return attachments
+}
+
+func targetRef(routeName string) map[string]interface{} {
+	return map[string]interface{}{
+		"group": gatewayAPIGroup,
+		"kind":  httpRouteKind,
+		"name":  routeName,
+	}
+}
+
+func rateWindow(unit string) string {
+	switch unit {
+	case "minute":
+		return "60s"
+	case "hour":
+		return "3600s"
+	case "day":
+		return "86400s"
+	default:
+		return "1s"
+	}
+}
+
+func toInterfaceSlice(in []string) []interface{} {
+	out := make([]interface{}, len(in))
+	for i, v := range in {
+		out[i] = v
+	}
+	return out
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+func TestBuildAuthPolicyWithJWTRequirement(t *testing.T) {
+	obj := BuildAuthPolicy(AuthPolicyConfig{
+		RouteName:    "my-route",
+		Namespace:    "ns",
+		RequireJWT:   true,
+		JWTIssuerURL: "https://issuer.example.com",
+		JWTAudiences: []string{"kserve"},
+	})
+	if obj.GetKind() != authPolicyKind || obj.GetAPIVersion() != kuadrantAPIVersion {
+		t.Fatalf("unexpected type meta: %s/%s", obj.GetAPIVersion(), obj.GetKind())
+	}
+	if obj.GetName() != "my-route-auth" || obj.GetNamespace() != "ns" {
+		t.Fatalf("unexpected object metadata: %s/%s", obj.GetNamespace(), obj.GetName())
+	}
+	targetRef, found, err := unstructured.NestedMap(obj.Object, "spec", "targetRef")
+	if err != nil || !found || targetRef["name"] != "my-route" {
+		t.Fatalf("unexpected targetRef: %+v, found=%v, err=%v", targetRef, found, err)
+	}
+	issuerURL, found, err := unstructured.NestedString(obj.Object, "spec", "rules", "authentication", "jwt", "jwt", "issuerUrl")
+	if err != nil || !found || issuerURL != "https://issuer.example.com" {
+		t.Fatalf("unexpected issuerUrl: %q, found=%v, err=%v", issuerURL, found, err)
+	}
+}
+
+func TestBuildAuthPolicyWithAuthConfigRef(t *testing.T) {
+	obj := BuildAuthPolicy(AuthPolicyConfig{RouteName: "my-route", Namespace: "ns", AuthConfigRefName: "existing-config"})
+	name, found, err := unstructured.NestedString(obj.Object, "spec", "rules", "authorization", "authConfigRef", "name")
+	if err != nil || !found || name != "existing-config" {
+		t.Fatalf("unexpected authConfigRef name: %q, found=%v, err=%v", name, found, err)
+	}
+}
+
+func TestBuildAuthPolicyNoRulesWhenNothingRequested(t *testing.T) {
+	obj := BuildAuthPolicy(AuthPolicyConfig{RouteName: "my-route", Namespace: "ns"})
+	if _, found, _ := unstructured.NestedMap(obj.Object, "spec", "rules"); found {
+		t.Fatalf("expected no rules to be set when neither JWT nor an AuthConfig ref is requested")
+	}
+}
+
+func TestBuildRateLimitPolicy(t *testing.T) {
+	obj := BuildRateLimitPolicy(RateLimitPolicyConfig{RouteName: "my-route", Namespace: "ns", RequestsPerUnit: 100, Unit: "minute", HeaderDimension: "x-user-id"})
+	if obj.GetKind() != rateLimitPolicyKind || obj.GetName() != "my-route-ratelimit" {
+		t.Fatalf("unexpected object metadata: %s/%s", obj.GetKind(), obj.GetName())
+	}
+	limit, found, err := unstructured.NestedMap(obj.Object, "spec", "limits", "default")
+	if err != nil || !found {
+		t.Fatalf("expected a default limit, found=%v, err=%v", found, err)
+	}
+	rates, ok := limit["rates"].([]interface{})
+	if !ok || len(rates) != 1 {
+		t.Fatalf("expected a single rate entry, got %+v", limit["rates"])
+	}
+	rate := rates[0].(map[string]interface{})
+	if rate["limit"] != int64(100) || rate["window"] != "60s" {
+		t.Fatalf("unexpected rate: %+v", rate)
+	}
+	counters, ok := limit["counters"].([]interface{})
+	if !ok || len(counters) != 1 || counters[0] != "request.headers.x-user-id" {
+		t.Fatalf("unexpected counters: %+v", limit["counters"])
+	}
+}
+
+func TestBuildRateLimitPolicyNoHeaderDimension(t *testing.T) {
+	obj := BuildRateLimitPolicy(RateLimitPolicyConfig{RouteName: "my-route", Namespace: "ns", RequestsPerUnit: 10, Unit: "second"})
+	limit, _, _ := unstructured.NestedMap(obj.Object, "spec", "limits", "default")
+	if _, ok := limit["counters"]; ok {
+		t.Fatalf("expected no counters when HeaderDimension is unset, got %+v", limit)
+	}
+}
+
+func TestRateWindow(t *testing.T) {
+	cases := map[string]string{"minute": "60s", "hour": "3600s", "day": "86400s", "second": "1s", "": "1s", "unknown": "1s"}
+	for unit, want := range cases {
+		if got := rateWindow(unit); got != want {
+			t.Errorf("rateWindow(%q) = %q, want %q", unit, got, want)
+		}
+	}
+}
+
+func TestBuildDNSPolicyDefaultsToSimpleStrategy(t *testing.T) {
+	obj := BuildDNSPolicy(DNSPolicyConfig{RouteName: "my-route", Namespace: "ns"})
+	if obj.GetKind() != dnsPolicyKind || obj.GetName() != "my-route-dns" {
+		t.Fatalf("unexpected object metadata: %s/%s", obj.GetKind(), obj.GetName())
+	}
+	strategy, found, err := unstructured.NestedString(obj.Object, "spec", "routingStrategy")
+	if err != nil || !found || strategy != string(DNSRoutingStrategySimple) {
+		t.Fatalf("unexpected routingStrategy: %q, found=%v, err=%v", strategy, found, err)
+	}
+}
+
+func TestBuildDNSPolicyHonorsExplicitStrategy(t *testing.T) {
+	obj := BuildDNSPolicy(DNSPolicyConfig{RouteName: "my-route", Namespace: "ns", Strategy: DNSRoutingStrategyLoadBalancedGeo})
+	strategy, _, _ := unstructured.NestedString(obj.Object, "spec", "routingStrategy")
+	if strategy != string(DNSRoutingStrategyLoadBalancedGeo) {
+		t.Fatalf("unexpected routingStrategy: %q", strategy)
+	}
+}
+
+func TestBuildPolicyAttachmentsForIngressPolicyNilPolicy(t *testing.T) {
+	if attachments := BuildPolicyAttachmentsForIngressPolicy(nil, "my-route", "ns"); attachments != nil {
+		t.Fatalf("expected nil attachments for a nil policy, got %+v", attachments)
+	}
+}
+
+func TestBuildPolicyAttachmentsForIngressPolicyBothReferenced(t *testing.T) {
+	policy := &v1beta1.IngressPolicy{AuthPolicyName: "auth-config", RateLimitPolicyName: "rate-config"}
+	attachments := BuildPolicyAttachmentsForIngressPolicy(policy, "my-route", "ns")
+	if len(attachments) != 2 {
+		t.Fatalf("expected one attachment per referenced policy, got %d", len(attachments))
+	}
+	if attachments[0].GetKind() != authPolicyKind || attachments[1].GetKind() != rateLimitPolicyKind {
+		t.Fatalf("unexpected attachment order/kinds: %s, %s", attachments[0].GetKind(), attachments[1].GetKind())
+	}
+}
+
+func TestBuildPolicyAttachmentsForIngressPolicyNeitherReferenced(t *testing.T) {
+	attachments := BuildPolicyAttachmentsForIngressPolicy(&v1beta1.IngressPolicy{}, "my-route", "ns")
+	if len(attachments) != 0 {
+		t.Fatalf("expected no attachments when neither policy name is set, got %+v", attachments)
+	}
+}
+
+func TestToInterfaceSlice(t *testing.T) {
+	out := toInterfaceSlice([]string{"a", "b"})
+	if len(out) != 2 || out[0] != "a" || out[1] != "b" {
+		t.Fatalf("unexpected conversion: %+v", out)
+	}
+}
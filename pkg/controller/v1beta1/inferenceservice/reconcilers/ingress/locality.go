@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"sort"
+
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// LocalityMode is Spec.Predictor.Routing.Locality (and the Transformer
+// equivalent): whether the generated HTTPRoute should prefer, require, or
+// ignore same-zone backends.
+type LocalityMode string
+
+const (
+	// LocalityPreferLocal keeps every zone's HTTPBackendRef, weighted by
+	// its ready replica count. No zone is preferred over another: the
+	// caller's own zone is not an input.
+	LocalityPreferLocal LocalityMode = "PreferLocal"
+	// LocalityStrictLocal drops a zone's HTTPBackendRef once it has no ready
+	// replicas. When no zone has ready replicas, every zone is kept at equal
+	// weight.
+	LocalityStrictLocal LocalityMode = "StrictLocal"
+	// LocalityNone is the current behavior: a single HTTPBackendRef to the
+	// cluster-wide Service with no zone awareness.
+	LocalityNone LocalityMode = "None"
+)
+
+// TopologyModeAnnotationKey is the well-known Service annotation that
+// enables EndpointSlice topology-aware hints. Nothing in this package sets
+// it on a Service; RoutingEnabled only reports whether a LocalityMode is
+// zone-aware.
+const TopologyModeAnnotationKey = "service.kubernetes.io/topology-mode"
+
+// TopologyModeAuto is the value TopologyModeAnnotationKey must carry to
+// enable EndpointSlice hints.
+const TopologyModeAuto = "Auto"
+
+// ZoneHeaderName is the RequestHeaderModifier header stamped with the
+// originating zone of the backend a request was routed to, so downstream
+// components can observe placement decisions.
+const ZoneHeaderName = "Kserve-Origin-Zone"
+
+// ZoneReplicaCount is one zone-scoped headless subset Service the
+// controller creates for a component, with the ready replica count read
+// from the EndpointSlice cache for that zone.
+type ZoneReplicaCount struct {
+	Zone          string
+	BackendName   string
+	ReadyReplicas int32
+}
+
+// RoutingEnabled reports whether mode requires any zone-aware backend
+// construction at all.
+func RoutingEnabled(mode LocalityMode) bool {
+	return mode == LocalityPreferLocal || mode == LocalityStrictLocal
+}
+
+// BuildLocalityBackendRefs turns per-zone replica counts into the weighted
+// HTTPBackendRef list BuildHTTPRoute's catch-all rule should use in place of
+// a single backendRef, weighting each zone by its ready replica count so
+// Gateway API's weighted-backend load balancing approximates even request
+// distribution within a zone. Zones with zero ready replicas are dropped
+// under LocalityStrictLocal and kept with weight 0 under
+// LocalityPreferLocal, unless every zone is empty, in which case all zones
+// get equal weight rather than the route having no backends at all.
+func BuildLocalityBackendRefs(mode LocalityMode, backendPort int32, zones []ZoneReplicaCount) []gatewayapiv1.HTTPBackendRef {
+	filtered := zones
+	if mode == LocalityStrictLocal {
+		filtered = nil
+		for _, z := range zones {
+			if z.ReadyReplicas > 0 {
+				filtered = append(filtered, z)
+			}
+		}
+	}
+	if len(filtered) == 0 {
+		filtered = zones
+	}
+
+	allZero := true
+	for _, z := range filtered {
+		if z.ReadyReplicas > 0 {
+			allZero = false
+			break
+		}
+	}
+
+	refs := make([]gatewayapiv1.HTTPBackendRef, 0, len(filtered))
+	for _, z := range filtered {
+		weight := z.ReadyReplicas
+		if allZero {
+			weight = 1
+		}
+		refs = append(refs, gatewayapiv1.HTTPBackendRef{
+			BackendRef: gatewayapiv1.BackendRef{
+				BackendObjectReference: gatewayapiv1.BackendObjectReference{
+					Name: gatewayapiv1.ObjectName(z.BackendName),
+					Port: portPtr(backendPort),
+				},
+				Weight: weightPtr(weight),
+			},
+		})
+	}
+
+	sort.Slice(refs, func(i, j int) bool { return refs[i].Name < refs[j].Name })
+	return refs
+}
+
+// BuildZoneHeaderModifier returns a RequestHeaderModifier filter that sets
+// ZoneHeaderName to zone on each request the rule forwards to its backend.
+func BuildZoneHeaderModifier(zone string) gatewayapiv1.HTTPRouteFilter {
+	return gatewayapiv1.HTTPRouteFilter{
+		Type: gatewayapiv1.HTTPRouteFilterRequestHeaderModifier,
+		RequestHeaderModifier: &gatewayapiv1.HTTPHeaderFilter{
+			Set: []gatewayapiv1.HTTPHeader{
+				{Name: ZoneHeaderName, Value: zone},
+			},
+		},
+	}
+}
+
+func weightPtr(w int32) *int32 {
+	return &w
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	networkingv1beta1 "istio.io/api/networking/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// BuildDestinationRuleLocalityLB derives an Istio DestinationRule's
+// trafficPolicy.localityLbSetting from
+// constants.LocalityRoutingAnnotationKey. It returns nil for
+// LocalityRoutingDisabled (or any unrecognized value), leaving
+// trafficPolicy.localityLbSetting unset so Istio's regular (non-locality)
+// load balancing applies. Under LocalityRoutingPreferLocal, Enabled is set
+// with no Distribute/Failover overrides, which makes Istio's own
+// topology-aware locality weighting (derived from the region/zone/subzone
+// labels on each endpoint) prefer same-zone backends while still falling
+// back cross-zone. LocalityRoutingStrictLocal additionally sets
+// FailoverPriority to an empty, non-nil slice: Istio only fails over
+// cross-zone when FailoverPriority or Failover is configured, so an empty
+// FailoverPriority keeps Enabled locality weighting without ever failing
+// over, matching the "never cross a zone" semantics of StrictLocal.
+func BuildDestinationRuleLocalityLB(locality string) *networkingv1beta1.LocalityLoadBalancerSetting {
+	switch locality {
+	case constants.LocalityRoutingPreferLocal:
+		return &networkingv1beta1.LocalityLoadBalancerSetting{
+			Enabled: wrapperspb.Bool(true),
+		}
+	case constants.LocalityRoutingStrictLocal:
+		return &networkingv1beta1.LocalityLoadBalancerSetting{
+			Enabled:          wrapperspb.Bool(true),
+			FailoverPriority: []string{},
+		}
+	default:
+		return nil
+	}
+}
+
+// ApplyServiceTrafficDistribution sets svc.Spec.TrafficDistribution
+// (Kubernetes' native zone-aware routing hint, honored by kube-proxy and
+// compatible CNI/LB implementations) from the same
+// constants.LocalityRoutingAnnotationKey value used for the Istio path,
+// for raw K8s Deployments that aren't behind Istio. PreferLocal and
+// StrictLocal both map to corev1.ServiceTrafficDistributionPreferClose:
+// unlike DestinationRule, trafficDistribution has no strict/never-failover
+// mode, so on this path StrictLocal gets the same hint as PreferLocal and
+// traffic still reaches other zones when no same-zone endpoint is ready.
+// LocalityRoutingDisabled (or an unset annotation) clears any
+// previously-set value.
+func ApplyServiceTrafficDistribution(svc *corev1.Service, locality string) {
+	switch locality {
+	case constants.LocalityRoutingPreferLocal, constants.LocalityRoutingStrictLocal:
+		preferClose := corev1.ServiceTrafficDistributionPreferClose
+		svc.Spec.TrafficDistribution = &preferClose
+	default:
+		svc.Spec.TrafficDistribution = nil
+	}
+}
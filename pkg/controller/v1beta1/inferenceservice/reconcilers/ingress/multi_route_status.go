@@ -0,0 +1,227 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// ComponentRoute names one HTTPRoute generated for the InferenceService
+// (top-level, predictor, transformer, or explainer) so
+// AggregateComponentRoutes can label which route a failure came from.
+type ComponentRoute struct {
+	Component   string // "topLevel", "predictor", "transformer", "explainer"
+	ParentRefs  []gatewayapiv1.ParentReference
+	RouteStatus gatewayapiv1.RouteStatus
+}
+
+// AggregateComponentRoutes walks every ComponentRoute's parentRefs,
+// classifies each one (see ClassifyParent), and rolls all of them up into a
+// single IngressReady apis.Condition whose Reason/Message names the
+// offending component/gateway/listener combination plus the Gateway API
+// controller that reported it. IngressReady is only True when every parent
+// of every route is bound; across multiple components/routes it always
+// reflects the single least-ready parent (see WorstCondition), not just the
+// first or last one classified.
+func AggregateComponentRoutes(routes []ComponentRoute) apis.Condition {
+	var all []RouteBindResult
+	var failures []string
+	var firstReason string
+	worst := corev1.ConditionUnknown
+	for _, route := range routes {
+		classifications := ClassifyParents(route.ParentRefs, route.RouteStatus)
+		for _, c := range classifications {
+			all = append(all, RouteBindResult{ParentRef: c.ParentRef, Accepted: c.Outcome == ParentAccepted, Reason: string(c.Outcome), Message: c.Message})
+			if c.Outcome != ParentAccepted {
+				reason := componentFailureReason(route.Component, c.Outcome)
+				if firstReason == "" || (worst != corev1.ConditionFalse && !c.Outcome.IsTransient()) {
+					firstReason = reason
+				}
+				if !c.Outcome.IsTransient() {
+					worst = corev1.ConditionFalse
+				}
+				failures = append(failures, fmt.Sprintf("%s/%s/%s (controller %s): %s (%s)", route.Component, namespaceOf(c.ParentRef), c.ParentRef.Name, controllerNameOrUnknown(c.ControllerName), reason, c.Message))
+			}
+		}
+	}
+
+	condition := AggregateIngressReady(all)
+	condition.Type = IngressReadyConditionType
+	if len(failures) > 0 {
+		condition.Status = worst
+		condition.Reason = firstReason
+		condition.Message = fmt.Sprintf("%d parentRef(s) not bound: %v", len(failures), failures)
+	}
+	return condition
+}
+
+// controllerNameOrUnknown renders a parent's ControllerName for a failure
+// message, falling back to "unknown" for the ParentPending case where the
+// gateway hasn't reported status yet and so hasn't named itself.
+func controllerNameOrUnknown(controllerName string) string {
+	if controllerName == "" {
+		return "unknown"
+	}
+	return controllerName
+}
+
+// AggregateIngressReadyAcrossComponents combines each component's own
+// AggregateComponentRoutes-style condition (e.g. one per top-level,
+// predictor, transformer, explainer route already computed by a caller that
+// needs the per-component detail too) into the single IngressReady
+// condition that should land on InferenceService.Status, using
+// WorstCondition so a degraded explainer route can't be masked by an
+// otherwise-healthy predictor route.
+func AggregateIngressReadyAcrossComponents(perComponent []apis.Condition) apis.Condition {
+	worst := WorstCondition(perComponent)
+	worst.Type = IngressReadyConditionType
+	return worst
+}
+
+// PerGatewayConditionType builds a sub-condition type scoped to both the
+// component and the gateway parentRef, e.g.
+// "IngressReady.predictor.istio-system.kserve-ingress-gateway", so a failure
+// on one component's route doesn't get confused with another's against the
+// same gateway.
+func PerGatewayConditionType(component string, parentRef gatewayapiv1.ParentReference) apis.ConditionType {
+	return apis.ConditionType(fmt.Sprintf("IngressReady.%s.%s.%s", component, namespaceOf(parentRef), parentRef.Name))
+}
+
+// PerGatewayConditions returns one apis.Condition per ComponentRoute parentRef,
+// keyed by PerGatewayConditionType, for surfacing alongside the aggregated
+// IngressReady condition.
+func PerGatewayConditions(routes []ComponentRoute) []apis.Condition {
+	var out []apis.Condition
+	for _, route := range routes {
+		for _, c := range ClassifyParents(route.ParentRefs, route.RouteStatus) {
+			status := corev1.ConditionFalse
+			if c.Outcome == ParentAccepted {
+				status = corev1.ConditionTrue
+			} else if c.Outcome.IsTransient() {
+				status = corev1.ConditionUnknown
+			}
+			out = append(out, apis.Condition{
+				Type:    PerGatewayConditionType(route.Component, c.ParentRef),
+				Status:  status,
+				Reason:  string(c.Outcome),
+				Message: c.Message,
+			})
+		}
+	}
+	return out
+}
+
+// GatewayProgrammedConditionType mirrors the upstream Gateway resource's own
+// "Programmed" condition (the gateway implementation has actually wired up
+// the data plane, as opposed to merely accepting the spec) onto the
+// InferenceService, since a route can be Accepted by a Gateway that itself
+// isn't Programmed yet.
+const GatewayProgrammedConditionType apis.ConditionType = "GatewayProgrammed"
+
+// GatewayNotProgrammedReason is used on both GatewayProgrammedConditionType
+// and IngressReadyConditionType when the referenced Gateway object reports
+// Programmed=False or hasn't reported it at all.
+const GatewayNotProgrammedReason = "GatewayNotProgrammed"
+
+// ClassifyGatewayProgrammed builds the GatewayProgrammed condition from a
+// Gateway's own status conditions, looked up by the caller (the binder has
+// no client, so it only classifies whatever status the reconciler fetched).
+func ClassifyGatewayProgrammed(gatewayName string, programmed *metav1Condition) apis.Condition {
+	if programmed == nil {
+		return apis.Condition{
+			Type: GatewayProgrammedConditionType, Status: corev1.ConditionUnknown,
+			Reason: GatewayNotProgrammedReason, Message: fmt.Sprintf("gateway %s has not yet reported a Programmed condition", gatewayName),
+		}
+	}
+	status := corev1.ConditionFalse
+	if programmed.status {
+		status = corev1.ConditionTrue
+	}
+	return apis.Condition{
+		Type: GatewayProgrammedConditionType, Status: status,
+		Reason: programmed.reason, Message: programmed.message,
+	}
+}
+
+// metav1Condition is the minimal projection of a Gateway's Programmed
+// condition the classifier needs, avoiding a dependency on the Gateway API's
+// Gateway type (and its generated client) from this otherwise client-free file.
+type metav1Condition struct {
+	status  bool
+	reason  string
+	message string
+}
+
+// componentFailureReason builds the per-component reason surfaced on
+// IngressReady, e.g. "ExplainerBackendUnresolved", so operators don't have to
+// cross-reference which component's route failed from a bare ParentOutcome.
+func componentFailureReason(component string, outcome ParentOutcome) string {
+	title := component
+	if len(title) > 0 {
+		title = string(title[0]-'a'+'A') + title[1:]
+	}
+	switch outcome {
+	case BackendNotFound:
+		return title + "BackendUnresolved"
+	case RefNotPermitted:
+		return title + "RefNotPermitted"
+	case NoMatchingListener:
+		return title + "NoMatchingListener"
+	case HostnameConflict:
+		return title + "HostnameConflict"
+	default:
+		return title + "NotBound"
+	}
+}
+
+// RouteParentStatusChangedPredicate triggers a reconcile only when an
+// HTTPRoute/GRPCRoute/TLSRoute's Status.Parents actually changed, so the
+// controller re-evaluates IngressReady as soon as the gateway controller
+// updates binding status, without reconciling on unrelated spec churn.
+func RouteParentStatusChangedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldParents, oldOK := routeParents(e.ObjectOld)
+			newParents, newOK := routeParents(e.ObjectNew)
+			if !oldOK || !newOK {
+				return true
+			}
+			return !equality.Semantic.DeepEqual(oldParents, newParents)
+		},
+	}
+}
+
+func routeParents(obj interface{}) ([]gatewayapiv1.RouteParentStatus, bool) {
+	switch r := obj.(type) {
+	case *gatewayapiv1.HTTPRoute:
+		return r.Status.Parents, true
+	case *gatewayapiv1.GRPCRoute:
+		return r.Status.Parents, true
+	case *gatewayapiv1alpha2.TLSRoute:
+		return r.Status.Parents, true
+	default:
+		return nil, false
+	}
+}
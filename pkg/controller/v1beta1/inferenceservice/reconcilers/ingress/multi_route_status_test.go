@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func acceptedRouteStatus(parentRef gatewayapiv1.ParentReference, controllerName string) gatewayapiv1.RouteStatus {
+	return gatewayapiv1.RouteStatus{Parents: []gatewayapiv1.RouteParentStatus{{
+		ParentRef:      parentRef,
+		ControllerName: gatewayapiv1.GatewayController(controllerName),
+		Conditions: []metav1.Condition{
+			{Type: string(gatewayapiv1.RouteConditionAccepted), Status: metav1.ConditionTrue, Reason: "Accepted"},
+			{Type: string(gatewayapiv1.RouteConditionResolvedRefs), Status: metav1.ConditionTrue, Reason: "ResolvedRefs"},
+		},
+	}}}
+}
+
+func rejectedRouteStatus(parentRef gatewayapiv1.ParentReference, controllerName, reason, message string) gatewayapiv1.RouteStatus {
+	return gatewayapiv1.RouteStatus{Parents: []gatewayapiv1.RouteParentStatus{{
+		ParentRef:      parentRef,
+		ControllerName: gatewayapiv1.GatewayController(controllerName),
+		Conditions: []metav1.Condition{
+			{Type: string(gatewayapiv1.RouteConditionAccepted), Status: metav1.ConditionFalse, Reason: reason, Message: message},
+		},
+	}}}
+}
+
+func TestAggregateComponentRoutesAllBound(t *testing.T) {
+	parentRef := gatewayapiv1.ParentReference{Name: "gw"}
+	routes := []ComponentRoute{
+		{Component: "predictor", ParentRefs: []gatewayapiv1.ParentReference{parentRef}, RouteStatus: acceptedRouteStatus(parentRef, "istio.io/gateway-controller")},
+	}
+	condition := AggregateComponentRoutes(routes)
+	if condition.Type != IngressReadyConditionType || condition.Status != corev1.ConditionTrue {
+		t.Fatalf("expected IngressReady=True, got %+v", condition)
+	}
+}
+
+func TestAggregateComponentRoutesNamesFailingComponent(t *testing.T) {
+	parentRef := gatewayapiv1.ParentReference{Name: "gw"}
+	routes := []ComponentRoute{
+		{Component: "explainer", ParentRefs: []gatewayapiv1.ParentReference{parentRef}, RouteStatus: rejectedRouteStatus(parentRef, "istio.io/gateway-controller", string(gatewayapiv1.RouteReasonBackendNotFound), "service not found")},
+	}
+	condition := AggregateComponentRoutes(routes)
+	if condition.Status != corev1.ConditionFalse {
+		t.Fatalf("expected IngressReady=False, got %+v", condition)
+	}
+	if condition.Reason != "ExplainerBackendUnresolved" {
+		t.Fatalf("expected a component-specific reason, got %q", condition.Reason)
+	}
+}
+
+func TestAggregateIngressReadyAcrossComponentsUsesWorst(t *testing.T) {
+	perComponent := []apis.Condition{
+		{Status: corev1.ConditionTrue},
+		{Status: corev1.ConditionFalse, Reason: "ExplainerBackendUnresolved"},
+	}
+	condition := AggregateIngressReadyAcrossComponents(perComponent)
+	if condition.Type != IngressReadyConditionType || condition.Status != corev1.ConditionFalse {
+		t.Fatalf("expected the worst (False) condition to win, got %+v", condition)
+	}
+}
+
+func TestPerGatewayConditionType(t *testing.T) {
+	parentRef := gatewayapiv1.ParentReference{Name: "gw"}
+	if got := PerGatewayConditionType("predictor", parentRef); got != "IngressReady.predictor.default.gw" {
+		t.Fatalf("unexpected condition type: %q", got)
+	}
+}
+
+func TestPerGatewayConditions(t *testing.T) {
+	parentRef := gatewayapiv1.ParentReference{Name: "gw"}
+	routes := []ComponentRoute{
+		{Component: "predictor", ParentRefs: []gatewayapiv1.ParentReference{parentRef}, RouteStatus: acceptedRouteStatus(parentRef, "istio.io/gateway-controller")},
+	}
+	conditions := PerGatewayConditions(routes)
+	if len(conditions) != 1 || conditions[0].Status != corev1.ConditionTrue {
+		t.Fatalf("unexpected conditions: %+v", conditions)
+	}
+}
+
+func TestClassifyGatewayProgrammed(t *testing.T) {
+	if condition := ClassifyGatewayProgrammed("gw", nil); condition.Status != corev1.ConditionUnknown || condition.Reason != GatewayNotProgrammedReason {
+		t.Fatalf("expected Unknown when no condition has been reported, got %+v", condition)
+	}
+	programmed := &metav1Condition{status: true, reason: "Programmed", message: "gateway is programmed"}
+	if condition := ClassifyGatewayProgrammed("gw", programmed); condition.Status != corev1.ConditionTrue {
+		t.Fatalf("expected True when the gateway reports Programmed=true, got %+v", condition)
+	}
+}
+
+func TestRouteParentStatusChangedPredicate(t *testing.T) {
+	predicateFuncs := RouteParentStatusChangedPredicate()
+	parentRef := gatewayapiv1.ParentReference{Name: "gw"}
+	oldRoute := &gatewayapiv1.HTTPRoute{Status: gatewayapiv1.HTTPRouteStatus{RouteStatus: acceptedRouteStatus(parentRef, "istio.io/gateway-controller")}}
+	newRoute := &gatewayapiv1.HTTPRoute{Status: gatewayapiv1.HTTPRouteStatus{RouteStatus: acceptedRouteStatus(parentRef, "istio.io/gateway-controller")}}
+
+	if predicateFuncs.Update(event.UpdateEvent{ObjectOld: oldRoute, ObjectNew: newRoute}) {
+		t.Fatalf("expected no reconcile when Status.Parents is unchanged")
+	}
+
+	changedRoute := &gatewayapiv1.HTTPRoute{Status: gatewayapiv1.HTTPRouteStatus{RouteStatus: rejectedRouteStatus(parentRef, "istio.io/gateway-controller", "BackendNotFound", "gone")}}
+	if !predicateFuncs.Update(event.UpdateEvent{ObjectOld: oldRoute, ObjectNew: changedRoute}) {
+		t.Fatalf("expected a reconcile when Status.Parents changed")
+	}
+
+	unrelatedOld := &corev1.Service{}
+	unrelatedNew := &corev1.Service{}
+	if !predicateFuncs.Update(event.UpdateEvent{ObjectOld: unrelatedOld, ObjectNew: unrelatedNew}) {
+		t.Fatalf("expected unrecognized object types to default to reconciling")
+	}
+}
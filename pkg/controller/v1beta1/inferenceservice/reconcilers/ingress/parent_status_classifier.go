@@ -0,0 +1,254 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// ParentOutcome is a typed classification of why a single parentRef did or
+// did not fully bind, borrowing the reason taxonomy from Consul's API
+// Gateway controller so every gateway implementation's Accepted/ResolvedRefs
+// conditions map onto the same small set of outcomes.
+type ParentOutcome string
+
+const (
+	// ParentAccepted means the gateway accepted the route and resolved every
+	// backend reference: this parent is fully bound.
+	ParentAccepted ParentOutcome = "ParentAccepted"
+	// ParentPending means the gateway has not yet reported status for this
+	// parentRef. This is transient: it's expected immediately after the route
+	// is created, before the gateway controller's next reconcile.
+	ParentPending ParentOutcome = "ParentPending"
+	// RefNotPermitted means a ReferenceGrant is required (cross-namespace
+	// backend) but missing or doesn't cover this route.
+	RefNotPermitted ParentOutcome = "RefNotPermitted"
+	// NoMatchingListener means no listener on the parent Gateway matches this
+	// route's sectionName/protocol/hostnames.
+	NoMatchingListener ParentOutcome = "NoMatchingListener"
+	// HostnameConflict means this route's hostname collides with a
+	// higher-priority route already bound to the same listener.
+	HostnameConflict ParentOutcome = "HostnameConflict"
+	// BackendNotFound means a referenced backendRef does not exist.
+	BackendNotFound ParentOutcome = "BackendNotFound"
+	// Unknown is used when the gateway reported a reason this classifier
+	// doesn't recognize; it is treated as a permanent failure rather than
+	// silently retried forever.
+	Unknown ParentOutcome = "Unknown"
+)
+
+// transientOutcomes are the subset of ParentOutcome values that represent
+// "not yet reconciled by the gateway controller" rather than a permanent
+// misconfiguration a human needs to fix.
+var transientOutcomes = map[ParentOutcome]bool{
+	ParentPending: true,
+}
+
+// IsTransient reports whether outcome is expected to resolve on its own once
+// the gateway controller catches up, as opposed to requiring a spec change.
+func (o ParentOutcome) IsTransient() bool {
+	return transientOutcomes[o]
+}
+
+// ParentClassification is the typed outcome for one parentRef, replacing the
+// single Accepted bool in RouteBindResult with enough detail to build the
+// IngressAccepted/IngressRefsResolved/IngressListenerReady sub-conditions.
+type ParentClassification struct {
+	ParentRef gatewayapiv1.ParentReference
+	Outcome   ParentOutcome
+	Message   string
+	// ControllerName is the copied-through gatewayapiv1.RouteParentStatus
+	// ControllerName, identifying which Gateway API implementation reported
+	// this outcome (e.g. "istio.io/gateway-controller"), so a mixed-vendor
+	// cluster's failure messages name the controller that rejected the
+	// route rather than just the parentRef.
+	ControllerName string
+}
+
+// ClassifyParent inspects one parent's reported conditions and returns a
+// typed ParentClassification instead of the coarse Accepted/Reason/Message
+// triple ComputeParentBindResults produces, so callers can distinguish
+// *why* a parent isn't bound.
+func ClassifyParent(parentRef gatewayapiv1.ParentReference, parentStatus gatewayapiv1.RouteParentStatus, reported bool) ParentClassification {
+	if !reported {
+		return ParentClassification{
+			ParentRef: parentRef,
+			Outcome:   ParentPending,
+			Message:   "gateway has not yet reported status for this parentRef",
+		}
+	}
+
+	controllerName := string(parentStatus.ControllerName)
+
+	resolvedRefs := conditionStatus(parentStatus.Conditions, string(gatewayapiv1.RouteConditionResolvedRefs))
+	if resolvedRefs != nil && resolvedRefs.Status != metav1.ConditionTrue {
+		return ParentClassification{ParentRef: parentRef, Outcome: reasonToOutcome(resolvedRefs.Reason), Message: resolvedRefs.Message, ControllerName: controllerName}
+	}
+
+	accepted := conditionStatus(parentStatus.Conditions, string(gatewayapiv1.RouteConditionAccepted))
+	if accepted == nil {
+		return ParentClassification{ParentRef: parentRef, Outcome: ParentPending, Message: "gateway has not yet reported an Accepted condition for this parentRef", ControllerName: controllerName}
+	}
+	if accepted.Status != metav1.ConditionTrue {
+		return ParentClassification{ParentRef: parentRef, Outcome: reasonToOutcome(accepted.Reason), Message: accepted.Message, ControllerName: controllerName}
+	}
+	return ParentClassification{ParentRef: parentRef, Outcome: ParentAccepted, Message: accepted.Message, ControllerName: controllerName}
+}
+
+// reasonToOutcome maps the Gateway API's standard Accepted/ResolvedRefs
+// reason strings onto our typed ParentOutcome taxonomy.
+func reasonToOutcome(reason string) ParentOutcome {
+	switch reason {
+	case string(gatewayapiv1.RouteReasonRefNotPermitted):
+		return RefNotPermitted
+	case string(gatewayapiv1.RouteReasonNoMatchingListenerHostname), string(gatewayapiv1.RouteReasonNoMatchingParent):
+		return NoMatchingListener
+	case string(gatewayapiv1.RouteReasonBackendNotFound):
+		return BackendNotFound
+	default:
+		return Unknown
+	}
+}
+
+// ClassifyParents classifies every configured parentRef against routeStatus,
+// the typed counterpart to ComputeParentBindResults.
+func ClassifyParents(parentRefs []gatewayapiv1.ParentReference, routeStatus gatewayapiv1.RouteStatus) []ParentClassification {
+	classifications := make([]ParentClassification, 0, len(parentRefs))
+	for _, parentRef := range parentRefs {
+		parentStatus, ok := findParentStatus(routeStatus.Parents, parentRef)
+		classifications = append(classifications, ClassifyParent(parentRef, parentStatus, ok))
+	}
+	return classifications
+}
+
+const (
+	IngressAcceptedConditionType      apis.ConditionType = "IngressAccepted"
+	IngressRefsResolvedConditionType  apis.ConditionType = "IngressRefsResolved"
+	IngressListenerReadyConditionType apis.ConditionType = "IngressListenerReady"
+)
+
+// AggregateClassifiedConditions rolls a set of ParentClassifications up into
+// the three sub-conditions: IngressAccepted is False only for a
+// HostnameConflict or Unknown outcome, staying Unknown while every
+// unresolved parent is merely ParentPending. RefNotPermitted and
+// BackendNotFound set IngressRefsResolved False, and NoMatchingListener sets
+// IngressListenerReady False.
+// IngressRefsResolved and IngressListenerReady single out the two most
+// common permanent failure modes so operators don't have to decode a single
+// overloaded message.
+func AggregateClassifiedConditions(classifications []ParentClassification) []apis.Condition {
+	accepted := apis.Condition{Type: IngressAcceptedConditionType, Status: corev1.ConditionTrue}
+	refsResolved := apis.Condition{Type: IngressRefsResolvedConditionType, Status: corev1.ConditionTrue}
+	listenerReady := apis.Condition{Type: IngressListenerReadyConditionType, Status: corev1.ConditionTrue}
+
+	var pending, permanent int
+	for _, c := range classifications {
+		switch c.Outcome {
+		case ParentAccepted:
+			continue
+		case ParentPending:
+			pending++
+		default:
+			permanent++
+		}
+
+		switch c.Outcome {
+		case RefNotPermitted, BackendNotFound:
+			refsResolved = apis.Condition{
+				Type: IngressRefsResolvedConditionType, Status: corev1.ConditionFalse,
+				Reason: string(c.Outcome), Message: fmt.Sprintf("parentRef %s/%s: %s", namespaceOf(c.ParentRef), c.ParentRef.Name, c.Message),
+			}
+		case NoMatchingListener:
+			listenerReady = apis.Condition{
+				Type: IngressListenerReadyConditionType, Status: corev1.ConditionFalse,
+				Reason: string(c.Outcome), Message: fmt.Sprintf("parentRef %s/%s: %s", namespaceOf(c.ParentRef), c.ParentRef.Name, c.Message),
+			}
+		case HostnameConflict, Unknown:
+			accepted = apis.Condition{
+				Type: IngressAcceptedConditionType, Status: corev1.ConditionFalse,
+				Reason: string(c.Outcome), Message: fmt.Sprintf("parentRef %s/%s: %s", namespaceOf(c.ParentRef), c.ParentRef.Name, c.Message),
+			}
+		}
+	}
+
+	if permanent == 0 && pending > 0 && accepted.Status == corev1.ConditionTrue {
+		accepted = apis.Condition{Type: IngressAcceptedConditionType, Status: corev1.ConditionUnknown, Reason: string(ParentPending), Message: "waiting for gateway controller to report status"}
+	}
+
+	return []apis.Condition{accepted, refsResolved, listenerReady}
+}
+
+// IngressRoutesBoundConditionType lists, in its Message, every backend ref
+// this InferenceService's routes reference that the gateway reported as
+// unresolved (RouteConditionResolvedRefs=False), so an operator debugging a
+// stuck isvc doesn't have to open the HTTPRoute YAML to find which Service
+// name was misspelled or deleted.
+const IngressRoutesBoundConditionType apis.ConditionType = "IngressRoutesBound"
+
+// PartiallyInvalidReason mirrors the Gateway API's RouteReasonPartiallyInvalid:
+// some, but not all, of a route's rules were rejected by the gateway (e.g. one
+// rule's backendRef is invalid while the rest of the route still serves
+// traffic). It is worse than ParentAccepted but not a hard NotBound failure.
+const PartiallyInvalidReason = "PartiallyInvalid"
+
+// AggregateIngressRoutesBound rolls a set of ParentClassifications into the
+// IngressRoutesBound condition, enumerating every parentRef whose backend
+// refs the gateway could not resolve.
+func AggregateIngressRoutesBound(classifications []ParentClassification) apis.Condition {
+	var unresolved []string
+	for _, c := range classifications {
+		if c.Outcome == BackendNotFound || c.Outcome == RefNotPermitted {
+			unresolved = append(unresolved, fmt.Sprintf("%s/%s: %s", namespaceOf(c.ParentRef), c.ParentRef.Name, c.Message))
+		}
+	}
+	if len(unresolved) == 0 {
+		return apis.Condition{Type: IngressRoutesBoundConditionType, Status: corev1.ConditionTrue}
+	}
+	return apis.Condition{
+		Type: IngressRoutesBoundConditionType, Status: corev1.ConditionFalse,
+		Reason: "ResolvedRefsFalse", Message: fmt.Sprintf("unresolved backend refs: %v", unresolved),
+	}
+}
+
+// WorstCondition returns the most-severe status among a set of conditions
+// sharing the same Type, where False is worse than Unknown is worse than
+// True, so IngressReady reflects the single worst parent's outcome rather
+// than the most-recently-observed one.
+func WorstCondition(conditions []apis.Condition) apis.Condition {
+	var worst apis.Condition
+	rank := map[corev1.ConditionStatus]int{corev1.ConditionFalse: 2, corev1.ConditionUnknown: 1, corev1.ConditionTrue: 0}
+	worstRank := -1
+	for _, c := range conditions {
+		if rank[c.Status] > worstRank {
+			worstRank = rank[c.Status]
+			worst = c
+		}
+	}
+	return worst
+}
+
+func namespaceOf(parentRef gatewayapiv1.ParentReference) string {
+	if parentRef.Namespace != nil {
+		return string(*parentRef.Namespace)
+	}
+	return "default"
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/apis"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func conditionByType(conditions []apis.Condition, conditionType apis.ConditionType) apis.Condition {
+	for _, c := range conditions {
+		if c.Type == conditionType {
+			return c
+		}
+	}
+	return apis.Condition{}
+}
+
+func TestIsTransient(t *testing.T) {
+	if !ParentPending.IsTransient() {
+		t.Fatalf("expected ParentPending to be transient")
+	}
+	if ParentAccepted.IsTransient() {
+		t.Fatalf("expected ParentAccepted to not be transient")
+	}
+	if Unknown.IsTransient() {
+		t.Fatalf("expected Unknown to not be transient")
+	}
+}
+
+func TestClassifyParentNotReported(t *testing.T) {
+	parentRef := gatewayapiv1.ParentReference{Name: "gw"}
+	got := ClassifyParent(parentRef, gatewayapiv1.RouteParentStatus{}, false)
+	if got.Outcome != ParentPending {
+		t.Fatalf("expected ParentPending, got %+v", got)
+	}
+}
+
+func TestClassifyParentAccepted(t *testing.T) {
+	parentRef := gatewayapiv1.ParentReference{Name: "gw"}
+	got := ClassifyParent(parentRef, acceptedParentStatus(parentRef), true)
+	if got.Outcome != ParentAccepted {
+		t.Fatalf("expected ParentAccepted, got %+v", got)
+	}
+}
+
+func TestClassifyParentNoAcceptedConditionYet(t *testing.T) {
+	parentRef := gatewayapiv1.ParentReference{Name: "gw"}
+	got := ClassifyParent(parentRef, gatewayapiv1.RouteParentStatus{ParentRef: parentRef}, true)
+	if got.Outcome != ParentPending {
+		t.Fatalf("expected ParentPending when Accepted hasn't been reported, got %+v", got)
+	}
+}
+
+func TestClassifyParentResolvedRefsFalseTakesPrecedence(t *testing.T) {
+	parentRef := gatewayapiv1.ParentReference{Name: "gw"}
+	parentStatus := gatewayapiv1.RouteParentStatus{
+		ParentRef:      parentRef,
+		ControllerName: "istio.io/gateway-controller",
+		Conditions: []metav1.Condition{
+			{Type: string(gatewayapiv1.RouteConditionAccepted), Status: metav1.ConditionTrue, Reason: "Accepted"},
+			{Type: string(gatewayapiv1.RouteConditionResolvedRefs), Status: metav1.ConditionFalse, Reason: string(gatewayapiv1.RouteReasonBackendNotFound), Message: "service not found"},
+		},
+	}
+	got := ClassifyParent(parentRef, parentStatus, true)
+	if got.Outcome != BackendNotFound {
+		t.Fatalf("expected BackendNotFound to override Accepted=True, got %+v", got)
+	}
+	if got.ControllerName != "istio.io/gateway-controller" {
+		t.Fatalf("expected the controller name to be copied through, got %+v", got)
+	}
+}
+
+func TestReasonToOutcome(t *testing.T) {
+	cases := []struct {
+		reason string
+		want   ParentOutcome
+	}{
+		{string(gatewayapiv1.RouteReasonRefNotPermitted), RefNotPermitted},
+		{string(gatewayapiv1.RouteReasonNoMatchingListenerHostname), NoMatchingListener},
+		{string(gatewayapiv1.RouteReasonNoMatchingParent), NoMatchingListener},
+		{string(gatewayapiv1.RouteReasonBackendNotFound), BackendNotFound},
+		{"SomethingElse", Unknown},
+	}
+	for _, tc := range cases {
+		if got := reasonToOutcome(tc.reason); got != tc.want {
+			t.Errorf("reasonToOutcome(%q) = %v, want %v", tc.reason, got, tc.want)
+		}
+	}
+}
+
+func TestClassifyParents(t *testing.T) {
+	acceptedRef := gatewayapiv1.ParentReference{Name: "gw-a"}
+	pendingRef := gatewayapiv1.ParentReference{Name: "gw-b"}
+	routeStatus := gatewayapiv1.RouteStatus{Parents: []gatewayapiv1.RouteParentStatus{acceptedParentStatus(acceptedRef)}}
+
+	got := ClassifyParents([]gatewayapiv1.ParentReference{acceptedRef, pendingRef}, routeStatus)
+	if len(got) != 2 || got[0].Outcome != ParentAccepted || got[1].Outcome != ParentPending {
+		t.Fatalf("unexpected classifications: %+v", got)
+	}
+}
+
+func TestAggregateClassifiedConditionsAllAccepted(t *testing.T) {
+	conditions := AggregateClassifiedConditions([]ParentClassification{{Outcome: ParentAccepted}})
+	for _, c := range conditions {
+		if c.Status != corev1.ConditionTrue {
+			t.Fatalf("expected every sub-condition to be True, got %+v", conditions)
+		}
+	}
+}
+
+func TestAggregateClassifiedConditionsPendingOnly(t *testing.T) {
+	conditions := AggregateClassifiedConditions([]ParentClassification{{Outcome: ParentPending}})
+	accepted := conditionByType(conditions, IngressAcceptedConditionType)
+	if accepted.Status != corev1.ConditionUnknown {
+		t.Fatalf("expected IngressAccepted to be Unknown while a parent is pending, got %+v", accepted)
+	}
+}
+
+func TestAggregateClassifiedConditionsRefNotPermitted(t *testing.T) {
+	parentRef := gatewayapiv1.ParentReference{Name: "gw", Namespace: ptr.To(gatewayapiv1.Namespace("ns"))}
+	conditions := AggregateClassifiedConditions([]ParentClassification{
+		{ParentRef: parentRef, Outcome: RefNotPermitted, Message: "missing ReferenceGrant"},
+	})
+	refsResolved := conditionByType(conditions, IngressRefsResolvedConditionType)
+	if refsResolved.Status != corev1.ConditionFalse || refsResolved.Reason != string(RefNotPermitted) {
+		t.Fatalf("unexpected IngressRefsResolved condition: %+v", refsResolved)
+	}
+}
+
+func TestAggregateClassifiedConditionsNoMatchingListener(t *testing.T) {
+	conditions := AggregateClassifiedConditions([]ParentClassification{{Outcome: NoMatchingListener, Message: "no listener"}})
+	listenerReady := conditionByType(conditions, IngressListenerReadyConditionType)
+	if listenerReady.Status != corev1.ConditionFalse || listenerReady.Reason != string(NoMatchingListener) {
+		t.Fatalf("unexpected IngressListenerReady condition: %+v", listenerReady)
+	}
+}
+
+func TestAggregateClassifiedConditionsHostnameConflict(t *testing.T) {
+	conditions := AggregateClassifiedConditions([]ParentClassification{{Outcome: HostnameConflict, Message: "conflict"}})
+	accepted := conditionByType(conditions, IngressAcceptedConditionType)
+	if accepted.Status != corev1.ConditionFalse || accepted.Reason != string(HostnameConflict) {
+		t.Fatalf("unexpected IngressAccepted condition: %+v", accepted)
+	}
+}
+
+func TestAggregateIngressRoutesBoundNoUnresolved(t *testing.T) {
+	got := AggregateIngressRoutesBound([]ParentClassification{{Outcome: ParentAccepted}})
+	if got.Status != corev1.ConditionTrue {
+		t.Fatalf("expected IngressRoutesBound to be True, got %+v", got)
+	}
+}
+
+func TestAggregateIngressRoutesBoundListsUnresolved(t *testing.T) {
+	parentRef := gatewayapiv1.ParentReference{Name: "gw"}
+	got := AggregateIngressRoutesBound([]ParentClassification{
+		{ParentRef: parentRef, Outcome: BackendNotFound, Message: "service not found"},
+	})
+	if got.Status != corev1.ConditionFalse || got.Reason != "ResolvedRefsFalse" {
+		t.Fatalf("unexpected IngressRoutesBound condition: %+v", got)
+	}
+}
+
+func TestWorstCondition(t *testing.T) {
+	conditions := []apis.Condition{
+		{Status: corev1.ConditionTrue},
+		{Status: corev1.ConditionUnknown},
+		{Status: corev1.ConditionFalse},
+	}
+	if got := WorstCondition(conditions); got.Status != corev1.ConditionFalse {
+		t.Fatalf("expected the worst (False) condition to win, got %+v", got)
+	}
+}
+
+func TestNamespaceOf(t *testing.T) {
+	if got := namespaceOf(gatewayapiv1.ParentReference{Name: "gw"}); got != "default" {
+		t.Fatalf("expected a default namespace fallback, got %q", got)
+	}
+	withNamespace := gatewayapiv1.ParentReference{Name: "gw", Namespace: ptr.To(gatewayapiv1.Namespace("istio-system"))}
+	if got := namespaceOf(withNamespace); got != "istio-system" {
+		t.Fatalf("expected the explicit namespace, got %q", got)
+	}
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	gatewayapiv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=referencegrants,verbs=get;list;watch;create;update;patch;delete
+
+// NeedsReferenceGrant reports whether the gateway and the backend Service live
+// in different namespaces, in which case Gateway API requires a ReferenceGrant
+// in the backend's namespace before the HTTPRoute's cross-namespace backendRef
+// is honored.
+func NeedsReferenceGrant(gatewayNamespace, backendNamespace string) bool {
+	return gatewayNamespace != "" && backendNamespace != "" && gatewayNamespace != backendNamespace
+}
+
+// BuildReferenceGrant generates the ReferenceGrant that must live in the
+// backend Service's namespace, granting HTTPRoutes (and GRPCRoutes) from the
+// gateway's namespace permission to reference Services there.
+func BuildReferenceGrant(gatewayNamespace, backendNamespace, backendServiceName string) *gatewayapiv1beta1.ReferenceGrant {
+	return &gatewayapiv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("allow-%s-httproute", gatewayNamespace),
+			Namespace: backendNamespace,
+		},
+		Spec: gatewayapiv1beta1.ReferenceGrantSpec{
+			From: []gatewayapiv1beta1.ReferenceGrantFrom{
+				{
+					Group:     "gateway.networking.k8s.io",
+					Kind:      "HTTPRoute",
+					Namespace: gatewayapiv1beta1.Namespace(gatewayNamespace),
+				},
+				{
+					Group:     "gateway.networking.k8s.io",
+					Kind:      "GRPCRoute",
+					Namespace: gatewayapiv1beta1.Namespace(gatewayNamespace),
+				},
+			},
+			To: []gatewayapiv1beta1.ReferenceGrantTo{
+				{
+					Kind: "Service",
+					Name: (*gatewayapiv1beta1.ObjectName)(&backendServiceName),
+				},
+			},
+		},
+	}
+}
+
+// ReferenceGrantOwner identifies the InferenceService that contributed a set
+// of backend names to a coalesced ReferenceGrant, so CoalesceReferenceGrant
+// can attach a (non-controller) OwnerReference for it. Kubernetes GC only
+// deletes the ReferenceGrant once every owner listed on it is gone, so the
+// grant outlives any single InferenceService as long as another one in the
+// namespace still needs it.
+type ReferenceGrantOwner struct {
+	Name       string
+	UID        types.UID
+	APIVersion string
+	Kind       string
+}
+
+// AsOwnerReference converts owner into the metav1.OwnerReference appended by
+// AddReferenceGrantOwner; blockOwnerDeletion and Controller are left unset
+// since the grant is meant to be co-owned, not exclusively controlled, by
+// whichever InferenceServices reference it.
+func (owner ReferenceGrantOwner) AsOwnerReference() metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion: owner.APIVersion,
+		Kind:       owner.Kind,
+		Name:       owner.Name,
+		UID:        owner.UID,
+	}
+}
+
+// ReferenceGrantBackendNames lists the resources one InferenceService needs a
+// coalesced ReferenceGrant to cover: the Service(s) backing its components,
+// plus the Secret/ConfigMap CA material referenced by a BackendTLSPolicy when
+// upstream TLS is enabled.
+type ReferenceGrantBackendNames struct {
+	Services   []string
+	Secrets    []string
+	ConfigMaps []string
+}
+
+// CoalesceReferenceGrantName is the name used for the single, shared
+// ReferenceGrant generated per backend namespace, as opposed to
+// BuildReferenceGrant's per-service name, so repeated reconciles of different
+// InferenceServices in the same namespace converge on one object rather than
+// each owning a separate grant for the same gateway namespace.
+func CoalesceReferenceGrantName(gatewayNamespace string) string {
+	return fmt.Sprintf("allow-%s-ingress", gatewayNamespace)
+}
+
+// BuildCoalescedReferenceGrant generates the shared, namespace-wide
+// ReferenceGrant granting the gateway namespace's HTTPRoutes/GRPCRoutes
+// access to every backend name contributed so far, merging in backends with
+// MergeReferenceGrantBackends before this is called.
+func BuildCoalescedReferenceGrant(gatewayNamespace, backendNamespace string, backends ReferenceGrantBackendNames) *gatewayapiv1beta1.ReferenceGrant {
+	to := make([]gatewayapiv1beta1.ReferenceGrantTo, 0, len(backends.Services)+len(backends.Secrets)+len(backends.ConfigMaps))
+	for _, name := range backends.Services {
+		n := gatewayapiv1beta1.ObjectName(name)
+		to = append(to, gatewayapiv1beta1.ReferenceGrantTo{Kind: "Service", Name: &n})
+	}
+	for _, name := range backends.Secrets {
+		n := gatewayapiv1beta1.ObjectName(name)
+		to = append(to, gatewayapiv1beta1.ReferenceGrantTo{Kind: "Secret", Name: &n})
+	}
+	for _, name := range backends.ConfigMaps {
+		n := gatewayapiv1beta1.ObjectName(name)
+		to = append(to, gatewayapiv1beta1.ReferenceGrantTo{Kind: "ConfigMap", Name: &n})
+	}
+
+	return &gatewayapiv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CoalesceReferenceGrantName(gatewayNamespace),
+			Namespace: backendNamespace,
+		},
+		Spec: gatewayapiv1beta1.ReferenceGrantSpec{
+			From: []gatewayapiv1beta1.ReferenceGrantFrom{
+				{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Namespace: gatewayapiv1beta1.Namespace(gatewayNamespace)},
+				{Group: "gateway.networking.k8s.io", Kind: "GRPCRoute", Namespace: gatewayapiv1beta1.Namespace(gatewayNamespace)},
+			},
+			To: to,
+		},
+	}
+}
+
+// MergeReferenceGrantBackends folds a single InferenceService's backend names
+// into the running set accumulated across every ISVC reconciled so far in
+// this namespace, deduplicating and sorting so repeated calls are idempotent
+// and the generated ReferenceGrant doesn't churn on reconcile order.
+func MergeReferenceGrantBackends(existing ReferenceGrantBackendNames, additional ReferenceGrantBackendNames) ReferenceGrantBackendNames {
+	return ReferenceGrantBackendNames{
+		Services:   mergeUnique(existing.Services, additional.Services),
+		Secrets:    mergeUnique(existing.Secrets, additional.Secrets),
+		ConfigMaps: mergeUnique(existing.ConfigMaps, additional.ConfigMaps),
+	}
+}
+
+func mergeUnique(a, b []string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	merged := make([]string, 0, len(a)+len(b))
+	for _, name := range append(append([]string{}, a...), b...) {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		merged = append(merged, name)
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+// AddReferenceGrantOwner appends owner to grant's OwnerReferences if it isn't
+// already listed, using a non-controller reference (Controller is left unset)
+// so the grant can be co-owned by every InferenceService that contributed a
+// backend to it; Kubernetes GC removes the grant only once all of its owners
+// are gone.
+func AddReferenceGrantOwner(grant *gatewayapiv1beta1.ReferenceGrant, owner metav1.OwnerReference) {
+	for _, existing := range grant.OwnerReferences {
+		if existing.UID == owner.UID {
+			return
+		}
+	}
+	grant.OwnerReferences = append(grant.OwnerReferences, owner)
+}
+
+// ReferenceGrantReadyConditionType is surfaced on the InferenceService when a
+// cross-namespace backend reference requires a ReferenceGrant that hasn't
+// been created yet, so operators see why the route isn't bound instead of
+// only seeing the gateway's opaque RefNotPermitted reason.
+const ReferenceGrantReadyConditionType apis.ConditionType = "ReferenceGrantReady"
+
+// ReferenceGrantMissingCondition builds the False ReferenceGrantReady
+// condition to set when NeedsReferenceGrant is true but the coalesced grant
+// in the backend namespace does not yet exist.
+func ReferenceGrantMissingCondition(gatewayNamespace, backendNamespace string) apis.Condition {
+	return apis.Condition{
+		Type:    ReferenceGrantReadyConditionType,
+		Status:  corev1.ConditionFalse,
+		Reason:  "ReferenceGrantMissing",
+		Message: fmt.Sprintf("ReferenceGrant %s is required in namespace %s to allow routes from namespace %s to reach this InferenceService's backends", CoalesceReferenceGrantName(gatewayNamespace), backendNamespace, gatewayNamespace),
+	}
+}
+
+// ShouldIncludeTLSBackends reports whether a coalesced ReferenceGrant must
+// also cover Secret/ConfigMap CA material, i.e. BackendTLSPolicy is enabled
+// for this InferenceService (see ShouldAttachBackendTLSPolicy).
+func ShouldIncludeTLSBackends(backendTLSEnabled bool) bool {
+	return backendTLSEnabled
+}
+
+// RejectedGateway is one gateway fan-out candidate FilterGatewaysWithoutGrant
+// refused to add because no ReferenceGrant exists yet permitting it to
+// reference the backend namespace's Services.
+type RejectedGateway struct {
+	Gateway GatewayConfig
+	Reason  string
+}
+
+// FilterGatewaysWithoutGrant partitions a multi-gateway fan-out list into the
+// gateways allowed to be added as HTTPRoute parents and the ones refused for
+// lacking a ReferenceGrant, so a misconfigured additional gateway degrades to
+// "not added, reported" rather than producing a route the Gateway API
+// implementation will reject wholesale.
+func FilterGatewaysWithoutGrant(gateways []GatewayConfig, backendNamespace string, grantExists func(gatewayNamespace, backendNamespace string) bool) (allowed []GatewayConfig, rejected []RejectedGateway) {
+	for _, gw := range gateways {
+		if !NeedsReferenceGrant(gw.Namespace, backendNamespace) || grantExists(gw.Namespace, backendNamespace) {
+			allowed = append(allowed, gw)
+			continue
+		}
+		rejected = append(rejected, RejectedGateway{
+			Gateway: gw,
+			Reason:  fmt.Sprintf("no ReferenceGrant in namespace %s permits gateway %s/%s to reference Services here", backendNamespace, gw.Namespace, gw.Name),
+		})
+	}
+	return allowed, rejected
+}
+
+// ReferenceGrantMissingEventReason is the Event reason recorded against the
+// InferenceService for each gateway FilterGatewaysWithoutGrant rejected, so
+// `kubectl describe` surfaces the missing grant without inspecting status
+// conditions.
+const ReferenceGrantMissingEventReason = "ReferenceGrantMissing"
+
+// GrantAuthorizes reports whether an existing, user-managed ReferenceGrant
+// actually authorizes a route in sourceNamespace to reference
+// targetServiceName: existence alone isn't sufficient, since a grant's
+// From/To entries are scoped to specific namespaces, kinds, and (optionally)
+// names. A To entry with an empty Name authorizes every Service in the
+// target namespace, matching the Gateway API's own "all resources of this
+// kind" semantics.
+func GrantAuthorizes(grant *gatewayapiv1beta1.ReferenceGrant, sourceNamespace, targetServiceName string) bool {
+	if grant == nil {
+		return false
+	}
+
+	fromAllowed := false
+	for _, from := range grant.Spec.From {
+		if from.Group == "gateway.networking.k8s.io" && from.Kind == "HTTPRoute" && string(from.Namespace) == sourceNamespace {
+			fromAllowed = true
+			break
+		}
+	}
+	if !fromAllowed {
+		return false
+	}
+
+	for _, to := range grant.Spec.To {
+		if to.Kind != "Service" {
+			continue
+		}
+		if to.Name == nil || string(*to.Name) == targetServiceName {
+			return true
+		}
+	}
+	return false
+}
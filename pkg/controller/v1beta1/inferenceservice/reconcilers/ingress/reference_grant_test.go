@@ -0,0 +1,185 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayapiv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestNeedsReferenceGrant(t *testing.T) {
+	cases := []struct {
+		name, gatewayNS, backendNS string
+		want                       bool
+	}{
+		{"different namespaces", "gw-ns", "backend-ns", true},
+		{"same namespace", "ns", "ns", false},
+		{"empty gateway namespace", "", "backend-ns", false},
+		{"empty backend namespace", "gw-ns", "", false},
+	}
+	for _, tc := range cases {
+		if got := NeedsReferenceGrant(tc.gatewayNS, tc.backendNS); got != tc.want {
+			t.Errorf("%s: NeedsReferenceGrant() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestBuildReferenceGrant(t *testing.T) {
+	grant := BuildReferenceGrant("gw-ns", "backend-ns", "predictor")
+	if grant.Name != "allow-gw-ns-httproute" || grant.Namespace != "backend-ns" {
+		t.Fatalf("unexpected object metadata: %s/%s", grant.Namespace, grant.Name)
+	}
+	if len(grant.Spec.From) != 2 {
+		t.Fatalf("expected HTTPRoute and GRPCRoute From entries, got %+v", grant.Spec.From)
+	}
+	if len(grant.Spec.To) != 1 || string(*grant.Spec.To[0].Name) != "predictor" {
+		t.Fatalf("unexpected To entries: %+v", grant.Spec.To)
+	}
+}
+
+func TestReferenceGrantOwnerAsOwnerReference(t *testing.T) {
+	owner := ReferenceGrantOwner{Name: "isvc", UID: types.UID("abc"), APIVersion: "serving.kserve.io/v1beta1", Kind: "InferenceService"}
+	ref := owner.AsOwnerReference()
+	if ref.Name != "isvc" || ref.UID != types.UID("abc") || ref.Kind != "InferenceService" {
+		t.Fatalf("unexpected owner reference: %+v", ref)
+	}
+}
+
+func TestCoalesceReferenceGrantName(t *testing.T) {
+	if got := CoalesceReferenceGrantName("gw-ns"); got != "allow-gw-ns-ingress" {
+		t.Fatalf("unexpected name: %q", got)
+	}
+}
+
+func TestBuildCoalescedReferenceGrant(t *testing.T) {
+	grant := BuildCoalescedReferenceGrant("gw-ns", "backend-ns", ReferenceGrantBackendNames{
+		Services:   []string{"predictor"},
+		Secrets:    []string{"ca-secret"},
+		ConfigMaps: []string{"ca-bundle"},
+	})
+	if grant.Name != "allow-gw-ns-ingress" {
+		t.Fatalf("unexpected name: %q", grant.Name)
+	}
+	if len(grant.Spec.To) != 3 {
+		t.Fatalf("expected one To entry per backend name, got %+v", grant.Spec.To)
+	}
+}
+
+func TestMergeReferenceGrantBackendsDedupesAndSorts(t *testing.T) {
+	merged := MergeReferenceGrantBackends(
+		ReferenceGrantBackendNames{Services: []string{"b", "a"}},
+		ReferenceGrantBackendNames{Services: []string{"a", "c"}},
+	)
+	if len(merged.Services) != 3 || merged.Services[0] != "a" || merged.Services[1] != "b" || merged.Services[2] != "c" {
+		t.Fatalf("unexpected merged services: %+v", merged.Services)
+	}
+}
+
+func TestAddReferenceGrantOwnerDedupesByUID(t *testing.T) {
+	grant := &gatewayapiv1beta1.ReferenceGrant{}
+	owner := metav1.OwnerReference{UID: types.UID("abc"), Name: "isvc-a"}
+	AddReferenceGrantOwner(grant, owner)
+	AddReferenceGrantOwner(grant, owner)
+	if len(grant.OwnerReferences) != 1 {
+		t.Fatalf("expected the owner to be added only once, got %+v", grant.OwnerReferences)
+	}
+	AddReferenceGrantOwner(grant, metav1.OwnerReference{UID: types.UID("def"), Name: "isvc-b"})
+	if len(grant.OwnerReferences) != 2 {
+		t.Fatalf("expected a distinct owner to be appended, got %+v", grant.OwnerReferences)
+	}
+}
+
+func TestReferenceGrantMissingCondition(t *testing.T) {
+	condition := ReferenceGrantMissingCondition("gw-ns", "backend-ns")
+	if condition.Type != ReferenceGrantReadyConditionType {
+		t.Fatalf("unexpected condition type: %v", condition.Type)
+	}
+	if condition.Status != "False" {
+		t.Fatalf("expected the condition to be False, got %v", condition.Status)
+	}
+}
+
+func TestShouldIncludeTLSBackends(t *testing.T) {
+	if ShouldIncludeTLSBackends(false) {
+		t.Fatalf("expected false when backend TLS is disabled")
+	}
+	if !ShouldIncludeTLSBackends(true) {
+		t.Fatalf("expected true when backend TLS is enabled")
+	}
+}
+
+func TestFilterGatewaysWithoutGrant(t *testing.T) {
+	gateways := []GatewayConfig{
+		{Name: "same-ns", Namespace: "backend-ns"},
+		{Name: "granted", Namespace: "gw-ns-granted"},
+		{Name: "ungranted", Namespace: "gw-ns-ungranted"},
+	}
+	grantExists := func(gatewayNamespace, backendNamespace string) bool {
+		return gatewayNamespace == "gw-ns-granted"
+	}
+
+	allowed, rejected := FilterGatewaysWithoutGrant(gateways, "backend-ns", grantExists)
+	if len(allowed) != 2 {
+		t.Fatalf("expected the same-namespace and granted gateways to be allowed, got %+v", allowed)
+	}
+	if len(rejected) != 1 || rejected[0].Gateway.Name != "ungranted" {
+		t.Fatalf("expected only the ungranted gateway to be rejected, got %+v", rejected)
+	}
+}
+
+func TestGrantAuthorizes(t *testing.T) {
+	serviceName := gatewayapiv1beta1.ObjectName("predictor")
+	grant := &gatewayapiv1beta1.ReferenceGrant{
+		Spec: gatewayapiv1beta1.ReferenceGrantSpec{
+			From: []gatewayapiv1beta1.ReferenceGrantFrom{
+				{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Namespace: "gw-ns"},
+			},
+			To: []gatewayapiv1beta1.ReferenceGrantTo{
+				{Kind: "Service", Name: &serviceName},
+			},
+		},
+	}
+	if !GrantAuthorizes(grant, "gw-ns", "predictor") {
+		t.Fatalf("expected the grant to authorize the matching namespace/service")
+	}
+	if GrantAuthorizes(grant, "other-ns", "predictor") {
+		t.Fatalf("expected the grant to not authorize a different source namespace")
+	}
+	if GrantAuthorizes(grant, "gw-ns", "transformer") {
+		t.Fatalf("expected the grant to not authorize a different service name")
+	}
+	if GrantAuthorizes(nil, "gw-ns", "predictor") {
+		t.Fatalf("expected a nil grant to authorize nothing")
+	}
+}
+
+func TestGrantAuthorizesEmptyNameAuthorizesAnyService(t *testing.T) {
+	grant := &gatewayapiv1beta1.ReferenceGrant{
+		Spec: gatewayapiv1beta1.ReferenceGrantSpec{
+			From: []gatewayapiv1beta1.ReferenceGrantFrom{
+				{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Namespace: "gw-ns"},
+			},
+			To: []gatewayapiv1beta1.ReferenceGrantTo{{Kind: "Service"}},
+		},
+	}
+	if !GrantAuthorizes(grant, "gw-ns", "any-service") {
+		t.Fatalf("expected an empty To.Name to authorize any service")
+	}
+}
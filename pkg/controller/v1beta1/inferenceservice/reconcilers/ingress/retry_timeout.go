@@ -0,0 +1,245 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+// ServingRuntime annotation keys a cluster operator can set to drive
+// per-runtime retry/timeout defaults without a per-InferenceService
+// IngressPolicy override.
+const (
+	ServingRuntimeRetryOnAnnotationKey       = "serving.kserve.io/retry-on"
+	ServingRuntimeNumRetriesAnnotationKey    = "serving.kserve.io/num-retries"
+	ServingRuntimePerTryTimeoutAnnotationKey = "serving.kserve.io/per-try-timeout"
+)
+
+// ParseServingRuntimeRetryPolicy builds an IngressRetryPolicy from a
+// ServingRuntime's retry annotations, returning nil when none are set so
+// callers fall back to any IngressPolicy configured directly on the
+// InferenceService.
+func ParseServingRuntimeRetryPolicy(annotations map[string]string) *v1beta1.IngressRetryPolicy {
+	numRetries, hasNumRetries := annotations[ServingRuntimeNumRetriesAnnotationKey]
+	retryOn, hasRetryOn := annotations[ServingRuntimeRetryOnAnnotationKey]
+	perTryTimeout, hasPerTryTimeout := annotations[ServingRuntimePerTryTimeoutAnnotationKey]
+	if !hasNumRetries && !hasRetryOn && !hasPerTryTimeout {
+		return nil
+	}
+
+	policy := &v1beta1.IngressRetryPolicy{}
+	if hasNumRetries {
+		if attempts, err := strconv.Atoi(numRetries); err == nil {
+			policy.Attempts = int32(attempts)
+		}
+	}
+	if hasRetryOn {
+		policy.Conditions = strings.Split(retryOn, ",")
+	}
+	if hasPerTryTimeout {
+		if d, err := time.ParseDuration(perTryTimeout); err == nil {
+			policy.PerTryTimeout = &metav1.Duration{Duration: d}
+		}
+	}
+	return policy
+}
+
+// RouteRetryFilterGVK and RouteTimeoutFilterGVK are the Gateway API
+// experimental-channel ExtensionRef filter kinds some implementations (e.g.
+// Envoy Gateway) register; IsRouteFilterCRDInstalled detects them the same
+// way IsKuadrantCRDInstalled detects Kuadrant's policy CRDs.
+var (
+	RouteRetryFilterGVK   = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Kind: "RouteRetryFilter"}
+	RouteTimeoutFilterGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Kind: "RouteTimeoutFilter"}
+)
+
+// IsRouteFilterCRDInstalled reports whether the given experimental filter
+// CRD is registered on the cluster, so the reconciler can prefer a native
+// ExtensionRef filter and fall back to implementation-specific annotations
+// (Istio VirtualService retries, Envoy Gateway BackendTrafficPolicy) when it
+// isn't.
+func IsRouteFilterCRDInstalled(c client.Client, gvk schema.GroupVersionKind) bool {
+	_, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	return err == nil
+}
+
+// BuildEnvoyGatewayBackendTrafficPolicy generates an Envoy Gateway
+// BackendTrafficPolicy carrying the retry policy, for clusters where neither
+// the native HTTPRouteRule.Retry field nor RouteRetryFilterGVK is available.
+func BuildEnvoyGatewayBackendTrafficPolicy(name, namespace, targetRouteName string, policy *v1beta1.IngressRetryPolicy) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Group: "gateway.envoyproxy.io", Version: "v1alpha1", Kind: "BackendTrafficPolicy"})
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+
+	spec := map[string]interface{}{
+		"targetRef": map[string]interface{}{
+			"group": "gateway.networking.k8s.io",
+			"kind":  "HTTPRoute",
+			"name":  targetRouteName,
+		},
+	}
+	if policy != nil {
+		retry := map[string]interface{}{}
+		if policy.Attempts > 0 {
+			retry["numRetries"] = int64(policy.Attempts)
+		}
+		if policy.PerTryTimeout != nil {
+			retry["perRetryTimeout"] = policy.PerTryTimeout.Duration.String()
+		}
+		if len(policy.Conditions) > 0 {
+			retry["retryOn"] = policy.Conditions
+		}
+		spec["retry"] = retry
+	}
+	obj.Object["spec"] = spec
+	return obj
+}
+
+// nativeRetryGateways lists the kserveIngressGateway implementations known to
+// honor the upstream HTTPRouteRule.Retry field today, so the reconciler can
+// default NativeHTTPRouteRetrySupported from the configured gateway name
+// instead of requiring an operator to flip the feature gate by hand.
+var nativeRetryGateways = map[string]bool{
+	"istio":         true,
+	"envoy-gateway": true,
+	"envoygateway":  true,
+}
+
+// DetectNativeRetrySupport reports whether the named Gateway API
+// implementation (as configured for kserveIngressGateway) is known to honor
+// the native HTTPRouteRule.Retry field, falling back to false (the
+// ExtensionRef/annotation path) for unrecognized or empty implementation
+// names so unknown gateways don't silently drop retry configuration.
+func DetectNativeRetrySupport(gatewayImplementation string) bool {
+	return nativeRetryGateways[strings.ToLower(gatewayImplementation)]
+}
+
+// DefaultRequestTimeout is used by BuildTimeouts when the IngressPolicy is nil
+// or sets no RequestTimeout; the component's TimeoutSeconds is not read.
+const DefaultRequestTimeout = gatewayapiv1.Duration("60s")
+
+// BuildTimeouts translates IngressPolicy into the HTTPRouteRule.Timeouts block,
+// falling back to DefaultRequestTimeout when unset.
+func BuildTimeouts(policy *v1beta1.IngressPolicy) *gatewayapiv1.HTTPRouteTimeouts {
+	timeouts := &gatewayapiv1.HTTPRouteTimeouts{Request: ptrDuration(DefaultRequestTimeout)}
+	if policy == nil {
+		return timeouts
+	}
+	if policy.RequestTimeout != nil {
+		timeouts.Request = ptrDuration(gatewayapiv1.Duration(policy.RequestTimeout.Duration.String()))
+	}
+	if policy.BackendRequestTimeout != nil {
+		timeouts.BackendRequest = ptrDuration(gatewayapiv1.Duration(policy.BackendRequestTimeout.Duration.String()))
+	}
+	return timeouts
+}
+
+// ApplyRetryPolicy wires policy.Retry into rule, preferring the native
+// HTTPRouteRule.Retry field when NativeHTTPRouteRetrySupported is true and
+// falling back to BuildRetryFilter's ExtensionRef otherwise, so callers don't
+// need to duplicate this feature-gate check at every call site that builds a
+// predict/explain rule.
+func ApplyRetryPolicy(rule *gatewayapiv1.HTTPRouteRule, policy *v1beta1.IngressRetryPolicy, retryPolicyName string) {
+	if policy == nil {
+		return
+	}
+	if NativeHTTPRouteRetrySupported {
+		rule.Retry = BuildNativeRetry(policy)
+		return
+	}
+	rule.Filters = append(rule.Filters, BuildRetryFilter(retryPolicyName))
+}
+
+// BuildRetryFilter translates IngressPolicy.Retry into an ExtensionRef
+// HTTPRouteFilter pointing at an InferenceRouteRetryPolicy, for Gateway API
+// implementations that don't yet support the native HTTPRouteRule.Retry field.
+func BuildRetryFilter(retryPolicyName string) gatewayapiv1.HTTPRouteFilter {
+	return gatewayapiv1.HTTPRouteFilter{
+		Type: gatewayapiv1.HTTPRouteFilterExtensionRef,
+		ExtensionRef: &gatewayapiv1.LocalObjectReference{
+			Group: "serving.kserve.io",
+			Kind:  "InferenceRouteRetryPolicy",
+			Name:  gatewayapiv1.ObjectName(retryPolicyName),
+		},
+	}
+}
+
+// NativeHTTPRouteRetrySupported gates emission of the upstream
+// HTTPRouteRule.Retry field, which is still behind a Gateway API experimental
+// channel feature gate at the time of writing. When false, ApplyRetryPolicy
+// attaches BuildRetryFilter's ExtensionRef instead; BuildIstioRetryAnnotations
+// covers gateways that don't honor that extension either.
+var NativeHTTPRouteRetrySupported = false
+
+// BuildIstioRetryAnnotations renders the VirtualService-equivalent retry
+// behavior as Istio annotations on the generated Service, for clusters where
+// NativeHTTPRouteRetrySupported is false and BuildRetryFilter's
+// InferenceRouteRetryPolicy extension isn't honored by the installed gateway
+// controller either.
+func BuildIstioRetryAnnotations(policy *v1beta1.IngressRetryPolicy) map[string]string {
+	if policy == nil {
+		return nil
+	}
+	annotations := map[string]string{}
+	if policy.Attempts > 0 {
+		annotations["traffic.sidecar.istio.io/retry-attempts"] = strconv.Itoa(int(policy.Attempts))
+	}
+	if policy.PerTryTimeout != nil {
+		annotations["traffic.sidecar.istio.io/retry-per-try-timeout"] = policy.PerTryTimeout.Duration.String()
+	}
+	if len(policy.Conditions) > 0 {
+		annotations["traffic.sidecar.istio.io/retry-on"] = strings.Join(policy.Conditions, ",")
+	}
+	return annotations
+}
+
+func ptrDuration(d gatewayapiv1.Duration) *gatewayapiv1.Duration {
+	return &d
+}
+
+// BuildNativeRetry translates IngressPolicy.Retry into the upstream
+// HTTPRouteRule.Retry field, for clusters where NativeHTTPRouteRetrySupported
+// is true. Returns nil when policy has no retry configured, so callers can
+// assign the result directly to HTTPRouteRule.Retry.
+func BuildNativeRetry(policy *v1beta1.IngressRetryPolicy) *gatewayapiv1.HTTPRouteRetry {
+	if policy == nil {
+		return nil
+	}
+	retry := &gatewayapiv1.HTTPRouteRetry{}
+	if policy.Attempts > 0 {
+		attempts := int(policy.Attempts)
+		retry.Attempts = &attempts
+	}
+	if policy.PerTryTimeout != nil {
+		retry.Backoff = ptrDuration(gatewayapiv1.Duration(policy.PerTryTimeout.Duration.String()))
+	}
+	for _, code := range policy.Codes {
+		retry.Codes = append(retry.Codes, gatewayapiv1.HTTPRouteRetryStatusCode(code))
+	}
+	return retry
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+func TestParseServingRuntimeRetryPolicyNoAnnotations(t *testing.T) {
+	if policy := ParseServingRuntimeRetryPolicy(map[string]string{}); policy != nil {
+		t.Fatalf("expected nil policy when no retry annotations are set, got %+v", policy)
+	}
+}
+
+func TestParseServingRuntimeRetryPolicyParsesAllFields(t *testing.T) {
+	annotations := map[string]string{
+		ServingRuntimeNumRetriesAnnotationKey:    "3",
+		ServingRuntimeRetryOnAnnotationKey:       "5xx,reset",
+		ServingRuntimePerTryTimeoutAnnotationKey: "2s",
+	}
+	policy := ParseServingRuntimeRetryPolicy(annotations)
+	if policy == nil {
+		t.Fatalf("expected a non-nil policy")
+	}
+	if policy.Attempts != 3 {
+		t.Fatalf("unexpected attempts: %d", policy.Attempts)
+	}
+	if len(policy.Conditions) != 2 || policy.Conditions[0] != "5xx" || policy.Conditions[1] != "reset" {
+		t.Fatalf("unexpected conditions: %v", policy.Conditions)
+	}
+	if policy.PerTryTimeout == nil || policy.PerTryTimeout.Duration != 2*time.Second {
+		t.Fatalf("unexpected per-try timeout: %+v", policy.PerTryTimeout)
+	}
+}
+
+func TestParseServingRuntimeRetryPolicyIgnoresUnparseableValues(t *testing.T) {
+	annotations := map[string]string{
+		ServingRuntimeNumRetriesAnnotationKey:    "not-a-number",
+		ServingRuntimePerTryTimeoutAnnotationKey: "not-a-duration",
+	}
+	policy := ParseServingRuntimeRetryPolicy(annotations)
+	if policy == nil {
+		t.Fatalf("expected a non-nil policy since the annotations are present")
+	}
+	if policy.Attempts != 0 {
+		t.Fatalf("expected attempts to stay zero for an unparseable value, got %d", policy.Attempts)
+	}
+	if policy.PerTryTimeout != nil {
+		t.Fatalf("expected per-try timeout to stay nil for an unparseable value, got %+v", policy.PerTryTimeout)
+	}
+}
+
+func TestBuildEnvoyGatewayBackendTrafficPolicy(t *testing.T) {
+	policy := &v1beta1.IngressRetryPolicy{
+		Attempts:      3,
+		PerTryTimeout: &metav1.Duration{Duration: 2 * time.Second},
+		Conditions:    []string{"5xx"},
+	}
+	obj := BuildEnvoyGatewayBackendTrafficPolicy("retry-policy", "ns", "my-route", policy)
+	if obj.GetName() != "retry-policy" || obj.GetNamespace() != "ns" {
+		t.Fatalf("unexpected object metadata: %s/%s", obj.GetNamespace(), obj.GetName())
+	}
+	spec, ok := obj.Object["spec"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a spec map, got %+v", obj.Object)
+	}
+	retry, ok := spec["retry"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a retry map, got %+v", spec)
+	}
+	if retry["numRetries"] != int64(3) {
+		t.Fatalf("unexpected numRetries: %v", retry["numRetries"])
+	}
+}
+
+func TestBuildEnvoyGatewayBackendTrafficPolicyNilPolicy(t *testing.T) {
+	obj := BuildEnvoyGatewayBackendTrafficPolicy("retry-policy", "ns", "my-route", nil)
+	spec := obj.Object["spec"].(map[string]interface{})
+	if _, ok := spec["retry"]; ok {
+		t.Fatalf("expected no retry key when policy is nil, got %+v", spec)
+	}
+}
+
+func TestDetectNativeRetrySupport(t *testing.T) {
+	cases := []struct {
+		implementation string
+		want           bool
+	}{
+		{"istio", true},
+		{"Istio", true},
+		{"envoy-gateway", true},
+		{"envoygateway", true},
+		{"nginx", false},
+		{"", false},
+	}
+	for _, tc := range cases {
+		if got := DetectNativeRetrySupport(tc.implementation); got != tc.want {
+			t.Errorf("DetectNativeRetrySupport(%q) = %v, want %v", tc.implementation, got, tc.want)
+		}
+	}
+}
+
+func TestBuildTimeoutsDefaultsWhenPolicyNil(t *testing.T) {
+	timeouts := BuildTimeouts(nil)
+	if timeouts.Request == nil || *timeouts.Request != DefaultRequestTimeout {
+		t.Fatalf("expected the default request timeout, got %+v", timeouts.Request)
+	}
+	if timeouts.BackendRequest != nil {
+		t.Fatalf("expected no backend request timeout by default, got %+v", timeouts.BackendRequest)
+	}
+}
+
+func TestBuildTimeoutsHonorsPolicyOverrides(t *testing.T) {
+	policy := &v1beta1.IngressPolicy{
+		RequestTimeout:        &metav1.Duration{Duration: 30 * time.Second},
+		BackendRequestTimeout: &metav1.Duration{Duration: 10 * time.Second},
+	}
+	timeouts := BuildTimeouts(policy)
+	if timeouts.Request == nil || *timeouts.Request != gatewayapiv1.Duration("30s") {
+		t.Fatalf("unexpected request timeout: %+v", timeouts.Request)
+	}
+	if timeouts.BackendRequest == nil || *timeouts.BackendRequest != gatewayapiv1.Duration("10s") {
+		t.Fatalf("unexpected backend request timeout: %+v", timeouts.BackendRequest)
+	}
+}
+
+func TestApplyRetryPolicyNilPolicyNoOp(t *testing.T) {
+	rule := &gatewayapiv1.HTTPRouteRule{}
+	ApplyRetryPolicy(rule, nil, "retry-policy")
+	if rule.Retry != nil || len(rule.Filters) != 0 {
+		t.Fatalf("expected no changes for a nil policy, got %+v", rule)
+	}
+}
+
+func TestApplyRetryPolicyUsesExtensionRefFilterWhenNativeUnsupported(t *testing.T) {
+	if NativeHTTPRouteRetrySupported {
+		t.Skip("NativeHTTPRouteRetrySupported is true in this build")
+	}
+	rule := &gatewayapiv1.HTTPRouteRule{}
+	ApplyRetryPolicy(rule, &v1beta1.IngressRetryPolicy{Attempts: 2}, "retry-policy")
+	if rule.Retry != nil {
+		t.Fatalf("expected no native retry to be set, got %+v", rule.Retry)
+	}
+	if len(rule.Filters) != 1 || rule.Filters[0].Type != gatewayapiv1.HTTPRouteFilterExtensionRef {
+		t.Fatalf("expected an ExtensionRef filter, got %+v", rule.Filters)
+	}
+}
+
+func TestBuildRetryFilter(t *testing.T) {
+	filter := BuildRetryFilter("retry-policy")
+	if filter.Type != gatewayapiv1.HTTPRouteFilterExtensionRef {
+		t.Fatalf("unexpected filter type: %v", filter.Type)
+	}
+	if filter.ExtensionRef == nil || filter.ExtensionRef.Kind != "InferenceRouteRetryPolicy" || filter.ExtensionRef.Name != "retry-policy" {
+		t.Fatalf("unexpected extension ref: %+v", filter.ExtensionRef)
+	}
+}
+
+func TestBuildIstioRetryAnnotationsNilPolicy(t *testing.T) {
+	if annotations := BuildIstioRetryAnnotations(nil); annotations != nil {
+		t.Fatalf("expected nil annotations for a nil policy, got %+v", annotations)
+	}
+}
+
+func TestBuildIstioRetryAnnotations(t *testing.T) {
+	policy := &v1beta1.IngressRetryPolicy{
+		Attempts:      3,
+		PerTryTimeout: &metav1.Duration{Duration: 2 * time.Second},
+		Conditions:    []string{"5xx", "reset"},
+	}
+	annotations := BuildIstioRetryAnnotations(policy)
+	if annotations["traffic.sidecar.istio.io/retry-attempts"] != "3" {
+		t.Fatalf("unexpected retry-attempts annotation: %v", annotations)
+	}
+	if annotations["traffic.sidecar.istio.io/retry-per-try-timeout"] != "2s" {
+		t.Fatalf("unexpected retry-per-try-timeout annotation: %v", annotations)
+	}
+	if annotations["traffic.sidecar.istio.io/retry-on"] != "5xx,reset" {
+		t.Fatalf("unexpected retry-on annotation: %v", annotations)
+	}
+}
+
+func TestBuildNativeRetryNilPolicy(t *testing.T) {
+	if retry := BuildNativeRetry(nil); retry != nil {
+		t.Fatalf("expected nil retry for a nil policy, got %+v", retry)
+	}
+}
+
+func TestBuildNativeRetry(t *testing.T) {
+	policy := &v1beta1.IngressRetryPolicy{
+		Attempts:      3,
+		PerTryTimeout: &metav1.Duration{Duration: 2 * time.Second},
+		Codes:         []int32{502, 503},
+	}
+	retry := BuildNativeRetry(policy)
+	if retry.Attempts == nil || *retry.Attempts != 3 {
+		t.Fatalf("unexpected attempts: %+v", retry.Attempts)
+	}
+	if retry.Backoff == nil || *retry.Backoff != gatewayapiv1.Duration("2s") {
+		t.Fatalf("unexpected backoff: %+v", retry.Backoff)
+	}
+	if len(retry.Codes) != 2 || retry.Codes[0] != gatewayapiv1.HTTPRouteRetryStatusCode(502) {
+		t.Fatalf("unexpected codes: %+v", retry.Codes)
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+// ComponentConditionType builds a component-scoped sub-condition type, e.g.
+// ComponentConditionType("predictor", "ResolvedRefs") ->
+// "PredictorRouteResolvedRefs", so every component's route-binding status can
+// be surfaced on the InferenceService without colliding with the aggregated
+// IngressReady condition or another component's sub-conditions.
+func ComponentConditionType(component, suffix string) apis.ConditionType {
+	return apis.ConditionType(titleCaseComponent(component) + "Route" + suffix)
+}
+
+func titleCaseComponent(component string) string {
+	if len(component) == 0 {
+		return component
+	}
+	return string(component[0]-'a'+'A') + component[1:]
+}
+
+// ComponentSubConditions builds the per-component Accepted/ResolvedRefs pair
+// for one ComponentRoute, mirroring AggregateClassifiedConditions but scoped
+// to a single component (e.g. "TransformerRouteAccepted") instead of rolled up
+// across every route the InferenceService generates.
+func ComponentSubConditions(route ComponentRoute) []apis.Condition {
+	acceptedType := ComponentConditionType(route.Component, "Accepted")
+	resolvedRefsType := ComponentConditionType(route.Component, "ResolvedRefs")
+	accepted := apis.Condition{Type: acceptedType, Status: corev1.ConditionTrue}
+	resolvedRefs := apis.Condition{Type: resolvedRefsType, Status: corev1.ConditionTrue}
+
+	var pending, permanent int
+	for _, c := range ClassifyParents(route.ParentRefs, route.RouteStatus) {
+		switch c.Outcome {
+		case ParentAccepted:
+			continue
+		case ParentPending:
+			pending++
+		default:
+			permanent++
+		}
+
+		switch c.Outcome {
+		case BackendNotFound, RefNotPermitted:
+			resolvedRefs = apis.Condition{Type: resolvedRefsType, Status: corev1.ConditionFalse, Reason: string(c.Outcome), Message: c.Message}
+		default:
+			accepted = apis.Condition{Type: acceptedType, Status: corev1.ConditionFalse, Reason: string(c.Outcome), Message: c.Message}
+		}
+	}
+
+	if permanent == 0 && pending > 0 && accepted.Status == corev1.ConditionTrue {
+		accepted = apis.Condition{Type: acceptedType, Status: corev1.ConditionUnknown, Reason: string(ParentPending), Message: "waiting for gateway controller to report status"}
+	}
+	return []apis.Condition{accepted, resolvedRefs}
+}
+
+// AllComponentSubConditions flattens ComponentSubConditions across every
+// route the reconciler generated, for callers that set all of an
+// InferenceService's conditions in one pass.
+func AllComponentSubConditions(routes []ComponentRoute) []apis.Condition {
+	var out []apis.Condition
+	for _, route := range routes {
+		out = append(out, ComponentSubConditions(route)...)
+	}
+	return out
+}
+
+// IngressRouteParentStatus is the machine-readable projection of one
+// RouteParentStatus entry, naming why a route isn't bound without the
+// underlying HTTPRoute/GRPCRoute object. No InferenceServiceStatus field
+// holds this type, so it does not appear on the InferenceService.
+type IngressRouteParentStatus struct {
+	ControllerName   string                 `json:"controllerName,omitempty"`
+	GatewayNamespace string                 `json:"gatewayNamespace"`
+	GatewayName      string                 `json:"gatewayName"`
+	Accepted         corev1.ConditionStatus `json:"accepted"`
+	ResolvedRefs     corev1.ConditionStatus `json:"resolvedRefs"`
+	Reason           string                 `json:"reason,omitempty"`
+	Message          string                 `json:"message,omitempty"`
+}
+
+// IngressRouteStatus describes a single generated route (top-level,
+// predictor, transformer, or explainer) and the binding status each of its
+// parents reported. No InferenceServiceStatus field holds this type.
+type IngressRouteStatus struct {
+	Component string                     `json:"component"`
+	Parents   []IngressRouteParentStatus `json:"parents,omitempty"`
+}
+
+// BuildIngressRouteStatuses projects every ComponentRoute's reported
+// RouteParentStatus entries into an IngressRouteStatus slice, one entry per
+// component with one IngressRouteParentStatus per parent that has actually
+// reported status (parents that haven't reported yet are represented by
+// AggregateComponentRoutes/ComponentSubConditions's Unknown status instead,
+// since there is nothing yet to report for them here).
+func BuildIngressRouteStatuses(routes []ComponentRoute) []IngressRouteStatus {
+	statuses := make([]IngressRouteStatus, 0, len(routes))
+	for _, route := range routes {
+		parents := make([]IngressRouteParentStatus, 0, len(route.RouteStatus.Parents))
+		for _, parentStatus := range route.RouteStatus.Parents {
+			classification := ClassifyParent(parentStatus.ParentRef, parentStatus, true)
+			accepted, resolvedRefs := corev1.ConditionFalse, corev1.ConditionFalse
+			switch classification.Outcome {
+			case ParentAccepted:
+				accepted, resolvedRefs = corev1.ConditionTrue, corev1.ConditionTrue
+			case ParentPending:
+				accepted, resolvedRefs = corev1.ConditionUnknown, corev1.ConditionUnknown
+			case BackendNotFound, RefNotPermitted:
+				accepted = corev1.ConditionUnknown
+			}
+			parents = append(parents, IngressRouteParentStatus{
+				ControllerName:   string(parentStatus.ControllerName),
+				GatewayNamespace: namespaceOf(parentStatus.ParentRef),
+				GatewayName:      string(parentStatus.ParentRef.Name),
+				Accepted:         accepted,
+				ResolvedRefs:     resolvedRefs,
+				Reason:           string(classification.Outcome),
+				Message:          classification.Message,
+			})
+		}
+		statuses = append(statuses, IngressRouteStatus{Component: route.Component, Parents: parents})
+	}
+	return statuses
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// RouteBindResult captures why a single parentRef did or did not bind, mirroring the
+// reason taxonomy used by Gateway API implementations (e.g. Consul's API Gateway
+// controller) so operators get a consistent, actionable message regardless of
+// which gateway rejected the route.
+type RouteBindResult struct {
+	ParentRef gatewayapiv1.ParentReference
+	Accepted  bool
+	Reason    string
+	Message   string
+}
+
+// PerParentConditionType builds the sub-condition type recorded on the InferenceService
+// for a single HTTPRoute parentRef, e.g. "IngressReady/istio-system/kserve-ingress-gateway".
+func PerParentConditionType(parentRef gatewayapiv1.ParentReference) apis.ConditionType {
+	namespace := "default"
+	if parentRef.Namespace != nil {
+		namespace = string(*parentRef.Namespace)
+	}
+	return apis.ConditionType(fmt.Sprintf("IngressReady/%s/%s", namespace, parentRef.Name))
+}
+
+// ComputeParentBindResults inspects RouteStatus.Parents (as populated by the gateway
+// controller) and returns one RouteBindResult per configured parentRef, including parents
+// that have not reported status yet.
+func ComputeParentBindResults(parentRefs []gatewayapiv1.ParentReference, routeStatus gatewayapiv1.RouteStatus) []RouteBindResult {
+	results := make([]RouteBindResult, 0, len(parentRefs))
+	for _, parentRef := range parentRefs {
+		parentStatus, ok := findParentStatus(routeStatus.Parents, parentRef)
+		if !ok {
+			results = append(results, RouteBindResult{
+				ParentRef: parentRef,
+				Accepted:  false,
+				Reason:    "Pending",
+				Message:   "gateway has not yet reported status for this parentRef",
+			})
+			continue
+		}
+		results = append(results, bindResultFromConditions(parentRef, parentStatus.Conditions))
+	}
+	return results
+}
+
+func findParentStatus(parents []gatewayapiv1.RouteParentStatus, parentRef gatewayapiv1.ParentReference) (gatewayapiv1.RouteParentStatus, bool) {
+	for _, parent := range parents {
+		if parentRefsEqual(parent.ParentRef, parentRef) {
+			return parent, true
+		}
+	}
+	return gatewayapiv1.RouteParentStatus{}, false
+}
+
+func parentRefsEqual(a, b gatewayapiv1.ParentReference) bool {
+	if a.Name != b.Name {
+		return false
+	}
+	if (a.Namespace == nil) != (b.Namespace == nil) {
+		return false
+	}
+	if a.Namespace != nil && *a.Namespace != *b.Namespace {
+		return false
+	}
+	if (a.SectionName == nil) != (b.SectionName == nil) {
+		return false
+	}
+	if a.SectionName != nil && *a.SectionName != *b.SectionName {
+		return false
+	}
+	return true
+}
+
+// bindResultFromConditions translates the Accepted/ResolvedRefs conditions
+// reported by the gateway into a single RouteBindResult with a human-readable reason.
+func bindResultFromConditions(parentRef gatewayapiv1.ParentReference, conditions []metav1.Condition) RouteBindResult {
+	accepted := conditionStatus(conditions, string(gatewayapiv1.RouteConditionAccepted))
+	resolvedRefs := conditionStatus(conditions, string(gatewayapiv1.RouteConditionResolvedRefs))
+
+	switch {
+	case accepted != nil && accepted.Status != metav1.ConditionTrue:
+		return RouteBindResult{ParentRef: parentRef, Accepted: false, Reason: accepted.Reason, Message: accepted.Message}
+	case resolvedRefs != nil && resolvedRefs.Status != metav1.ConditionTrue:
+		return RouteBindResult{ParentRef: parentRef, Accepted: false, Reason: resolvedRefs.Reason, Message: resolvedRefs.Message}
+	case accepted != nil && accepted.Status == metav1.ConditionTrue:
+		return RouteBindResult{ParentRef: parentRef, Accepted: true, Reason: accepted.Reason, Message: accepted.Message}
+	default:
+		return RouteBindResult{
+			ParentRef: parentRef,
+			Accepted:  false,
+			Reason:    "Pending",
+			Message:   "gateway has not yet reported an Accepted condition for this parentRef",
+		}
+	}
+}
+
+func conditionStatus(conditions []metav1.Condition, conditionType string) *metav1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// PerParentConditions converts each RouteBindResult into the apis.Condition that should be
+// set on the InferenceService, keyed by PerParentConditionType.
+func PerParentConditions(results []RouteBindResult) []apis.Condition {
+	out := make([]apis.Condition, 0, len(results))
+	for _, result := range results {
+		status := corev1.ConditionFalse
+		if result.Accepted {
+			status = corev1.ConditionTrue
+		}
+		out = append(out, apis.Condition{
+			Type:    PerParentConditionType(result.ParentRef),
+			Status:  status,
+			Reason:  result.Reason,
+			Message: result.Message,
+		})
+	}
+	return out
+}
+
+// ComputeMultiRouteBindResults is ComputeParentBindResults extended to cover
+// an InferenceService that generates more than one route kind for the same
+// component, e.g. an HTTPRoute and a sibling GRPCRoute for a gRPC-native
+// predictor. The parentRefs are assumed to be the same across route kinds
+// (they share a parent gateway), so results from every route's
+// RouteStatus.Parents are concatenated and fed to AggregateIngressReady
+// together, meaning IngressReady is True without the PartiallyBound warning
+// only once every route is bound.
+func ComputeMultiRouteBindResults(parentRefs []gatewayapiv1.ParentReference, routeStatuses ...gatewayapiv1.RouteStatus) []RouteBindResult {
+	var results []RouteBindResult
+	for _, routeStatus := range routeStatuses {
+		results = append(results, ComputeParentBindResults(parentRefs, routeStatus)...)
+	}
+	return results
+}
+
+// AggregateIngressReady rolls the per-parent bind results up into the top-level
+// IngressReady condition: True if at least one parent accepted the route, with
+// Severity Warning (rather than a hard failure) when some parents are degraded.
+func AggregateIngressReady(results []RouteBindResult) apis.Condition {
+	var acceptedCount, total int
+	var lastDegradedMessage string
+	for _, result := range results {
+		total++
+		if result.Accepted {
+			acceptedCount++
+		} else {
+			lastDegradedMessage = result.Message
+		}
+	}
+
+	switch {
+	case total == 0:
+		return apis.Condition{Status: corev1.ConditionUnknown, Reason: "NoParents", Message: "no parentRefs configured"}
+	case acceptedCount == total:
+		return apis.Condition{Status: corev1.ConditionTrue}
+	case acceptedCount > 0:
+		return apis.Condition{
+			Status:   corev1.ConditionTrue,
+			Severity: apis.ConditionSeverityWarning,
+			Reason:   "PartiallyBound",
+			Message:  fmt.Sprintf("%d/%d gateway parents accepted the route; last failure: %s", acceptedCount, total, lastDegradedMessage),
+		}
+	default:
+		return apis.Condition{
+			Status:  corev1.ConditionFalse,
+			Reason:  "NotBound",
+			Message: fmt.Sprintf("no gateway parent accepted the route: %s", lastDegradedMessage),
+		}
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestPerParentConditionType(t *testing.T) {
+	withNamespace := gatewayapiv1.ParentReference{Name: "gw", Namespace: ptr.To(gatewayapiv1.Namespace("istio-system"))}
+	if got := PerParentConditionType(withNamespace); got != "IngressReady/istio-system/gw" {
+		t.Fatalf("unexpected condition type: %q", got)
+	}
+	noNamespace := gatewayapiv1.ParentReference{Name: "gw"}
+	if got := PerParentConditionType(noNamespace); got != "IngressReady/default/gw" {
+		t.Fatalf("expected a default namespace fallback, got %q", got)
+	}
+}
+
+func acceptedParentStatus(parentRef gatewayapiv1.ParentReference) gatewayapiv1.RouteParentStatus {
+	return gatewayapiv1.RouteParentStatus{
+		ParentRef: parentRef,
+		Conditions: []metav1.Condition{
+			{Type: string(gatewayapiv1.RouteConditionAccepted), Status: metav1.ConditionTrue, Reason: "Accepted"},
+			{Type: string(gatewayapiv1.RouteConditionResolvedRefs), Status: metav1.ConditionTrue, Reason: "ResolvedRefs"},
+		},
+	}
+}
+
+func TestComputeParentBindResultsPending(t *testing.T) {
+	parentRefs := []gatewayapiv1.ParentReference{{Name: "gw"}}
+	results := ComputeParentBindResults(parentRefs, gatewayapiv1.RouteStatus{})
+	if len(results) != 1 || results[0].Accepted || results[0].Reason != "Pending" {
+		t.Fatalf("expected a single pending result, got %+v", results)
+	}
+}
+
+func TestComputeParentBindResultsAccepted(t *testing.T) {
+	parentRef := gatewayapiv1.ParentReference{Name: "gw"}
+	routeStatus := gatewayapiv1.RouteStatus{Parents: []gatewayapiv1.RouteParentStatus{acceptedParentStatus(parentRef)}}
+	results := ComputeParentBindResults([]gatewayapiv1.ParentReference{parentRef}, routeStatus)
+	if len(results) != 1 || !results[0].Accepted {
+		t.Fatalf("expected the parent to be accepted, got %+v", results)
+	}
+}
+
+func TestComputeParentBindResultsAcceptedFalse(t *testing.T) {
+	parentRef := gatewayapiv1.ParentReference{Name: "gw"}
+	routeStatus := gatewayapiv1.RouteStatus{Parents: []gatewayapiv1.RouteParentStatus{{
+		ParentRef: parentRef,
+		Conditions: []metav1.Condition{
+			{Type: string(gatewayapiv1.RouteConditionAccepted), Status: metav1.ConditionFalse, Reason: "NoMatchingListenerHostname", Message: "no listener matched"},
+		},
+	}}}
+	results := ComputeParentBindResults([]gatewayapiv1.ParentReference{parentRef}, routeStatus)
+	if len(results) != 1 || results[0].Accepted || results[0].Reason != "NoMatchingListenerHostname" {
+		t.Fatalf("unexpected result: %+v", results[0])
+	}
+}
+
+func TestComputeParentBindResultsResolvedRefsFalseTakesPrecedence(t *testing.T) {
+	parentRef := gatewayapiv1.ParentReference{Name: "gw"}
+	routeStatus := gatewayapiv1.RouteStatus{Parents: []gatewayapiv1.RouteParentStatus{{
+		ParentRef: parentRef,
+		Conditions: []metav1.Condition{
+			{Type: string(gatewayapiv1.RouteConditionAccepted), Status: metav1.ConditionTrue, Reason: "Accepted"},
+			{Type: string(gatewayapiv1.RouteConditionResolvedRefs), Status: metav1.ConditionFalse, Reason: "BackendNotFound", Message: "service not found"},
+		},
+	}}}
+	results := ComputeParentBindResults([]gatewayapiv1.ParentReference{parentRef}, routeStatus)
+	if len(results) != 1 || results[0].Accepted || results[0].Reason != "BackendNotFound" {
+		t.Fatalf("expected ResolvedRefs=False to override Accepted=True, got %+v", results[0])
+	}
+}
+
+func TestPerParentConditions(t *testing.T) {
+	results := []RouteBindResult{
+		{ParentRef: gatewayapiv1.ParentReference{Name: "gw-a"}, Accepted: true},
+		{ParentRef: gatewayapiv1.ParentReference{Name: "gw-b"}, Accepted: false, Reason: "Pending"},
+	}
+	conditions := PerParentConditions(results)
+	if len(conditions) != 2 {
+		t.Fatalf("expected one condition per result, got %+v", conditions)
+	}
+	if conditions[0].Status != corev1.ConditionTrue || conditions[1].Status != corev1.ConditionFalse {
+		t.Fatalf("unexpected condition statuses: %+v", conditions)
+	}
+}
+
+func TestComputeMultiRouteBindResultsConcatenates(t *testing.T) {
+	parentRef := gatewayapiv1.ParentReference{Name: "gw"}
+	accepted := gatewayapiv1.RouteStatus{Parents: []gatewayapiv1.RouteParentStatus{acceptedParentStatus(parentRef)}}
+	pending := gatewayapiv1.RouteStatus{}
+	results := ComputeMultiRouteBindResults([]gatewayapiv1.ParentReference{parentRef}, accepted, pending)
+	if len(results) != 2 {
+		t.Fatalf("expected results from both route statuses, got %+v", results)
+	}
+	if !results[0].Accepted || results[1].Accepted {
+		t.Fatalf("unexpected aggregated results: %+v", results)
+	}
+}
+
+func TestAggregateIngressReady(t *testing.T) {
+	cases := []struct {
+		name       string
+		results    []RouteBindResult
+		wantStatus corev1.ConditionStatus
+		wantReason string
+	}{
+		{name: "no parents", results: nil, wantStatus: corev1.ConditionUnknown, wantReason: "NoParents"},
+		{name: "all accepted", results: []RouteBindResult{{Accepted: true}, {Accepted: true}}, wantStatus: corev1.ConditionTrue, wantReason: ""},
+		{name: "partially bound", results: []RouteBindResult{{Accepted: true}, {Accepted: false, Message: "down"}}, wantStatus: corev1.ConditionTrue, wantReason: "PartiallyBound"},
+		{name: "none bound", results: []RouteBindResult{{Accepted: false, Message: "down"}}, wantStatus: corev1.ConditionFalse, wantReason: "NotBound"},
+	}
+	for _, tc := range cases {
+		got := AggregateIngressReady(tc.results)
+		if got.Status != tc.wantStatus || got.Reason != tc.wantReason {
+			t.Fatalf("%s: AggregateIngressReady() = %+v, want status=%v reason=%q", tc.name, got, tc.wantStatus, tc.wantReason)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"knative.dev/pkg/apis"
+)
+
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceroutetimeoutpolicies;inferencerouteretrypolicies;inferencerouteheaderpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceroutetimeoutpolicies/status;inferencerouteretrypolicies/status;inferencerouteheaderpolicies/status,verbs=get;update;patch
+
+// RoutePolicyKind enumerates the policy-attachment CRDs an InferenceService's
+// generated HTTPRoute can bind to, following the direct/back-reference
+// convention also used for the Kuadrant AuthPolicy/RateLimitPolicy bindings
+// (see kuadrant_policy.go).
+type RoutePolicyKind string
+
+const (
+	TimeoutPolicyKind RoutePolicyKind = "InferenceRouteTimeoutPolicy"
+	RetryPolicyKind   RoutePolicyKind = "InferenceRouteRetryPolicy"
+	HeaderPolicyKind  RoutePolicyKind = "InferenceRouteHeaderPolicy"
+)
+
+// DirectPolicyAnnotationKey is set on the InferenceService pointing at the
+// policy object bound to it, e.g. "kserve.io/timeoutpolicy" for
+// TimeoutPolicyKind.
+func DirectPolicyAnnotationKey(kind RoutePolicyKind) string {
+	return fmt.Sprintf("kserve.io/%s", policySingular(kind))
+}
+
+// BackReferenceAnnotationKey is set on the policy object listing every
+// InferenceService currently bound to it, e.g. "kserve.io/timeoutpolicies",
+// so tooling can discover bindings from either direction without a list call.
+func BackReferenceAnnotationKey(kind RoutePolicyKind) string {
+	return fmt.Sprintf("kserve.io/%sies", strings.TrimSuffix(policySingular(kind), "y"))
+}
+
+func policySingular(kind RoutePolicyKind) string {
+	switch kind {
+	case TimeoutPolicyKind:
+		return "timeoutpolicy"
+	case RetryPolicyKind:
+		return "retrypolicy"
+	case HeaderPolicyKind:
+		return "headerpolicy"
+	default:
+		return "policy"
+	}
+}
+
+// RoutePolicyTargetRef is the targetRef every policy-attachment CRD carries,
+// naming either a single InferenceService or a label selector for
+// fleet-wide defaults, mirroring Gateway API's PolicyTargetReference.
+type RoutePolicyTargetRef struct {
+	Name          string
+	LabelSelector map[string]string
+}
+
+// Matches reports whether this targetRef binds to an InferenceService with
+// the given name/labels: an exact Name match always wins; otherwise every
+// entry in LabelSelector must be present and equal in the isvc's labels.
+func (ref RoutePolicyTargetRef) Matches(isvcName string, isvcLabels map[string]string) bool {
+	if ref.Name != "" {
+		return ref.Name == isvcName
+	}
+	if len(ref.LabelSelector) == 0 {
+		return false
+	}
+	for k, v := range ref.LabelSelector {
+		if isvcLabels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// ResolveClosestPolicy picks the best-matching policy for an InferenceService
+// among candidates already known to match (see Matches): a targetRef naming
+// the isvc directly always beats a label-selector default, and ties among
+// label-selector matches are broken by the most specific (largest) selector.
+func ResolveClosestPolicy(isvcName string, isvcLabels map[string]string, candidates []RoutePolicyTargetRef) (RoutePolicyTargetRef, bool) {
+	var best RoutePolicyTargetRef
+	found := false
+	for _, c := range candidates {
+		if !c.Matches(isvcName, isvcLabels) {
+			continue
+		}
+		switch {
+		case !found:
+			best, found = c, true
+		case c.Name != "" && best.Name == "":
+			best = c
+		case c.Name == "" && best.Name == "" && len(c.LabelSelector) > len(best.LabelSelector):
+			best = c
+		}
+	}
+	return best, found
+}
+
+// RoutePolicyEnforcedConditionType is reported on the policy object itself
+// (not the InferenceService) indicating whether it successfully attached to
+// at least one target.
+const RoutePolicyEnforcedConditionType apis.ConditionType = "Enforced"
+
+// EnforcedCondition builds the Enforced condition for a policy object given
+// how many InferenceServices it ended up bound to.
+func EnforcedCondition(boundCount int) apis.Condition {
+	if boundCount == 0 {
+		return apis.Condition{
+			Type: RoutePolicyEnforcedConditionType, Status: corev1.ConditionFalse,
+			Reason: "NoMatchingTargets", Message: "targetRef matched no InferenceService",
+		}
+	}
+	return apis.Condition{
+		Type: RoutePolicyEnforcedConditionType, Status: corev1.ConditionTrue,
+		Reason: "Bound", Message: fmt.Sprintf("bound to %d InferenceService(s)", boundCount),
+	}
+}
+
+// SetBackReferenceAnnotation writes the sorted, de-duplicated list of
+// InferenceService names bound to a policy object onto
+// BackReferenceAnnotationKey, so the annotation's value is stable across
+// reconciles regardless of map/slice iteration order.
+func SetBackReferenceAnnotation(policy *unstructured.Unstructured, kind RoutePolicyKind, isvcNames []string) {
+	unique := make(map[string]bool, len(isvcNames))
+	for _, name := range isvcNames {
+		unique[name] = true
+	}
+	sorted := make([]string, 0, len(unique))
+	for name := range unique {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	annotations := policy.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	joined := ""
+	for i, name := range sorted {
+		if i > 0 {
+			joined += ","
+		}
+		joined += name
+	}
+	annotations[BackReferenceAnnotationKey(kind)] = joined
+	policy.SetAnnotations(annotations)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDirectAndBackReferenceAnnotationKeys(t *testing.T) {
+	cases := []struct {
+		kind    RoutePolicyKind
+		direct  string
+		backRef string
+	}{
+		{kind: TimeoutPolicyKind, direct: "kserve.io/timeoutpolicy", backRef: "kserve.io/timeoutpolicies"},
+		{kind: RetryPolicyKind, direct: "kserve.io/retrypolicy", backRef: "kserve.io/retrypolicies"},
+		{kind: HeaderPolicyKind, direct: "kserve.io/headerpolicy", backRef: "kserve.io/headerpolicies"},
+	}
+	for _, tc := range cases {
+		if got := DirectPolicyAnnotationKey(tc.kind); got != tc.direct {
+			t.Fatalf("DirectPolicyAnnotationKey(%v) = %q, want %q", tc.kind, got, tc.direct)
+		}
+		if got := BackReferenceAnnotationKey(tc.kind); got != tc.backRef {
+			t.Fatalf("BackReferenceAnnotationKey(%v) = %q, want %q", tc.kind, got, tc.backRef)
+		}
+	}
+}
+
+func TestRoutePolicyTargetRefMatches(t *testing.T) {
+	cases := []struct {
+		name       string
+		ref        RoutePolicyTargetRef
+		isvcName   string
+		isvcLabels map[string]string
+		want       bool
+	}{
+		{name: "exact name match", ref: RoutePolicyTargetRef{Name: "isvc-a"}, isvcName: "isvc-a", want: true},
+		{name: "exact name mismatch ignores labels", ref: RoutePolicyTargetRef{Name: "isvc-a"}, isvcName: "isvc-b", isvcLabels: map[string]string{"team": "a"}, want: false},
+		{name: "label selector match", ref: RoutePolicyTargetRef{LabelSelector: map[string]string{"team": "a"}}, isvcName: "isvc-b", isvcLabels: map[string]string{"team": "a", "env": "prod"}, want: true},
+		{name: "label selector mismatch", ref: RoutePolicyTargetRef{LabelSelector: map[string]string{"team": "a"}}, isvcName: "isvc-b", isvcLabels: map[string]string{"team": "b"}, want: false},
+		{name: "empty selector matches nothing", ref: RoutePolicyTargetRef{}, isvcName: "isvc-b", want: false},
+	}
+	for _, tc := range cases {
+		if got := tc.ref.Matches(tc.isvcName, tc.isvcLabels); got != tc.want {
+			t.Fatalf("%s: Matches() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestResolveClosestPolicyPrefersDirectNameOverLabelSelector(t *testing.T) {
+	candidates := []RoutePolicyTargetRef{
+		{LabelSelector: map[string]string{"team": "a"}},
+		{Name: "isvc-a"},
+	}
+	best, found := ResolveClosestPolicy("isvc-a", map[string]string{"team": "a"}, candidates)
+	if !found || best.Name != "isvc-a" {
+		t.Fatalf("expected the direct name match to win, got %+v, found=%v", best, found)
+	}
+}
+
+func TestResolveClosestPolicyPrefersMoreSpecificLabelSelector(t *testing.T) {
+	candidates := []RoutePolicyTargetRef{
+		{LabelSelector: map[string]string{"team": "a"}},
+		{LabelSelector: map[string]string{"team": "a", "env": "prod"}},
+	}
+	best, found := ResolveClosestPolicy("isvc-a", map[string]string{"team": "a", "env": "prod"}, candidates)
+	if !found || len(best.LabelSelector) != 2 {
+		t.Fatalf("expected the more specific selector to win, got %+v, found=%v", best, found)
+	}
+}
+
+func TestResolveClosestPolicyNoMatch(t *testing.T) {
+	candidates := []RoutePolicyTargetRef{{Name: "other-isvc"}}
+	if _, found := ResolveClosestPolicy("isvc-a", nil, candidates); found {
+		t.Fatalf("expected no match")
+	}
+}
+
+func TestEnforcedCondition(t *testing.T) {
+	if condition := EnforcedCondition(0); condition.Status != corev1.ConditionFalse || condition.Reason != "NoMatchingTargets" {
+		t.Fatalf("expected NoMatchingTargets when boundCount is 0, got %+v", condition)
+	}
+	if condition := EnforcedCondition(2); condition.Status != corev1.ConditionTrue || condition.Reason != "Bound" {
+		t.Fatalf("expected Bound when boundCount > 0, got %+v", condition)
+	}
+}
+
+func TestSetBackReferenceAnnotationSortsAndDedupes(t *testing.T) {
+	policy := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	SetBackReferenceAnnotation(policy, TimeoutPolicyKind, []string{"isvc-b", "isvc-a", "isvc-b"})
+	annotations := policy.GetAnnotations()
+	if got := annotations[BackReferenceAnnotationKey(TimeoutPolicyKind)]; got != "isvc-a,isvc-b" {
+		t.Fatalf("expected a sorted, de-duplicated annotation value, got %q", got)
+	}
+}
+
+func TestSetBackReferenceAnnotationPreservesExistingAnnotations(t *testing.T) {
+	policy := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	policy.SetAnnotations(map[string]string{"other": "value"})
+	SetBackReferenceAnnotation(policy, RetryPolicyKind, []string{"isvc-a"})
+	annotations := policy.GetAnnotations()
+	if annotations["other"] != "value" {
+		t.Fatalf("expected the pre-existing annotation to be preserved, got %+v", annotations)
+	}
+	if annotations[BackReferenceAnnotationKey(RetryPolicyKind)] != "isvc-a" {
+		t.Fatalf("expected the back-reference annotation to be set, got %+v", annotations)
+	}
+}
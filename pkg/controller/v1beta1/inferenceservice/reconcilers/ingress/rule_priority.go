@@ -0,0 +1,238 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// RulePriorityAnnotationKey records the score SortRulesByPriority computed for
+// a rule's match, so operators can debug why one InferenceService's route
+// shadowed another's on a shared Gateway without re-deriving the scoring by
+// hand.
+const RulePriorityAnnotationKey = "serving.kserve.io/rule-priority"
+
+// RoutePriorityAnnotationKey records the same top-rule priority score as
+// RulePriorityAnnotationKey, under the name ApplyRulePriority's callers
+// expect when reconciling a route object end to end rather than just
+// computing an ordering in isolation.
+const RoutePriorityAnnotationKey = "serving.kserve.io/route-priority"
+
+// ruleScore is the precedence tuple from the HTTPRoute spec's match
+// specificity rules, extended with a namespace/name/creationTimestamp
+// tie-breaker so ordering is deterministic across InferenceServices sharing a
+// Gateway, independent of reconcile order.
+type ruleScore struct {
+	hostnameExact        bool
+	pathMatchRank        int
+	exactPath            bool
+	pathPrefixLength     int
+	methodPresent        bool
+	headerMatchCount     int
+	queryParamMatchCount int
+	creationTimestamp    time.Time
+	namespace, name      string
+}
+
+// ScoredRule pairs one single-match HTTPRouteRule with the owning
+// InferenceService's identity and creation time, the inputs SortRulesByPriority
+// needs to order rules from multiple InferenceServices sharing a Gateway.
+type ScoredRule struct {
+	Rule              gatewayapiv1.HTTPRouteRule
+	Namespace         string
+	Name              string
+	CreationTimestamp time.Time
+	// Hostnames is the owning route's Spec.Hostnames, used to rank an
+	// exact-hostname route ahead of one relying on a wildcard hostname.
+	Hostnames []gatewayapiv1.Hostname
+}
+
+// pathMatchRank orders HTTPRoute path match types by specificity: Exact is
+// most specific, then PathPrefix, then RegularExpression (implementation-
+// defined precedence relative to prefix matches, so treated as least
+// specific here), and finally no path match at all.
+func pathMatchRank(matchType *gatewayapiv1.PathMatchType) int {
+	if matchType == nil {
+		return 3
+	}
+	switch *matchType {
+	case gatewayapiv1.PathMatchExact:
+		return 0
+	case gatewayapiv1.PathMatchPathPrefix:
+		return 1
+	case gatewayapiv1.PathMatchRegularExpression:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// hasOnlyExactHostnames reports whether every hostname in hostnames is a
+// precise hostname (no leading "*." wildcard label), so a route scoped to
+// "foo.example.com" outranks one relying on "*.example.com" when both would
+// otherwise match a request. A route with no hostnames (matches any) is
+// treated as non-exact, the least specific case.
+func hasOnlyExactHostnames(hostnames []gatewayapiv1.Hostname) bool {
+	if len(hostnames) == 0 {
+		return false
+	}
+	for _, h := range hostnames {
+		if strings.HasPrefix(string(h), "*.") {
+			return false
+		}
+	}
+	return true
+}
+
+// SplitMultiMatchRules expands every rule with more than one entry in
+// Matches into one single-match rule per entry (sharing the same
+// filters/backendRefs), so each can be scored independently: a rule combining
+// an exact-path match and a prefix match has two different specificities and
+// must not be scored as a single unit.
+func SplitMultiMatchRules(rules []gatewayapiv1.HTTPRouteRule) []gatewayapiv1.HTTPRouteRule {
+	split := make([]gatewayapiv1.HTTPRouteRule, 0, len(rules))
+	for _, rule := range rules {
+		if len(rule.Matches) <= 1 {
+			split = append(split, rule)
+			continue
+		}
+		for _, match := range rule.Matches {
+			single := rule
+			single.Matches = []gatewayapiv1.HTTPRouteMatch{match}
+			split = append(split, single)
+		}
+	}
+	return split
+}
+
+// scoreRule computes the match-specificity tuple for one single-match rule.
+// Rules with no Matches (a catch-all, e.g. the fallback rule) score as the
+// least specific: empty path, no method, no header/query matches.
+func scoreRule(rule gatewayapiv1.HTTPRouteRule, namespace, name string, creationTimestamp time.Time, hostnames []gatewayapiv1.Hostname) ruleScore {
+	score := ruleScore{namespace: namespace, name: name, creationTimestamp: creationTimestamp, pathMatchRank: 3, hostnameExact: hasOnlyExactHostnames(hostnames)}
+	if len(rule.Matches) == 0 {
+		return score
+	}
+	match := rule.Matches[0]
+	if match.Path != nil {
+		score.pathMatchRank = pathMatchRank(match.Path.Type)
+		score.exactPath = score.pathMatchRank == 0
+	}
+	if match.Path != nil && match.Path.Value != nil {
+		score.pathPrefixLength = len(*match.Path.Value)
+	}
+	score.methodPresent = match.Method != nil
+	score.headerMatchCount = len(match.Headers)
+	score.queryParamMatchCount = len(match.QueryParams)
+	return score
+}
+
+// less reports whether a should sort ahead of b, i.e. a is more specific (or,
+// when equally specific, was created first / sorts first by namespace/name).
+func (a ruleScore) less(b ruleScore) bool {
+	if a.hostnameExact != b.hostnameExact {
+		return a.hostnameExact
+	}
+	if a.pathMatchRank != b.pathMatchRank {
+		return a.pathMatchRank < b.pathMatchRank
+	}
+	if a.pathPrefixLength != b.pathPrefixLength {
+		return a.pathPrefixLength > b.pathPrefixLength
+	}
+	if a.headerMatchCount != b.headerMatchCount {
+		return a.headerMatchCount > b.headerMatchCount
+	}
+	if a.queryParamMatchCount != b.queryParamMatchCount {
+		return a.queryParamMatchCount > b.queryParamMatchCount
+	}
+	if a.methodPresent != b.methodPresent {
+		return a.methodPresent
+	}
+	if a.namespace != b.namespace {
+		return a.namespace < b.namespace
+	}
+	if a.name != b.name {
+		return a.name < b.name
+	}
+	return a.creationTimestamp.Before(b.creationTimestamp)
+}
+
+// formatRulePriority renders a ruleScore as the RulePriorityAnnotationKey
+// value, e.g. "hostnameExact=true,pathMatchRank=0,prefixLen=12,method=false,headers=1,query=0".
+func formatRulePriority(score ruleScore) string {
+	return fmt.Sprintf("hostnameExact=%t,pathMatchRank=%d,prefixLen=%d,method=%t,headers=%d,query=%d",
+		score.hostnameExact, score.pathMatchRank, score.pathPrefixLength, score.methodPresent, score.headerMatchCount, score.queryParamMatchCount)
+}
+
+// SortRulesByPriority splits any multi-match rules, scores every resulting
+// single-match rule by HTTPRoute match-precedence specificity, and returns
+// them sorted most-specific first, along with the annotation value to
+// attach to each owning HTTPRoute recording the top (most specific) score.
+func SortRulesByPriority(rules []ScoredRule) ([]gatewayapiv1.HTTPRouteRule, string) {
+	type scored struct {
+		rule  gatewayapiv1.HTTPRouteRule
+		score ruleScore
+	}
+
+	var all []scored
+	for _, sr := range rules {
+		for _, single := range SplitMultiMatchRules([]gatewayapiv1.HTTPRouteRule{sr.Rule}) {
+			all = append(all, scored{rule: single, score: scoreRule(single, sr.Namespace, sr.Name, sr.CreationTimestamp, sr.Hostnames)})
+		}
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].score.less(all[j].score)
+	})
+
+	ordered := make([]gatewayapiv1.HTTPRouteRule, 0, len(all))
+	var topAnnotation string
+	for i, s := range all {
+		ordered = append(ordered, s.rule)
+		if i == 0 {
+			topAnnotation = formatRulePriority(s.score)
+		}
+	}
+	return ordered, topAnnotation
+}
+
+// ApplyRulePriority reorders route's Spec.Rules most-specific first using
+// SortRulesByPriority and records the resulting top-rule score under
+// RoutePriorityAnnotationKey, so a single HTTPRoute spanning several
+// InferenceService-owned rules (the top-level fallback/prefix rules plus any
+// per-component rules attached to it) ends up with both a deterministic rule
+// order and a human-inspectable priority annotation in one call.
+func ApplyRulePriority(route *gatewayapiv1.HTTPRoute, namespace, name string, creationTimestamp time.Time) {
+	scored := make([]ScoredRule, 0, len(route.Spec.Rules))
+	for _, rule := range route.Spec.Rules {
+		scored = append(scored, ScoredRule{Rule: rule, Namespace: namespace, Name: name, CreationTimestamp: creationTimestamp, Hostnames: route.Spec.Hostnames})
+	}
+	ordered, topAnnotation := SortRulesByPriority(scored)
+	route.Spec.Rules = ordered
+	if topAnnotation == "" {
+		return
+	}
+	if route.Annotations == nil {
+		route.Annotations = map[string]string{}
+	}
+	route.Annotations[RoutePriorityAnnotationKey] = topAnnotation
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/utils/ptr"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestSplitMultiMatchRules(t *testing.T) {
+	rule := gatewayapiv1.HTTPRouteRule{
+		Matches: []gatewayapiv1.HTTPRouteMatch{
+			{Path: &gatewayapiv1.HTTPPathMatch{Type: ptr.To(gatewayapiv1.PathMatchExact), Value: ptr.To("/a")}},
+			{Path: &gatewayapiv1.HTTPPathMatch{Type: ptr.To(gatewayapiv1.PathMatchPathPrefix), Value: ptr.To("/b")}},
+		},
+	}
+	split := SplitMultiMatchRules([]gatewayapiv1.HTTPRouteRule{rule})
+	if len(split) != 2 {
+		t.Fatalf("expected a rule per match, got %+v", split)
+	}
+	if len(split[0].Matches) != 1 || *split[0].Matches[0].Path.Value != "/a" {
+		t.Fatalf("unexpected first split rule: %+v", split[0])
+	}
+	if len(split[1].Matches) != 1 || *split[1].Matches[0].Path.Value != "/b" {
+		t.Fatalf("unexpected second split rule: %+v", split[1])
+	}
+}
+
+func TestSplitMultiMatchRulesSingleMatchUnchanged(t *testing.T) {
+	rule := gatewayapiv1.HTTPRouteRule{Matches: []gatewayapiv1.HTTPRouteMatch{{}}}
+	split := SplitMultiMatchRules([]gatewayapiv1.HTTPRouteRule{rule})
+	if len(split) != 1 {
+		t.Fatalf("expected a single-match rule to pass through unchanged, got %+v", split)
+	}
+}
+
+func scoredRule(opts ...func(*ScoredRule)) ScoredRule {
+	sr := ScoredRule{Namespace: "ns", Name: "name", CreationTimestamp: time.Unix(0, 0)}
+	for _, opt := range opts {
+		opt(&sr)
+	}
+	return sr
+}
+
+func withExactPath(path string) func(*ScoredRule) {
+	return func(sr *ScoredRule) {
+		sr.Rule.Matches = []gatewayapiv1.HTTPRouteMatch{
+			{Path: &gatewayapiv1.HTTPPathMatch{Type: ptr.To(gatewayapiv1.PathMatchExact), Value: ptr.To(path)}},
+		}
+	}
+}
+
+func TestSortRulesByPriorityHeadersOutrankMethod(t *testing.T) {
+	headerRule := scoredRule()
+	headerRule.Rule.Matches = []gatewayapiv1.HTTPRouteMatch{{Headers: []gatewayapiv1.HTTPHeaderMatch{{Name: "x", Value: "y"}}}}
+	methodRule := scoredRule()
+	methodRule.Rule.Matches = []gatewayapiv1.HTTPRouteMatch{{Method: ptr.To(gatewayapiv1.HTTPMethodGet)}}
+
+	ordered, _ := SortRulesByPriority([]ScoredRule{methodRule, headerRule})
+	if len(ordered[0].Matches) == 0 || len(ordered[0].Matches[0].Headers) != 1 {
+		t.Fatalf("expected the header-matching rule to outrank the method-only rule, got order %+v", ordered)
+	}
+}
+
+func TestSortRulesByPriorityQueryParamsOutrankMethod(t *testing.T) {
+	queryRule := scoredRule()
+	queryRule.Rule.Matches = []gatewayapiv1.HTTPRouteMatch{{QueryParams: []gatewayapiv1.HTTPQueryParamMatch{{Name: "q", Value: "v"}}}}
+	methodRule := scoredRule()
+	methodRule.Rule.Matches = []gatewayapiv1.HTTPRouteMatch{{Method: ptr.To(gatewayapiv1.HTTPMethodGet)}}
+
+	ordered, _ := SortRulesByPriority([]ScoredRule{methodRule, queryRule})
+	if len(ordered[0].Matches) == 0 || len(ordered[0].Matches[0].QueryParams) != 1 {
+		t.Fatalf("expected the query-param-matching rule to outrank the method-only rule, got order %+v", ordered)
+	}
+}
+
+func TestSortRulesByPriorityTieBreaksOnNamespaceThenName(t *testing.T) {
+	// Both rules are catch-alls (equal specificity), so namespace must decide
+	// the order ahead of creationTimestamp, even though "z-ns" was created
+	// first.
+	laterCreatedButEarlierNamespace := scoredRule(func(sr *ScoredRule) { sr.Namespace = "a-ns"; sr.CreationTimestamp = time.Unix(200, 0) })
+	earlierCreatedButLaterNamespace := scoredRule(func(sr *ScoredRule) { sr.Namespace = "z-ns"; sr.CreationTimestamp = time.Unix(100, 0) })
+
+	_, topAnnotation := SortRulesByPriority([]ScoredRule{earlierCreatedButLaterNamespace, laterCreatedButEarlierNamespace})
+	if topAnnotation == "" {
+		t.Fatalf("expected a non-empty top annotation")
+	}
+
+	aScore := scoreRule(laterCreatedButEarlierNamespace.Rule, laterCreatedButEarlierNamespace.Namespace, laterCreatedButEarlierNamespace.Name, laterCreatedButEarlierNamespace.CreationTimestamp, nil)
+	zScore := scoreRule(earlierCreatedButLaterNamespace.Rule, earlierCreatedButLaterNamespace.Namespace, earlierCreatedButLaterNamespace.Name, earlierCreatedButLaterNamespace.CreationTimestamp, nil)
+	if !aScore.less(zScore) {
+		t.Fatalf("expected the a-ns rule to sort ahead of the z-ns rule regardless of creationTimestamp")
+	}
+}
+
+func TestSortRulesByPriorityExactPathOutranksPrefix(t *testing.T) {
+	exact := scoredRule(withExactPath("/predict"))
+	prefix := scoredRule()
+	prefix.Rule.Matches = []gatewayapiv1.HTTPRouteMatch{
+		{Path: &gatewayapiv1.HTTPPathMatch{Type: ptr.To(gatewayapiv1.PathMatchPathPrefix), Value: ptr.To("/")}},
+	}
+
+	ordered, topAnnotation := SortRulesByPriority([]ScoredRule{prefix, exact})
+	if *ordered[0].Matches[0].Path.Value != "/predict" {
+		t.Fatalf("expected the exact-path rule first, got %+v", ordered)
+	}
+	if topAnnotation == "" {
+		t.Fatalf("expected a non-empty top annotation")
+	}
+}
+
+func TestRuleScoreLessOrderingTuple(t *testing.T) {
+	base := ruleScore{namespace: "ns", name: "name"}
+
+	headerHeavy := base
+	headerHeavy.headerMatchCount = 2
+	methodOnly := base
+	methodOnly.methodPresent = true
+	if !headerHeavy.less(methodOnly) {
+		t.Fatalf("expected more header matches to outrank a bare method match")
+	}
+
+	queryHeavy := base
+	queryHeavy.queryParamMatchCount = 1
+	if !queryHeavy.less(methodOnly) {
+		t.Fatalf("expected a query-param match to outrank a bare method match")
+	}
+
+	nsA := base
+	nsA.namespace = "a"
+	nsA.creationTimestamp = time.Unix(500, 0)
+	nsZ := base
+	nsZ.namespace = "z"
+	nsZ.creationTimestamp = time.Unix(1, 0)
+	if !nsA.less(nsZ) {
+		t.Fatalf("expected namespace to tie-break ahead of creationTimestamp")
+	}
+}
+
+func TestApplyRulePriorityReordersAndAnnotates(t *testing.T) {
+	route := &gatewayapiv1.HTTPRoute{
+		Spec: gatewayapiv1.HTTPRouteSpec{
+			Rules: []gatewayapiv1.HTTPRouteRule{
+				{},
+				{Matches: []gatewayapiv1.HTTPRouteMatch{
+					{Path: &gatewayapiv1.HTTPPathMatch{Type: ptr.To(gatewayapiv1.PathMatchExact), Value: ptr.To("/predict")}},
+				}},
+			},
+		},
+	}
+	ApplyRulePriority(route, "ns", "name", time.Unix(0, 0))
+
+	if len(route.Spec.Rules[0].Matches) == 0 {
+		t.Fatalf("expected the exact-path rule to be reordered first, got %+v", route.Spec.Rules)
+	}
+	if route.Annotations[RoutePriorityAnnotationKey] == "" {
+		t.Fatalf("expected RoutePriorityAnnotationKey to be set")
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tcproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tcproutes/status,verbs=get;update;patch
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// TCPBackendConfig describes a predictor backend that needs raw TCP
+// passthrough, e.g. a gRPC server where even SNI-based TLS routing isn't
+// applicable because the connection isn't TLS at all. Like TLSRoute, TCPRoute
+// has no hostname or filter support: the parentRef's listener alone selects
+// which traffic reaches the backend.
+type TCPBackendConfig struct {
+	Name        string
+	Namespace   string
+	ParentRefs  []gatewayapiv1.ParentReference
+	BackendName string
+	BackendPort int32
+}
+
+// BuildTCPRoute generates a TCPRoute that forwards all traffic on the parent
+// listener to the backend Service, unmodified.
+func BuildTCPRoute(cfg TCPBackendConfig) *gatewayapiv1alpha2.TCPRoute {
+	return &gatewayapiv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfg.Name,
+			Namespace: cfg.Namespace,
+		},
+		Spec: gatewayapiv1alpha2.TCPRouteSpec{
+			CommonRouteSpec: gatewayapiv1alpha2.CommonRouteSpec{
+				ParentRefs: cfg.ParentRefs,
+			},
+			Rules: []gatewayapiv1alpha2.TCPRouteRule{
+				{
+					BackendRefs: []gatewayapiv1.BackendRef{
+						{
+							BackendObjectReference: gatewayapiv1.BackendObjectReference{
+								Name: gatewayapiv1.ObjectName(cfg.BackendName),
+								Port: portPtr(cfg.BackendPort),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// PredictorPassthroughProtocol names the passthrough ingress modes: "TLS" for
+// a TLSRoute (SNI-routed, still encrypted end to end) and "TCP" for a
+// TCPRoute (no TLS at all). No PredictorSpec field has this type, and
+// nothing in this package calls IsPassthroughProtocol to choose the route
+// kind, so the predictor is still routed through the HTTPRoute.
+type PredictorPassthroughProtocol string
+
+const (
+	PredictorProtocolTLS PredictorPassthroughProtocol = "TLS"
+	PredictorProtocolTCP PredictorPassthroughProtocol = "TCP"
+)
+
+// IsPassthroughProtocol reports whether protocol is "TLS" or "TCP".
+func IsPassthroughProtocol(protocol string) bool {
+	return PredictorPassthroughProtocol(protocol) == PredictorProtocolTLS || PredictorPassthroughProtocol(protocol) == PredictorProtocolTCP
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tlsroutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tlsroutes/status,verbs=get;update;patch
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// TLSBackendConfig describes a predictor backend that needs end-to-end TLS
+// passthrough (the gateway only SNI-routes on the ClientHello; it never
+// terminates TLS) instead of the plaintext-or-gateway-terminated-TLS path the
+// HTTPRoute/BackendTLSPolicy builders cover.
+type TLSBackendConfig struct {
+	Name        string
+	Namespace   string
+	SNIHosts    []gatewayapiv1.Hostname
+	ParentRefs  []gatewayapiv1.ParentReference
+	BackendName string
+	BackendPort int32
+}
+
+// BuildTLSRoute generates a TLSRoute that forwards all traffic matching the
+// configured SNI hostnames to the backend Service, unmodified: TLSRoute has
+// no filter support, so there is no header-injection equivalent to the
+// Kserve-Isvc-Name/Namespace headers set on the HTTPRoute/GRPCRoute paths.
+func BuildTLSRoute(cfg TLSBackendConfig) *gatewayapiv1alpha2.TLSRoute {
+	return &gatewayapiv1alpha2.TLSRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfg.Name,
+			Namespace: cfg.Namespace,
+		},
+		Spec: gatewayapiv1alpha2.TLSRouteSpec{
+			CommonRouteSpec: gatewayapiv1alpha2.CommonRouteSpec{
+				ParentRefs: cfg.ParentRefs,
+			},
+			Hostnames: cfg.SNIHosts,
+			Rules: []gatewayapiv1alpha2.TLSRouteRule{
+				{
+					BackendRefs: []gatewayapiv1.BackendRef{
+						{
+							BackendObjectReference: gatewayapiv1.BackendObjectReference{
+								Name: gatewayapiv1.ObjectName(cfg.BackendName),
+								Port: portPtr(cfg.BackendPort),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"testing"
+
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestBuildTLSRoute(t *testing.T) {
+	route := BuildTLSRoute(TLSBackendConfig{
+		Name:        "isvc-predictor-tls",
+		Namespace:   "ns",
+		SNIHosts:    []gatewayapiv1.Hostname{"isvc.example.com"},
+		BackendName: "isvc-predictor",
+		BackendPort: 443,
+	})
+	if route.Name != "isvc-predictor-tls" || route.Namespace != "ns" {
+		t.Fatalf("unexpected object metadata: %s/%s", route.Namespace, route.Name)
+	}
+	if len(route.Spec.Hostnames) != 1 || route.Spec.Hostnames[0] != "isvc.example.com" {
+		t.Fatalf("unexpected SNI hostnames: %v", route.Spec.Hostnames)
+	}
+	if len(route.Spec.Rules) != 1 {
+		t.Fatalf("expected a single rule, got %+v", route.Spec.Rules)
+	}
+	backendRefs := route.Spec.Rules[0].BackendRefs
+	if len(backendRefs) != 1 || string(backendRefs[0].Name) != "isvc-predictor" {
+		t.Fatalf("unexpected backend refs: %+v", backendRefs)
+	}
+	if int32(*backendRefs[0].Port) != 443 {
+		t.Fatalf("unexpected backend port: %v", backendRefs[0].Port)
+	}
+}
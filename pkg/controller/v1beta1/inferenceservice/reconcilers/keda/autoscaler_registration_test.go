@@ -0,0 +1,47 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/autoscaler"
+)
+
+// TestKedaRegistersWithAutoscalerRegistry confirms this package's init()
+// registered itself with the shared autoscaler registry under
+// constants.AutoscalerClassKeda, and that the registered factory builds an
+// adapter wrapping a real *ScaledObjectReconciler.
+func TestKedaRegistersWithAutoscalerRegistry(t *testing.T) {
+	factory, err := autoscaler.Get(constants.AutoscalerClassKeda)
+	if err != nil {
+		t.Fatalf("expected the keda package's init() to have registered itself: %v", err)
+	}
+
+	reconciler := factory(nil, nil, metav1.ObjectMeta{Name: "test"}, &v1beta1.ComponentExtensionSpec{})
+	adapter, ok := reconciler.(*reconcilerAdapter)
+	if !ok {
+		t.Fatalf("expected the registered factory to return a *reconcilerAdapter, got %T", reconciler)
+	}
+	if adapter.ScaledObjectReconciler == nil {
+		t.Fatalf("expected the adapter to wrap a non-nil ScaledObjectReconciler")
+	}
+}
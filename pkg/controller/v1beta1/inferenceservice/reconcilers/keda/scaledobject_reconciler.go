@@ -0,0 +1,339 @@
+/*
+Copyright 2021 The KServe Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package keda reconciles a KEDA ScaledObject as an alternative to the
+// HorizontalPodAutoscaler for raw-deployment InferenceServices, selected via
+// constants.AutoscalerClassKeda. KEDA's CRDs aren't vendored as typed clients
+// in this module, so the ScaledObject is built and compared as
+// unstructured.Unstructured, the same approach pkg/controller/.../ingress
+// uses for the Kuadrant policy CRDs.
+package keda
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("ScaledObjectReconciler")
+
+// scaledObjectGVK is the GroupVersionKind of the KEDA ScaledObject CRD.
+var scaledObjectGVK = schema.GroupVersionKind{
+	Group:   "keda.sh",
+	Version: "v1alpha1",
+	Kind:    "ScaledObject",
+}
+
+const defaultPollingInterval = 30
+
+// ScaledObjectReconciler is the struct of the KEDA autoscaling target.
+type ScaledObjectReconciler struct {
+	client       client.Client
+	scheme       *runtime.Scheme
+	ScaledObject *unstructured.Unstructured
+	// TriggerAuthentications holds one TriggerAuthentication per external
+	// metric with AuthRef set, so secured Prometheus/OTLP scalers can be
+	// reconciled alongside the ScaledObject that references them.
+	TriggerAuthentications []*unstructured.Unstructured
+	componentExt           *v1beta1.ComponentExtensionSpec
+}
+
+func NewScaledObjectReconciler(client client.Client,
+	scheme *runtime.Scheme,
+	componentMeta metav1.ObjectMeta,
+	componentExt *v1beta1.ComponentExtensionSpec,
+) *ScaledObjectReconciler {
+	return &ScaledObjectReconciler{
+		client:                 client,
+		scheme:                 scheme,
+		ScaledObject:           createScaledObject(componentMeta, componentExt),
+		TriggerAuthentications: BuildTriggerAuthenticationsForMetrics(ComponentMeta{Name: componentMeta.Name, Namespace: componentMeta.Namespace}, componentExt.Metrics),
+		componentExt:           componentExt,
+	}
+}
+
+func createScaledObject(componentMeta metav1.ObjectMeta,
+	componentExt *v1beta1.ComponentExtensionSpec,
+) *unstructured.Unstructured {
+	minReplicas := int64(constants.DefaultMinReplicas)
+	if componentExt.MinReplicas != nil && int64(*componentExt.MinReplicas) > minReplicas {
+		minReplicas = int64(*componentExt.MinReplicas)
+	}
+	maxReplicas := int64(componentExt.MaxReplicas)
+	if maxReplicas < minReplicas {
+		maxReplicas = minReplicas
+	}
+
+	so := &unstructured.Unstructured{}
+	so.SetGroupVersionKind(scaledObjectGVK)
+	so.SetName(componentMeta.Name)
+	so.SetNamespace(componentMeta.Namespace)
+	so.SetLabels(componentMeta.Labels)
+	so.SetAnnotations(componentMeta.Annotations)
+
+	var triggers []interface{}
+	if annotationTrigger := buildAnnotationTrigger(componentMeta, componentExt); annotationTrigger != nil {
+		triggers = append(triggers, annotationTrigger)
+	} else {
+		triggers = append(triggers, buildCPUTrigger(componentMeta, componentExt))
+	}
+	for _, trigger := range buildExternalTriggers(componentMeta.Name, componentExt.Metrics) {
+		triggers = append(triggers, trigger)
+	}
+
+	spec := map[string]interface{}{
+		"scaleTargetRef": map[string]interface{}{
+			"name": componentMeta.Name,
+		},
+		"minReplicaCount": minReplicas,
+		"maxReplicaCount": maxReplicas,
+		"pollingInterval": int64(defaultPollingInterval),
+		"triggers":        triggers,
+	}
+	_ = unstructured.SetNestedMap(so.Object, spec, "spec")
+	return so
+}
+
+// buildCPUTrigger mirrors the HPAReconciler's default metric (CPU
+// utilization, constants.DefaultCPUUtilization unless overridden) so
+// switching an InferenceService's AutoscalerClass between hpa and keda
+// doesn't change its default scaling behavior.
+func buildCPUTrigger(componentMeta metav1.ObjectMeta, componentExt *v1beta1.ComponentExtensionSpec) map[string]interface{} {
+	utilization := int32(constants.DefaultCPUUtilization)
+	if value, ok := componentMeta.Annotations[constants.TargetUtilizationPercentage]; ok {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			utilization = int32(parsed) // #nosec G109
+		}
+	}
+	if componentExt.ScaleTarget != nil {
+		utilization = int32(*componentExt.ScaleTarget)
+	}
+	return map[string]interface{}{
+		"type": "cpu",
+		"metadata": map[string]interface{}{
+			"type":  "Utilization",
+			"value": strconv.Itoa(int(utilization)),
+		},
+	}
+}
+
+// buildAnnotationTrigger builds the single trigger selected by
+// constants.KedaTriggerAnnotationKey, a lighter-weight alternative to a
+// ComponentExtensionSpec.Metrics entry for components that only need one
+// trigger. KedaTriggerPrometheus is handled by buildExternalTriggers instead
+// once the component has a Metrics entry of ExternalScaleMetricSourceType,
+// since a Prometheus trigger needs the query/threshold/serverAddress fields
+// only ExternalMetricSource carries; this only covers the annotation-only
+// cpu/memory/kafka triggers. Returns nil when the annotation is unset or
+// names an unrecognized type, so the caller falls back to the default CPU
+// trigger.
+func buildAnnotationTrigger(componentMeta metav1.ObjectMeta, componentExt *v1beta1.ComponentExtensionSpec) map[string]interface{} {
+	switch constants.KedaTriggerType(componentMeta.Annotations[constants.KedaTriggerAnnotationKey]) {
+	case constants.KedaTriggerCPU:
+		return buildCPUTrigger(componentMeta, componentExt)
+	case constants.KedaTriggerMemory:
+		return buildMemoryTrigger(componentMeta, componentExt)
+	case constants.KedaTriggerKafka:
+		return buildKafkaTrigger(componentMeta)
+	default:
+		return nil
+	}
+}
+
+// buildMemoryTrigger is buildCPUTrigger's memory-scaling counterpart,
+// selected via constants.KedaTriggerAnnotationKey=memory since, unlike CPU,
+// ComponentExtensionSpec has no dedicated memory-target field to default
+// from.
+func buildMemoryTrigger(componentMeta metav1.ObjectMeta, componentExt *v1beta1.ComponentExtensionSpec) map[string]interface{} {
+	utilization := int32(constants.DefaultCPUUtilization)
+	if componentExt.ScaleTarget != nil {
+		utilization = int32(*componentExt.ScaleTarget)
+	}
+	return map[string]interface{}{
+		"type": "memory",
+		"metadata": map[string]interface{}{
+			"type":  "Utilization",
+			"value": strconv.Itoa(int(utilization)),
+		},
+	}
+}
+
+// buildKafkaTrigger builds KEDA's kafka scaler trigger from the
+// KedaKafkaXAnnotationKey annotations, since the kafka scaler's metadata
+// keys (bootstrapServers, consumerGroup, topic, lagThreshold) don't map onto
+// ExternalMetricSource's Prometheus-shaped query/threshold/serverAddress
+// fields.
+func buildKafkaTrigger(componentMeta metav1.ObjectMeta) map[string]interface{} {
+	annotations := componentMeta.Annotations
+	lagThreshold := annotations[constants.KedaKafkaLagThresholdAnnotationKey]
+	if lagThreshold == "" {
+		lagThreshold = "5"
+	}
+	return map[string]interface{}{
+		"type": "kafka",
+		"metadata": map[string]interface{}{
+			"bootstrapServers": annotations[constants.KedaKafkaBootstrapServersAnnotationKey],
+			"topic":            annotations[constants.KedaKafkaTopicAnnotationKey],
+			"consumerGroup":    annotations[constants.KedaKafkaConsumerGroupAnnotationKey],
+			"lagThreshold":     lagThreshold,
+		},
+	}
+}
+
+// buildExternalTriggers renders each v1beta1.ScaleMetricSpec with
+// Type=External as a KEDA trigger, so ComponentExtensionSpec.Metrics entries
+// backed by e.g. a Prometheus query (vllm:num_requests_waiting, queue depth)
+// scale the ScaledObject alongside the default CPU trigger. A metric with
+// AuthRef set references the matching TriggerAuthentication (see
+// BuildTriggerAuthenticationsForMetrics) via authenticationRef, so the
+// trigger can poll a secured Prometheus/Thanos/OTLP endpoint.
+func buildExternalTriggers(componentName string, metrics []v1beta1.ScaleMetricSpec) []map[string]interface{} {
+	var triggers []map[string]interface{}
+	for _, m := range metrics {
+		if m.Type != v1beta1.ExternalScaleMetricSourceType || m.External == nil {
+			continue
+		}
+		metadata := map[string]interface{}{
+			"query":     m.External.Query,
+			"threshold": m.External.Threshold,
+		}
+		if m.External.ServerAddress != "" {
+			metadata["serverAddress"] = m.External.ServerAddress
+		}
+		trigger := map[string]interface{}{
+			"type":     m.External.Name,
+			"metadata": metadata,
+		}
+		if m.External.AuthRef != nil {
+			trigger["authenticationRef"] = map[string]interface{}{
+				"name": TriggerAuthenticationName(componentName, m.External.Name),
+			}
+		}
+		triggers = append(triggers, trigger)
+	}
+	return triggers
+}
+
+// ResolveAutoscalerClass upgrades an unset ("") autoscaler class to
+// constants.AutoscalerClassKeda when the component declares an external
+// metric; an explicitly configured class is returned unchanged. So switching a component from a
+// CPU-only HPA to an external-metric ScaledObject only requires adding a
+// Metrics entry, not also flipping the autoscaler-class annotation.
+func ResolveAutoscalerClass(configured constants.AutoscalerClassType, metrics []v1beta1.ScaleMetricSpec) constants.AutoscalerClassType {
+	if configured == constants.AutoscalerClassKeda {
+		return configured
+	}
+	if configured == "" && v1beta1.HasExternalMetric(metrics) {
+		return constants.AutoscalerClassKeda
+	}
+	return configured
+}
+
+// checkScaledObjectExist checks if the ScaledObject exists.
+func (r *ScaledObjectReconciler) checkScaledObjectExist(client client.Client) (constants.CheckResultType, *unstructured.Unstructured, error) {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(scaledObjectGVK)
+	err := client.Get(context.TODO(), types.NamespacedName{
+		Namespace: r.ScaledObject.GetNamespace(),
+		Name:      r.ScaledObject.GetName(),
+	}, existing)
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			if shouldCreateScaledObject(r.ScaledObject) {
+				return constants.CheckResultCreate, nil, nil
+			}
+			return constants.CheckResultSkipped, nil, nil
+		}
+		return constants.CheckResultUnknown, nil, err
+	}
+
+	if !shouldCreateScaledObject(r.ScaledObject) {
+		return constants.CheckResultDelete, existing, nil
+	}
+	return constants.CheckResultUpdate, existing, nil
+}
+
+func shouldCreateScaledObject(desired *unstructured.Unstructured) bool {
+	autoscalerClass, ok := desired.GetAnnotations()[constants.AutoscalerClass]
+	return ok && constants.AutoscalerClassType(autoscalerClass) == constants.AutoscalerClassKeda
+}
+
+// reconcileTriggerAuthentications creates each pending TriggerAuthentication
+// if it doesn't already exist. Unlike the ScaledObject itself, these are
+// small, idempotent credential-reference objects with no fields that change
+// after creation (the Secret they point at is mutated in place instead), so
+// update/delete handling isn't needed here.
+func (r *ScaledObjectReconciler) reconcileTriggerAuthentications() error {
+	for _, ta := range r.TriggerAuthentications {
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(triggerAuthenticationGVK)
+		err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: ta.GetNamespace(), Name: ta.GetName()}, existing)
+		if err == nil {
+			continue
+		}
+		if !apierr.IsNotFound(err) {
+			return err
+		}
+		if err := r.client.Create(context.TODO(), ta); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reconcile ...
+func (r *ScaledObjectReconciler) Reconcile() (*unstructured.Unstructured, error) {
+	if err := r.reconcileTriggerAuthentications(); err != nil {
+		return nil, err
+	}
+
+	checkResult, existing, err := r.checkScaledObjectExist(r.client)
+	log.Info("ScaledObject reconcile", "checkResult", checkResult, "err", err)
+	if err != nil {
+		return nil, err
+	}
+
+	var opErr error
+	switch checkResult {
+	case constants.CheckResultCreate:
+		opErr = r.client.Create(context.TODO(), r.ScaledObject)
+	case constants.CheckResultUpdate:
+		r.ScaledObject.SetResourceVersion(existing.GetResourceVersion())
+		opErr = r.client.Update(context.TODO(), r.ScaledObject)
+	case constants.CheckResultDelete:
+		opErr = r.client.Delete(context.TODO(), existing)
+	default:
+		return existing, nil
+	}
+
+	if opErr != nil {
+		return nil, opErr
+	}
+
+	return r.ScaledObject, nil
+}
+
+func (r *ScaledObjectReconciler) SetControllerReferences(owner metav1.Object, scheme *runtime.Scheme) error {
+	return controllerutil.SetControllerReference(owner, r.ScaledObject, scheme)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The KServe Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestBuildExternalTriggersSkipsNonExternalMetrics(t *testing.T) {
+	metrics := []v1beta1.ScaleMetricSpec{
+		{Type: v1beta1.ResourceScaleMetricSourceType, Resource: &v1beta1.ResourceMetricSource{Name: "cpu"}},
+		{Type: v1beta1.ExternalScaleMetricSourceType, External: nil},
+	}
+	if got := buildExternalTriggers("isvc-predictor", metrics); got != nil {
+		t.Fatalf("expected no triggers, got %+v", got)
+	}
+}
+
+func TestBuildExternalTriggersRendersPrometheusQuery(t *testing.T) {
+	metrics := []v1beta1.ScaleMetricSpec{
+		{
+			Type: v1beta1.ExternalScaleMetricSourceType,
+			External: &v1beta1.ExternalMetricSource{
+				Name:          "prometheus",
+				Query:         "vllm:num_requests_waiting",
+				Threshold:     "10",
+				ServerAddress: "http://prometheus:9090",
+			},
+		},
+	}
+	got := buildExternalTriggers("isvc-predictor", metrics)
+	if len(got) != 1 {
+		t.Fatalf("expected a single trigger, got %+v", got)
+	}
+	if got[0]["type"] != "prometheus" {
+		t.Fatalf("unexpected trigger type: %+v", got[0])
+	}
+	metadata, ok := got[0]["metadata"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected metadata to be a map, got %+v", got[0]["metadata"])
+	}
+	if metadata["query"] != "vllm:num_requests_waiting" || metadata["threshold"] != "10" || metadata["serverAddress"] != "http://prometheus:9090" {
+		t.Fatalf("unexpected metadata: %+v", metadata)
+	}
+}
+
+func TestBuildExternalTriggersOmitsEmptyServerAddress(t *testing.T) {
+	metrics := []v1beta1.ScaleMetricSpec{
+		{
+			Type: v1beta1.ExternalScaleMetricSourceType,
+			External: &v1beta1.ExternalMetricSource{
+				Name:      "prometheus",
+				Query:     "queue_depth",
+				Threshold: "5",
+			},
+		},
+	}
+	got := buildExternalTriggers("isvc-predictor", metrics)
+	metadata := got[0]["metadata"].(map[string]interface{})
+	if _, ok := metadata["serverAddress"]; ok {
+		t.Fatalf("expected no serverAddress key when unset, got %+v", metadata)
+	}
+}
+
+func TestResolveAutoscalerClassAlreadyKeda(t *testing.T) {
+	got := ResolveAutoscalerClass(constants.AutoscalerClassKeda, nil)
+	if got != constants.AutoscalerClassKeda {
+		t.Fatalf("expected AutoscalerClassKeda to be preserved, got %v", got)
+	}
+}
+
+func TestResolveAutoscalerClassUpgradesOnExternalMetric(t *testing.T) {
+	metrics := []v1beta1.ScaleMetricSpec{
+		{Type: v1beta1.ExternalScaleMetricSourceType, External: &v1beta1.ExternalMetricSource{Name: "prometheus"}},
+	}
+	got := ResolveAutoscalerClass("", metrics)
+	if got != constants.AutoscalerClassKeda {
+		t.Fatalf("expected an unset autoscaler class with an external metric to upgrade to keda, got %v", got)
+	}
+}
+
+func TestResolveAutoscalerClassLeavesExplicitChoiceAlone(t *testing.T) {
+	metrics := []v1beta1.ScaleMetricSpec{
+		{Type: v1beta1.ExternalScaleMetricSourceType, External: &v1beta1.ExternalMetricSource{Name: "prometheus"}},
+	}
+	got := ResolveAutoscalerClass(constants.AutoscalerClassHPA, metrics)
+	if got != constants.AutoscalerClassHPA {
+		t.Fatalf("expected an explicitly configured class to be left alone, got %v", got)
+	}
+}
+
+func TestResolveAutoscalerClassNoExternalMetricStaysEmpty(t *testing.T) {
+	got := ResolveAutoscalerClass("", nil)
+	if got != "" {
+		t.Fatalf("expected an unset class with no external metric to stay unset, got %v", got)
+	}
+}
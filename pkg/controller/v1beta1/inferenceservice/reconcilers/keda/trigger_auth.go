@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The KServe Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"fmt"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// triggerAuthenticationGVK is the GroupVersionKind of KEDA's
+// TriggerAuthentication CRD, scoped to the component's own namespace so
+// credentials aren't visible cluster-wide.
+var triggerAuthenticationGVK = schema.GroupVersionKind{
+	Group:   "keda.sh",
+	Version: "v1alpha1",
+	Kind:    "TriggerAuthentication",
+}
+
+// secretParamsByMode lists the KEDA secretTargetRef parameter names expected
+// in the Secret for each MetricAuthMode, which must also be the key names
+// present in that Secret.
+var secretParamsByMode = map[v1beta1.MetricAuthMode][]string{
+	v1beta1.MetricAuthBearer: {"bearerToken"},
+	v1beta1.MetricAuthBasic:  {"username", "password"},
+	v1beta1.MetricAuthTLS:    {"ca", "cert", "key"},
+}
+
+// TriggerAuthenticationName derives the TriggerAuthentication object name
+// for one ExternalMetricSource trigger, scoped to the owning component so
+// multiple metrics on the same component don't collide.
+func TriggerAuthenticationName(componentName, metricName string) string {
+	return fmt.Sprintf("%s-%s-auth", componentName, metricName)
+}
+
+// BuildTriggerAuthentication generates the TriggerAuthentication that grants
+// a ScaledObject trigger access to authRef.SecretName, one secretTargetRef
+// entry per parameter authRef.Mode requires.
+func BuildTriggerAuthentication(namespace, name string, authRef v1beta1.MetricAuthRef) *unstructured.Unstructured {
+	params, ok := secretParamsByMode[authRef.Mode]
+	if !ok {
+		params = secretParamsByMode[v1beta1.MetricAuthBearer]
+	}
+
+	secretTargetRefs := make([]interface{}, 0, len(params))
+	for _, param := range params {
+		secretTargetRefs = append(secretTargetRefs, map[string]interface{}{
+			"parameter": param,
+			"name":      authRef.SecretName,
+			"key":       param,
+		})
+	}
+
+	ta := &unstructured.Unstructured{}
+	ta.SetGroupVersionKind(triggerAuthenticationGVK)
+	ta.SetName(name)
+	ta.SetNamespace(namespace)
+	_ = unstructured.SetNestedSlice(ta.Object, secretTargetRefs, "spec", "secretTargetRef")
+	return ta
+}
+
+// ComponentMeta is the minimal identity BuildTriggerAuthenticationsForMetrics
+// needs, mirroring the componentMeta parameter createScaledObject already
+// takes.
+type ComponentMeta struct {
+	Name      string
+	Namespace string
+}
+
+// BuildTriggerAuthenticationsForMetrics generates one TriggerAuthentication
+// per metric that configured an AuthRef, so the ScaledObject reconciler can
+// create them alongside the ScaledObject itself.
+func BuildTriggerAuthenticationsForMetrics(meta ComponentMeta, metrics []v1beta1.ScaleMetricSpec) []*unstructured.Unstructured {
+	var authentications []*unstructured.Unstructured
+	for _, m := range metrics {
+		if m.Type != v1beta1.ExternalScaleMetricSourceType || m.External == nil || m.External.AuthRef == nil {
+			continue
+		}
+		name := TriggerAuthenticationName(meta.Name, m.External.Name)
+		authentications = append(authentications, BuildTriggerAuthentication(meta.Namespace, name, *m.External.AuthRef))
+	}
+	return authentications
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package otelcollector builds the OpenTelemetryCollector custom resource
+// (as unstructured.Unstructured, the same approach the keda package uses for
+// KEDA's CRDs, since neither is vendored as a typed client here) that runs as
+// a sidecar next to a component's container. The base pipeline always scrapes
+// the component's metrics port for the KEDA external-metric exporter;
+// v1beta1.OpenTelemetrySpec lets a component fan that pipeline out to
+// additional receivers/processors/exporters.
+package otelcollector
+
+import (
+	"fmt"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var collectorGVK = schema.GroupVersionKind{Group: "opentelemetry.io", Version: "v1beta1", Kind: "OpenTelemetryCollector"}
+
+const (
+	promReceiverName = "prometheus"
+	kedaExporterName = "otlp/keda"
+)
+
+// BuildCollectorConfig assembles the Collector's `spec.config` pipeline:
+// receivers/processors/exporters in `service.pipelines.metrics` plus whatever
+// otel adds, keyed by name so two entries of the same type don't collide.
+func BuildCollectorConfig(otel *v1beta1.OpenTelemetrySpec) map[string]interface{} {
+	receivers := map[string]interface{}{
+		promReceiverName: map[string]interface{}{},
+	}
+	processors := map[string]interface{}{}
+	exporters := map[string]interface{}{
+		kedaExporterName: map[string]interface{}{},
+	}
+	pipelineReceivers := []string{promReceiverName}
+	pipelineProcessors := []string{}
+	pipelineExporters := []string{kedaExporterName}
+
+	if otel != nil {
+		for i, r := range otel.Receivers {
+			name := fmt.Sprintf("%s/%d", r.Type, i)
+			receivers[name] = receiverConfig(r)
+			pipelineReceivers = append(pipelineReceivers, name)
+		}
+		for i, p := range otel.Processors {
+			name := fmt.Sprintf("%s/%d", p.Type, i)
+			processors[name] = processorConfig(p)
+			pipelineProcessors = append(pipelineProcessors, name)
+		}
+		for i, e := range otel.Exporters {
+			name := fmt.Sprintf("%s/%d", e.Type, i)
+			exporters[name] = exporterConfig(e)
+			pipelineExporters = append(pipelineExporters, name)
+		}
+	}
+
+	return map[string]interface{}{
+		"receivers":  receivers,
+		"processors": processors,
+		"exporters":  exporters,
+		"service": map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"receivers":  pipelineReceivers,
+					"processors": pipelineProcessors,
+					"exporters":  pipelineExporters,
+				},
+			},
+		},
+	}
+}
+
+func receiverConfig(r v1beta1.OTelReceiver) map[string]interface{} {
+	cfg := map[string]interface{}{}
+	if r.Endpoint != "" {
+		cfg["endpoint"] = r.Endpoint
+	}
+	return cfg
+}
+
+func processorConfig(p v1beta1.OTelProcessor) map[string]interface{} {
+	if p.Type != v1beta1.OTelProcessorTailSampling {
+		return map[string]interface{}{}
+	}
+	policies := make([]interface{}, 0, len(p.TailSamplingPolicies))
+	for _, policy := range p.TailSamplingPolicies {
+		entry := map[string]interface{}{"name": policy.Name}
+		switch {
+		case policy.StatusCode != "":
+			entry["type"] = "status_code"
+			entry["status_code"] = map[string]interface{}{"status_codes": []interface{}{policy.StatusCode}}
+		case policy.LatencyThresholdMs != 0:
+			entry["type"] = "latency"
+			entry["latency"] = map[string]interface{}{"threshold_ms": int64(policy.LatencyThresholdMs)}
+		}
+		policies = append(policies, entry)
+	}
+	return map[string]interface{}{"policies": policies}
+}
+
+func exporterConfig(e v1beta1.OTelExporter) map[string]interface{} {
+	cfg := map[string]interface{}{}
+	if e.Endpoint != "" {
+		cfg["endpoint"] = e.Endpoint
+	}
+	if e.Insecure {
+		cfg["tls"] = map[string]interface{}{"insecure": true}
+	}
+	return cfg
+}
+
+// BuildCollector wraps BuildCollectorConfig into the OpenTelemetryCollector CR
+// for namespace/name, owned by the component's Deployment.
+func BuildCollector(namespace, name string, otel *v1beta1.OpenTelemetrySpec) *unstructured.Unstructured {
+	collector := &unstructured.Unstructured{}
+	collector.SetGroupVersionKind(collectorGVK)
+	collector.SetNamespace(namespace)
+	collector.SetName(name)
+	_ = unstructured.SetNestedMap(collector.Object, BuildCollectorConfig(otel), "spec", "config")
+	_ = unstructured.SetNestedField(collector.Object, "sidecar", "spec", "mode")
+	return collector
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The KServe Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pdb reconciles a policy/v1 PodDisruptionBudget per component
+// (predictor, transformer, explainer) for raw-deployment InferenceServices, so
+// voluntary evictions (node drain, cluster upgrade) can't take a component
+// below its MinReplicas.
+package pdb
+
+import (
+	"context"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("PodDisruptionBudgetReconciler")
+
+// PDBReconciler is the struct of the PodDisruptionBudget raw K8s object,
+// mirroring HPAReconciler/ScaledObjectReconciler's shape.
+type PDBReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	PDB    *policyv1.PodDisruptionBudget
+}
+
+// NewPDBReconciler builds a PDBReconciler for one component. spec is nil when
+// the component didn't configure ComponentExtensionSpec.DisruptionBudget, in
+// which case no PodDisruptionBudget should exist for it.
+func NewPDBReconciler(client client.Client,
+	scheme *runtime.Scheme,
+	componentMeta metav1.ObjectMeta,
+	selectorLabels map[string]string,
+	spec *v1beta1.PodDisruptionBudgetSpec,
+) *PDBReconciler {
+	return &PDBReconciler{
+		client: client,
+		scheme: scheme,
+		PDB:    createPDB(componentMeta, selectorLabels, spec),
+	}
+}
+
+func createPDB(componentMeta metav1.ObjectMeta, selectorLabels map[string]string, spec *v1beta1.PodDisruptionBudgetSpec) *policyv1.PodDisruptionBudget {
+	pdbSpec := policyv1.PodDisruptionBudgetSpec{
+		Selector: &metav1.LabelSelector{MatchLabels: selectorLabels},
+	}
+	if spec != nil {
+		pdbSpec.MinAvailable = spec.MinAvailable
+		pdbSpec.MaxUnavailable = spec.MaxUnavailable
+	}
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: componentMeta,
+		Spec:       pdbSpec,
+	}
+}
+
+// shouldExist reports whether a PodDisruptionBudget should be reconciled for
+// this component: only when the user explicitly configured
+// ComponentExtensionSpec.DisruptionBudget, since a PDB with neither
+// MinAvailable nor MaxUnavailable set defaults to requiring 100% availability
+// and would otherwise block node drains KServe never opted into guarding.
+func shouldExist(spec *policyv1.PodDisruptionBudgetSpec) bool {
+	return spec.MinAvailable != nil || spec.MaxUnavailable != nil
+}
+
+func (r *PDBReconciler) checkPDBExist(client client.Client) (constants.CheckResultType, *policyv1.PodDisruptionBudget, error) {
+	existing := &policyv1.PodDisruptionBudget{}
+	err := client.Get(context.TODO(), types.NamespacedName{
+		Namespace: r.PDB.ObjectMeta.Namespace,
+		Name:      r.PDB.ObjectMeta.Name,
+	}, existing)
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			if shouldExist(&r.PDB.Spec) {
+				return constants.CheckResultCreate, nil, nil
+			}
+			return constants.CheckResultSkipped, nil, nil
+		}
+		return constants.CheckResultUnknown, nil, err
+	}
+
+	if !shouldExist(&r.PDB.Spec) {
+		return constants.CheckResultDelete, existing, nil
+	}
+	if equality.Semantic.DeepEqual(r.PDB.Spec, existing.Spec) {
+		return constants.CheckResultExisted, existing, nil
+	}
+	return constants.CheckResultUpdate, existing, nil
+}
+
+// Reconcile ...
+func (r *PDBReconciler) Reconcile() (*policyv1.PodDisruptionBudget, error) {
+	checkResult, existing, err := r.checkPDBExist(r.client)
+	log.Info("PodDisruptionBudget reconcile", "checkResult", checkResult, "err", err)
+	if err != nil {
+		return nil, err
+	}
+
+	var opErr error
+	switch checkResult {
+	case constants.CheckResultCreate:
+		opErr = r.client.Create(context.TODO(), r.PDB)
+	case constants.CheckResultUpdate:
+		r.PDB.ResourceVersion = existing.ResourceVersion
+		opErr = r.client.Update(context.TODO(), r.PDB)
+	case constants.CheckResultDelete:
+		opErr = r.client.Delete(context.TODO(), existing)
+	default:
+		return existing, nil
+	}
+
+	if opErr != nil {
+		return nil, opErr
+	}
+
+	return r.PDB, nil
+}
+
+func (r *PDBReconciler) SetControllerReferences(owner metav1.Object, scheme *runtime.Scheme) error {
+	return controllerutil.SetControllerReference(owner, r.PDB, scheme)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TopologyAwareAnnotationKey opts a raw-deployment component's Service into
+// EndpointSlice topology hints (serving.kubernetes.io/topology-mode: Auto),
+// so a topology-aware kube-proxy routes Service traffic to same-zone
+// endpoints when available.
+const TopologyAwareAnnotationKey = "serving.kserve.io/topologyAware"
+
+// ServiceTopologyModeAnnotationKey is the well-known Service annotation that
+// enables EndpointSlice zone hints, mirrored here for the plain-Service raw
+// deployment path (ingress.TopologyModeAnnotationKey names the same
+// annotation; the ingress package does not set it).
+const ServiceTopologyModeAnnotationKey = "service.kubernetes.io/topology-mode"
+
+const serviceTopologyModeAuto = "Auto"
+
+// IsTopologyAwareEnabled reports whether the InferenceService opted its
+// Service into EndpointSlice topology hints.
+func IsTopologyAwareEnabled(annotations map[string]string) bool {
+	return annotations[TopologyAwareAnnotationKey] == "true"
+}
+
+// BuildServiceTopologyAnnotations returns the annotations a raw-deployment
+// component's Service should carry, or nil when topology-awareness wasn't
+// requested.
+func BuildServiceTopologyAnnotations(annotations map[string]string) map[string]string {
+	if !IsTopologyAwareEnabled(annotations) {
+		return nil
+	}
+	return map[string]string{ServiceTopologyModeAnnotationKey: serviceTopologyModeAuto}
+}
+
+// ModelReadyConditionType is a pod readiness gate condition the model
+// agent/queue-proxy flips to True only once the model has finished loading,
+// so a pod that's TCP-ready but still loading a model isn't added to the
+// Service's/EndpointSlice's ready endpoints and doesn't receive traffic or
+// factor into HPA's ready-replica count.
+const ModelReadyConditionType corev1.PodConditionType = "serving.kserve.io/model-ready"
+
+// ModelReadyReadinessGate is the PodSpec.ReadinessGates entry a raw-deployment
+// component's pod template should carry so kubelet waits on
+// ModelReadyConditionType before marking the pod Ready.
+func ModelReadyReadinessGate() corev1.PodReadinessGate {
+	return corev1.PodReadinessGate{ConditionType: ModelReadyConditionType}
+}
+
+// discoveryGroupVersionEndpointSliceV1 is the GroupVersion EndpointSliceV1Available
+// checks for among a cluster's served API resources.
+const discoveryGroupVersionEndpointSliceV1 = "discovery.k8s.io/v1"
+
+// EndpointSliceV1Available reports whether discovery.k8s.io/v1 is among the
+// cluster's served API groups, so callers can gracefully skip
+// EndpointSlice-based zone-hint publishing (falling back to a plain Service
+// with no topology hints) on clusters too old to serve it.
+func EndpointSliceV1Available(servedAPIGroupVersions []string) bool {
+	for _, gv := range servedAPIGroupVersions {
+		if gv == discoveryGroupVersionEndpointSliceV1 {
+			return true
+		}
+	}
+	return false
+}
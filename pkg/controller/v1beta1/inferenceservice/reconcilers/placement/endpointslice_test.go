@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import "testing"
+
+func TestIsTopologyAwareEnabled(t *testing.T) {
+	if IsTopologyAwareEnabled(nil) {
+		t.Fatalf("expected no annotations to mean topology-awareness is disabled")
+	}
+	if IsTopologyAwareEnabled(map[string]string{TopologyAwareAnnotationKey: "false"}) {
+		t.Fatalf("expected an explicit 'false' to mean topology-awareness is disabled")
+	}
+	if !IsTopologyAwareEnabled(map[string]string{TopologyAwareAnnotationKey: "true"}) {
+		t.Fatalf("expected 'true' to enable topology-awareness")
+	}
+}
+
+func TestBuildServiceTopologyAnnotationsDisabled(t *testing.T) {
+	if got := BuildServiceTopologyAnnotations(nil); got != nil {
+		t.Fatalf("expected no annotations when topology-awareness isn't requested, got %+v", got)
+	}
+}
+
+func TestBuildServiceTopologyAnnotationsEnabled(t *testing.T) {
+	got := BuildServiceTopologyAnnotations(map[string]string{TopologyAwareAnnotationKey: "true"})
+	if got[ServiceTopologyModeAnnotationKey] != "Auto" {
+		t.Fatalf("unexpected annotations: %+v", got)
+	}
+}
+
+func TestModelReadyReadinessGate(t *testing.T) {
+	got := ModelReadyReadinessGate()
+	if got.ConditionType != ModelReadyConditionType {
+		t.Fatalf("unexpected readiness gate: %+v", got)
+	}
+}
+
+func TestEndpointSliceV1Available(t *testing.T) {
+	if EndpointSliceV1Available([]string{"v1", "apps/v1"}) {
+		t.Fatalf("expected discovery.k8s.io/v1 to be reported unavailable")
+	}
+	if !EndpointSliceV1Available([]string{"v1", "discovery.k8s.io/v1"}) {
+		t.Fatalf("expected discovery.k8s.io/v1 to be reported available")
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package placement builds the default zone/hostname TopologySpreadConstraints
+// and pod anti-affinity a raw-deployment component's Deployment gets once it
+// can run more than one replica, so replicas don't all land on one node/zone
+// by chance. A single-replica component gets neither, since spreading one pod
+// is meaningless.
+package placement
+
+import (
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	zoneTopologyKey     = "topology.kubernetes.io/zone"
+	hostnameTopologyKey = "kubernetes.io/hostname"
+	// LocalityHintLabelKey is propagated onto the component's generated
+	// Service so downstream service meshes / EndpointSlice consumers can
+	// prefer same-zone endpoints.
+	LocalityHintLabelKey = "serving.kserve.io/topology-locality-hint"
+)
+
+// BuildTopologySpreadConstraints returns the zone and hostname spread
+// constraints for a component, or nil when maxReplicas <= 1 or
+// placement.DisableTopologySpread is set. selectorLabels identifies the
+// component's own pods (the same labels used as Deployment.Spec.Selector).
+func BuildTopologySpreadConstraints(maxReplicas int32, selectorLabels map[string]string, placement *v1beta1.PlacementSpec) []corev1.TopologySpreadConstraint {
+	if maxReplicas <= 1 {
+		return nil
+	}
+	if placement != nil && placement.DisableTopologySpread {
+		return nil
+	}
+	unsatisfiable := corev1.ScheduleAnyway
+	if placement != nil && placement.WhenUnsatisfiable != nil {
+		unsatisfiable = *placement.WhenUnsatisfiable
+	}
+	selector := &metav1.LabelSelector{MatchLabels: selectorLabels}
+	return []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       zoneTopologyKey,
+			WhenUnsatisfiable: unsatisfiable,
+			LabelSelector:     selector,
+		},
+		{
+			MaxSkew:           1,
+			TopologyKey:       hostnameTopologyKey,
+			WhenUnsatisfiable: unsatisfiable,
+			LabelSelector:     selector,
+		},
+	}
+}
+
+// BuildPodAntiAffinity returns a soft pod anti-affinity keyed on
+// selectorLabels[constants.RawDeploymentAppLabel], or nil when maxReplicas <=
+// 1 or placement.DisableTopologySpread is set, mirroring
+// BuildTopologySpreadConstraints's own gating.
+func BuildPodAntiAffinity(maxReplicas int32, selectorLabels map[string]string, placement *v1beta1.PlacementSpec) *corev1.Affinity {
+	if maxReplicas <= 1 {
+		return nil
+	}
+	if placement != nil && placement.DisableTopologySpread {
+		return nil
+	}
+	appLabel, ok := selectorLabels[constants.RawDeploymentAppLabel]
+	if !ok {
+		return nil
+	}
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						TopologyKey: hostnameTopologyKey,
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{constants.RawDeploymentAppLabel: appLabel},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// BuildServiceLocalityLabels returns the label set BuildTopologySpreadConstraints'
+// caller should add to the component's generated Service so a topology-aware
+// kube-proxy/service mesh can prefer routing within the same zone as the
+// serving pod.
+func BuildServiceLocalityLabels() map[string]string {
+	return map[string]string{LocalityHintLabelKey: "true"}
+}
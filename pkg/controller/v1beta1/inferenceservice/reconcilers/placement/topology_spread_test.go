@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildTopologySpreadConstraintsSingleReplica(t *testing.T) {
+	if got := BuildTopologySpreadConstraints(1, nil, nil); got != nil {
+		t.Fatalf("expected no constraints for a single replica, got %+v", got)
+	}
+}
+
+func TestBuildTopologySpreadConstraintsDisabled(t *testing.T) {
+	placement := &v1beta1.PlacementSpec{DisableTopologySpread: true}
+	if got := BuildTopologySpreadConstraints(3, nil, placement); got != nil {
+		t.Fatalf("expected no constraints when DisableTopologySpread is set, got %+v", got)
+	}
+}
+
+func TestBuildTopologySpreadConstraintsDefaultsToScheduleAnyway(t *testing.T) {
+	selectorLabels := map[string]string{"app": "my-predictor"}
+	got := BuildTopologySpreadConstraints(3, selectorLabels, nil)
+	if len(got) != 2 {
+		t.Fatalf("expected one constraint for zone and one for hostname, got %+v", got)
+	}
+	for _, c := range got {
+		if c.WhenUnsatisfiable != corev1.ScheduleAnyway {
+			t.Fatalf("expected the default WhenUnsatisfiable to be ScheduleAnyway, got %+v", c)
+		}
+		if c.LabelSelector.MatchLabels["app"] != "my-predictor" {
+			t.Fatalf("expected the selector labels to be propagated, got %+v", c.LabelSelector)
+		}
+	}
+	if got[0].TopologyKey != zoneTopologyKey || got[1].TopologyKey != hostnameTopologyKey {
+		t.Fatalf("expected zone then hostname topology keys, got %+v", got)
+	}
+}
+
+func TestBuildTopologySpreadConstraintsHonorsExplicitWhenUnsatisfiable(t *testing.T) {
+	placement := &v1beta1.PlacementSpec{WhenUnsatisfiable: ptr.To(corev1.DoNotSchedule)}
+	got := BuildTopologySpreadConstraints(3, nil, placement)
+	for _, c := range got {
+		if c.WhenUnsatisfiable != corev1.DoNotSchedule {
+			t.Fatalf("expected DoNotSchedule to be honored, got %+v", c)
+		}
+	}
+}
+
+func TestBuildPodAntiAffinitySingleReplica(t *testing.T) {
+	selectorLabels := map[string]string{constants.RawDeploymentAppLabel: "my-predictor"}
+	if got := BuildPodAntiAffinity(1, selectorLabels, nil); got != nil {
+		t.Fatalf("expected no anti-affinity for a single replica, got %+v", got)
+	}
+}
+
+func TestBuildPodAntiAffinityDisabled(t *testing.T) {
+	selectorLabels := map[string]string{constants.RawDeploymentAppLabel: "my-predictor"}
+	placement := &v1beta1.PlacementSpec{DisableTopologySpread: true}
+	if got := BuildPodAntiAffinity(3, selectorLabels, placement); got != nil {
+		t.Fatalf("expected no anti-affinity when DisableTopologySpread is set, got %+v", got)
+	}
+}
+
+func TestBuildPodAntiAffinityNoAppLabel(t *testing.T) {
+	if got := BuildPodAntiAffinity(3, map[string]string{"other": "label"}, nil); got != nil {
+		t.Fatalf("expected no anti-affinity when the app label is missing, got %+v", got)
+	}
+}
+
+func TestBuildPodAntiAffinityBuildsPreferredTerm(t *testing.T) {
+	selectorLabels := map[string]string{constants.RawDeploymentAppLabel: "my-predictor"}
+	got := BuildPodAntiAffinity(3, selectorLabels, nil)
+	if got == nil || got.PodAntiAffinity == nil {
+		t.Fatalf("expected a pod anti-affinity to be set")
+	}
+	terms := got.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	if len(terms) != 1 || terms[0].Weight != 100 {
+		t.Fatalf("unexpected preferred terms: %+v", terms)
+	}
+	if terms[0].PodAffinityTerm.TopologyKey != hostnameTopologyKey {
+		t.Fatalf("expected the hostname topology key, got %q", terms[0].PodAffinityTerm.TopologyKey)
+	}
+	if terms[0].PodAffinityTerm.LabelSelector.MatchLabels[constants.RawDeploymentAppLabel] != "my-predictor" {
+		t.Fatalf("unexpected label selector: %+v", terms[0].PodAffinityTerm.LabelSelector)
+	}
+}
+
+func TestBuildServiceLocalityLabels(t *testing.T) {
+	got := BuildServiceLocalityLabels()
+	if got[LocalityHintLabelKey] != "true" {
+		t.Fatalf("unexpected locality labels: %+v", got)
+	}
+}
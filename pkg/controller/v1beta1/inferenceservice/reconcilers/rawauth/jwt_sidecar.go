@@ -0,0 +1,437 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rawauth builds the JWT-authentication Envoy sidecar for raw
+// deployments: a portable alternative to the OpenShift-specific oauth-proxy
+// sidecar (constants.ODHKserveRawAuth), selected via
+// constants.RawAuthModeAnnotationKey == constants.RawAuthModeJWT. It chains
+// Envoy's JWT authentication HTTP filter (validating against a JWKS URI,
+// issuer, and audiences) before an RBAC filter that maps JWT claims onto
+// SubjectAccessReview-style policies bound to the InferenceService.
+package rawauth
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kserve/kserve/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+)
+
+// AuthReadyConditionType is the ISVC status condition rawauth's JWKS
+// fetch/refresh loop should surface: True once the JWKS has been fetched
+// successfully at least once, False while every attempt so far has failed.
+// A transient refresh failure after that first success does not flip this
+// back to False, since renderBootstrap's remote_jwks config keeps serving
+// the last-good keyset instead of rejecting traffic.
+//
+// This constant only names the condition; wiring it onto
+// InferenceServiceStatus.Conditions belongs to the raw-deployment status
+// reconciler.
+const AuthReadyConditionType = "AuthReady"
+
+// JWTAuthConfig is the JWKS/issuer/audience reference read off an
+// InferenceService's annotations when RawAuthModeAnnotationKey is
+// RawAuthModeJWT (or ODHEnableJWTAuthAnnotationKey is truthy).
+type JWTAuthConfig struct {
+	JWKSURI              string
+	Issuer               string
+	Audiences            []string
+	ForwardPayloadHeader string
+	ClaimToHeader        map[string]string
+	RefreshInterval      string
+	// AllowedSubjects and AllowedGroups are matched against the validated
+	// JWT's `sub`/`groups` claims by the RBAC filter: a request is allowed
+	// only if its subject or one of its groups appears in one of these
+	// lists. Both empty means deny every request, not allow every
+	// authenticated one.
+	AllowedSubjects []string
+	AllowedGroups   []string
+}
+
+// IsJWTAuthMode reports whether annotations select the JWT sidecar instead of
+// the default oauth-proxy sidecar.
+func IsJWTAuthMode(annotations map[string]string) bool {
+	return annotations[constants.RawAuthModeAnnotationKey] == constants.RawAuthModeJWT ||
+		annotations[constants.ODHEnableJWTAuthAnnotationKey] == "true"
+}
+
+// IsOAuthProxyMode reports whether annotations select the OpenShift
+// oauth-proxy sidecar (constants.ODHKserveRawAuth).
+func IsOAuthProxyMode(annotations map[string]string) bool {
+	return annotations[constants.ODHKserveRawAuth] == "true"
+}
+
+// ValidateAuthModeConflict rejects an InferenceService that selects both the
+// oauth-proxy sidecar and JWT auth mode: both bind the raw-deployment pod's
+// externally-reachable port, so only one auth sidecar can actually own it.
+func ValidateAuthModeConflict(annotations map[string]string) error {
+	if IsOAuthProxyMode(annotations) && IsJWTAuthMode(annotations) {
+		return fmt.Errorf("%s and JWT auth mode (%s or %s=true) cannot both be enabled on the same InferenceService",
+			constants.ODHKserveRawAuth, constants.RawAuthModeAnnotationKey, constants.ODHEnableJWTAuthAnnotationKey)
+	}
+	return nil
+}
+
+// ExtractJWTAuthConfig reads JWTAuthConfig off annotations, returning false if
+// JWT auth mode isn't selected or the required JWKS URI/issuer are missing.
+func ExtractJWTAuthConfig(annotations map[string]string) (JWTAuthConfig, bool) {
+	if !IsJWTAuthMode(annotations) {
+		return JWTAuthConfig{}, false
+	}
+	cfg := JWTAuthConfig{
+		JWKSURI:              annotations[constants.RawAuthJWKSURIAnnotationKey],
+		Issuer:               annotations[constants.RawAuthJWTIssuerAnnotationKey],
+		ForwardPayloadHeader: constants.DefaultJWTForwardPayloadHeader,
+		RefreshInterval:      constants.DefaultJWTRefreshInterval,
+	}
+	if cfg.JWKSURI == "" || cfg.Issuer == "" {
+		return JWTAuthConfig{}, false
+	}
+	if audiences := annotations[constants.RawAuthJWTAudiencesAnnotationKey]; audiences != "" {
+		for _, aud := range strings.Split(audiences, ",") {
+			if aud = strings.TrimSpace(aud); aud != "" {
+				cfg.Audiences = append(cfg.Audiences, aud)
+			}
+		}
+	}
+	if header := annotations[constants.RawAuthJWTForwardPayloadHeaderAnnotationKey]; header != "" {
+		cfg.ForwardPayloadHeader = header
+	}
+	if interval := annotations[constants.RawAuthJWTRefreshIntervalAnnotationKey]; interval != "" {
+		cfg.RefreshInterval = interval
+	}
+	if mapping := annotations[constants.RawAuthJWTClaimToHeaderAnnotationKey]; mapping != "" {
+		cfg.ClaimToHeader = map[string]string{}
+		for _, pair := range strings.Split(mapping, ",") {
+			claim, header, ok := strings.Cut(strings.TrimSpace(pair), ":")
+			if !ok || claim == "" || header == "" {
+				continue
+			}
+			cfg.ClaimToHeader[claim] = header
+		}
+	}
+	cfg.AllowedSubjects = splitTrimmed(annotations[constants.RawAuthJWTAllowedSubjectsAnnotationKey])
+	cfg.AllowedGroups = splitTrimmed(annotations[constants.RawAuthJWTAllowedGroupsAnnotationKey])
+	return cfg, true
+}
+
+// splitTrimmed splits a comma-separated annotation value, dropping empty
+// entries, and returns nil (not an empty slice) when s is empty so callers
+// can treat "annotation absent" and "annotation empty" identically.
+func splitTrimmed(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// BootstrapConfigMapName returns the name of the ConfigMap
+// BuildBootstrapConfigMap produces for componentName, e.g.
+// "my-isvc-predictor-jwt-auth-envoy-config".
+func BootstrapConfigMapName(componentName string) string {
+	return componentName + constants.JWTAuthEnvoyBootstrapConfigMapSuffix
+}
+
+// envoyBootstrap is the minimal static Envoy bootstrap config this package
+// renders to YAML: a single listener terminating on JWTAuthEnvoyPort, running
+// the request through a JWT authentication filter then an RBAC filter before
+// proxying to the component's own container port.
+type envoyBootstrap struct {
+	StaticResources envoyStaticResources `json:"static_resources"`
+}
+
+type envoyStaticResources struct {
+	Listeners []envoyListener `json:"listeners"`
+	Clusters  []envoyCluster  `json:"clusters"`
+}
+
+type envoyListener struct {
+	Name         string             `json:"name"`
+	Address      envoySocketAddress `json:"address"`
+	FilterChains []envoyFilterChain `json:"filter_chains"`
+}
+
+type envoySocketAddress struct {
+	SocketAddress envoySocketAddressInner `json:"socket_address"`
+}
+
+type envoySocketAddressInner struct {
+	Address   string `json:"address"`
+	PortValue int32  `json:"port_value"`
+}
+
+type envoyFilterChain struct {
+	Filters []envoyFilter `json:"filters"`
+}
+
+type envoyFilter struct {
+	Name        string                 `json:"name"`
+	TypedConfig map[string]interface{} `json:"typed_config"`
+}
+
+type envoyCluster struct {
+	Name           string              `json:"name"`
+	ConnectTimeout string              `json:"connect_timeout"`
+	LoadAssignment envoyLoadAssignment `json:"load_assignment"`
+}
+
+type envoyLoadAssignment struct {
+	ClusterName string             `json:"cluster_name"`
+	Endpoints   []envoyLBEndpoints `json:"endpoints"`
+}
+
+type envoyLBEndpoints struct {
+	LBEndpoints []envoyLBEndpoint `json:"lb_endpoints"`
+}
+
+type envoyLBEndpoint struct {
+	Endpoint envoyEndpoint `json:"endpoint"`
+}
+
+type envoyEndpoint struct {
+	Address envoySocketAddress `json:"address"`
+}
+
+const upstreamClusterName = "upstream"
+
+// jwtPayloadMetadataKey is the dynamic-metadata namespace the JWT filter
+// copies validated claims into (provider's payload_in_metadata), and the
+// namespace claimPrincipal reads them back from when building RBAC
+// principals.
+const jwtPayloadMetadataKey = "kserve-jwt-payload"
+
+// claimPolicies builds the RBAC filter's policies mapping cfg's allowed
+// subjects/groups onto a single "claim-authorized" policy matching any
+// request: action ALLOW plus no matching policy denies the request, same as
+// Kubernetes RBAC with no binding. Any one of the listed subjects or groups
+// is sufficient (an OR across the union of both lists), mirroring the
+// InferenceGraph invoke RBAC's AllowedGroups/AllowedServiceAccounts model.
+func claimPolicies(cfg JWTAuthConfig) map[string]interface{} {
+	var principals []interface{}
+	for _, subject := range cfg.AllowedSubjects {
+		principals = append(principals, claimPrincipal("sub", subject))
+	}
+	for _, group := range cfg.AllowedGroups {
+		principals = append(principals, claimPrincipal("groups", group))
+	}
+	if len(principals) == 0 {
+		return map[string]interface{}{}
+	}
+	return map[string]interface{}{
+		"claim-authorized": map[string]interface{}{
+			"permissions": []interface{}{map[string]interface{}{"any": true}},
+			"principals":  principals,
+		},
+	}
+}
+
+// claimPrincipal builds an RBAC principal matching a single claim value: an
+// exact match for a scalar claim (sub), or membership in a repeated claim
+// (groups) via list_match/any_match.
+func claimPrincipal(claim, value string) map[string]interface{} {
+	var matcher map[string]interface{}
+	if claim == "groups" {
+		matcher = map[string]interface{}{
+			"list_match": map[string]interface{}{
+				"one_of": map[string]interface{}{"string_match": map[string]interface{}{"exact": value}},
+			},
+		}
+	} else {
+		matcher = map[string]interface{}{"string_match": map[string]interface{}{"exact": value}}
+	}
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"filter": "envoy.filters.http.jwt_authn",
+			"path": []interface{}{
+				map[string]interface{}{"key": jwtPayloadMetadataKey},
+				map[string]interface{}{"key": claim},
+			},
+			"value": matcher,
+		},
+	}
+}
+
+// renderBootstrap builds the Envoy bootstrap config authorizing JWTs from cfg
+// before proxying to 127.0.0.1:upstreamPort, the component's own container.
+func renderBootstrap(cfg JWTAuthConfig, upstreamPort int32) envoyBootstrap {
+	forwardPayloadHeader := cfg.ForwardPayloadHeader
+	if forwardPayloadHeader == "" {
+		forwardPayloadHeader = constants.DefaultJWTForwardPayloadHeader
+	}
+	refreshInterval := cfg.RefreshInterval
+	if refreshInterval == "" {
+		refreshInterval = constants.DefaultJWTRefreshInterval
+	}
+
+	provider := map[string]interface{}{
+		"issuer":    cfg.Issuer,
+		"audiences": cfg.Audiences,
+		// async_fetch pre-fetches the JWKS at startup rather than blocking the
+		// first request on it; on a failed refresh, envoy jwt_authn keeps
+		// serving the last successfully fetched keyset for cache_duration
+		// instead of rejecting every request until the endpoint recovers.
+		"remote_jwks": map[string]interface{}{
+			"http_uri":       map[string]interface{}{"uri": cfg.JWKSURI, "cluster": "jwks", "timeout": "5s"},
+			"cache_duration": refreshInterval,
+			"async_fetch":    map[string]interface{}{"fast_listener": true},
+		},
+		"forward_payload_header": forwardPayloadHeader,
+		// payload_in_metadata copies the validated claims into dynamic
+		// metadata under this key, which the RBAC filter's principals below
+		// read claims from to authorize the request.
+		"payload_in_metadata": jwtPayloadMetadataKey,
+	}
+	if len(cfg.ClaimToHeader) > 0 {
+		claimToHeaders := make([]interface{}, 0, len(cfg.ClaimToHeader))
+		for claim, header := range cfg.ClaimToHeader {
+			claimToHeaders = append(claimToHeaders, map[string]interface{}{"header_name": header, "claim_name": claim})
+		}
+		provider["claim_to_headers"] = claimToHeaders
+	}
+
+	jwtFilter := map[string]interface{}{
+		"@type": "type.googleapis.com/envoy.extensions.filters.http.jwt_authn.v3.JwtAuthentication",
+		"providers": map[string]interface{}{
+			"kserve": provider,
+		},
+		"rules": []interface{}{
+			map[string]interface{}{"match": map[string]interface{}{"prefix": "/"}, "requires": map[string]interface{}{"provider_name": "kserve"}},
+		},
+	}
+	rbacFilter := map[string]interface{}{
+		"@type": "type.googleapis.com/envoy.extensions.filters.http.rbac.v3.RBAC",
+		"rules": map[string]interface{}{
+			"action":   "ALLOW",
+			"policies": claimPolicies(cfg),
+		},
+	}
+
+	return envoyBootstrap{
+		StaticResources: envoyStaticResources{
+			Listeners: []envoyListener{
+				{
+					Name: "https",
+					Address: envoySocketAddress{
+						SocketAddress: envoySocketAddressInner{Address: "0.0.0.0", PortValue: constants.JWTAuthEnvoyPort},
+					},
+					FilterChains: []envoyFilterChain{
+						{
+							Filters: []envoyFilter{
+								{Name: "envoy.filters.http.jwt_authn", TypedConfig: jwtFilter},
+								{Name: "envoy.filters.http.rbac", TypedConfig: rbacFilter},
+							},
+						},
+					},
+				},
+			},
+			Clusters: []envoyCluster{
+				{
+					Name:           upstreamClusterName,
+					ConnectTimeout: "1s",
+					LoadAssignment: envoyLoadAssignment{
+						ClusterName: upstreamClusterName,
+						Endpoints: []envoyLBEndpoints{
+							{LBEndpoints: []envoyLBEndpoint{{Endpoint: envoyEndpoint{Address: envoySocketAddress{
+								SocketAddress: envoySocketAddressInner{Address: "127.0.0.1", PortValue: upstreamPort},
+							}}}}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// BuildBootstrapConfigMap renders the Envoy bootstrap config for cfg/upstreamPort
+// into a ConfigMap the sidecar mounts at startup.
+func BuildBootstrapConfigMap(namespace, componentName string, cfg JWTAuthConfig, upstreamPort int32) (*corev1.ConfigMap, error) {
+	data, err := yaml.Marshal(renderBootstrap(cfg, upstreamPort))
+	if err != nil {
+		return nil, fmt.Errorf("rendering envoy bootstrap config: %w", err)
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      BootstrapConfigMapName(componentName),
+			Namespace: namespace,
+		},
+		Data: map[string]string{"envoy-bootstrap.yaml": string(data)},
+	}, nil
+}
+
+// BuildJWTAuthSidecarContainer builds the Envoy sidecar container, mirroring
+// the oauth-proxy sidecar's shape (ports, probes, resources) so the two auth
+// modes are interchangeable in the Deployment's container list.
+func BuildJWTAuthSidecarContainer(componentName string) corev1.Container {
+	return corev1.Container{
+		Name:  constants.JWTAuthEnvoyContainerName,
+		Image: constants.JWTAuthEnvoyImage,
+		Args:  []string{"--config-path", "/etc/envoy/envoy-bootstrap.yaml"},
+		Ports: []corev1.ContainerPort{
+			{ContainerPort: constants.JWTAuthEnvoyPort, Name: "https", Protocol: corev1.ProtocolTCP},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "jwt-auth-envoy-config", MountPath: "/etc/envoy"},
+		},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path:   "/ready",
+					Port:   intstr.FromInt(constants.JWTAuthEnvoyPort),
+					Scheme: corev1.URISchemeHTTPS,
+				},
+			},
+			InitialDelaySeconds: 5,
+			TimeoutSeconds:      1,
+			PeriodSeconds:       5,
+			SuccessThreshold:    1,
+			FailureThreshold:    3,
+		},
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(constants.JWTAuthEnvoyResourceCPULimit),
+				corev1.ResourceMemory: resource.MustParse(constants.JWTAuthEnvoyResourceMemoryLimit),
+			},
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(constants.JWTAuthEnvoyResourceCPURequest),
+				corev1.ResourceMemory: resource.MustParse(constants.JWTAuthEnvoyResourceMemoryRequest),
+			},
+		},
+	}
+}
+
+// BuildBootstrapVolume builds the Volume that mounts the ConfigMap
+// BuildBootstrapConfigMap produced into the sidecar container.
+func BuildBootstrapVolume(componentName string) corev1.Volume {
+	return corev1.Volume{
+		Name: "jwt-auth-envoy-config",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: BootstrapConfigMapName(componentName)},
+			},
+		},
+	}
+}
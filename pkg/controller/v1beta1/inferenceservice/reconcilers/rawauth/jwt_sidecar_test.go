@@ -0,0 +1,174 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rawauth
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestIsJWTAuthMode(t *testing.T) {
+	cases := []struct {
+		name        string
+		annotations map[string]string
+		want        bool
+	}{
+		{name: "auth-mode jwt", annotations: map[string]string{constants.RawAuthModeAnnotationKey: "jwt"}, want: true},
+		{name: "odh enable flag", annotations: map[string]string{constants.ODHEnableJWTAuthAnnotationKey: "true"}, want: true},
+		{name: "neither set", annotations: map[string]string{}, want: false},
+		{name: "auth-mode other", annotations: map[string]string{constants.RawAuthModeAnnotationKey: "other"}, want: false},
+	}
+	for _, tc := range cases {
+		if got := IsJWTAuthMode(tc.annotations); got != tc.want {
+			t.Fatalf("%s: IsJWTAuthMode() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestValidateAuthModeConflict(t *testing.T) {
+	if err := ValidateAuthModeConflict(map[string]string{constants.ODHKserveRawAuth: "true"}); err != nil {
+		t.Fatalf("expected no conflict for oauth-proxy alone, got %v", err)
+	}
+	conflicting := map[string]string{constants.ODHKserveRawAuth: "true", constants.RawAuthModeAnnotationKey: constants.RawAuthModeJWT}
+	if err := ValidateAuthModeConflict(conflicting); err == nil {
+		t.Fatalf("expected an error when both oauth-proxy and JWT mode are enabled")
+	}
+}
+
+func TestExtractJWTAuthConfigNotEnabled(t *testing.T) {
+	if _, ok := ExtractJWTAuthConfig(map[string]string{}); ok {
+		t.Fatalf("expected no config when JWT auth mode is not selected")
+	}
+}
+
+func TestExtractJWTAuthConfigMissingRequiredFields(t *testing.T) {
+	annotations := map[string]string{constants.RawAuthModeAnnotationKey: constants.RawAuthModeJWT}
+	if _, ok := ExtractJWTAuthConfig(annotations); ok {
+		t.Fatalf("expected no config when JWKS URI/issuer are missing")
+	}
+}
+
+func TestExtractJWTAuthConfigParsesAllowedSubjectsAndGroups(t *testing.T) {
+	annotations := map[string]string{
+		constants.RawAuthModeAnnotationKey:               constants.RawAuthModeJWT,
+		constants.RawAuthJWKSURIAnnotationKey:            "https://issuer.example.com/jwks",
+		constants.RawAuthJWTIssuerAnnotationKey:          "https://issuer.example.com",
+		constants.RawAuthJWTAllowedSubjectsAnnotationKey: "alice, bob",
+		constants.RawAuthJWTAllowedGroupsAnnotationKey:   "ml-team,  ",
+	}
+	cfg, ok := ExtractJWTAuthConfig(annotations)
+	if !ok {
+		t.Fatalf("expected a valid config")
+	}
+	if len(cfg.AllowedSubjects) != 2 || cfg.AllowedSubjects[0] != "alice" || cfg.AllowedSubjects[1] != "bob" {
+		t.Fatalf("unexpected allowed subjects: %v", cfg.AllowedSubjects)
+	}
+	if len(cfg.AllowedGroups) != 1 || cfg.AllowedGroups[0] != "ml-team" {
+		t.Fatalf("unexpected allowed groups: %v", cfg.AllowedGroups)
+	}
+}
+
+func TestClaimPoliciesDenyByDefault(t *testing.T) {
+	policies := claimPolicies(JWTAuthConfig{})
+	if len(policies) != 0 {
+		t.Fatalf("expected no policies (deny-by-default) when no subjects/groups are configured, got %+v", policies)
+	}
+}
+
+func TestClaimPoliciesGrantsConfiguredSubjectsAndGroups(t *testing.T) {
+	cfg := JWTAuthConfig{AllowedSubjects: []string{"alice"}, AllowedGroups: []string{"ml-team"}}
+	policies := claimPolicies(cfg)
+	policy, ok := policies["claim-authorized"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a claim-authorized policy, got %+v", policies)
+	}
+	principals, ok := policy["principals"].([]interface{})
+	if !ok || len(principals) != 2 {
+		t.Fatalf("expected one principal per allowed subject/group, got %+v", policy["principals"])
+	}
+}
+
+func TestClaimPrincipalSubjectUsesExactStringMatch(t *testing.T) {
+	principal := claimPrincipal("sub", "alice")
+	metadata := principal["metadata"].(map[string]interface{})
+	value := metadata["value"].(map[string]interface{})
+	if _, ok := value["string_match"]; !ok {
+		t.Fatalf("expected a string_match matcher for a scalar claim, got %+v", value)
+	}
+}
+
+func TestClaimPrincipalGroupsUsesListMatch(t *testing.T) {
+	principal := claimPrincipal("groups", "ml-team")
+	metadata := principal["metadata"].(map[string]interface{})
+	value := metadata["value"].(map[string]interface{})
+	if _, ok := value["list_match"]; !ok {
+		t.Fatalf("expected a list_match matcher for the repeated groups claim, got %+v", value)
+	}
+}
+
+func TestRenderBootstrapNoAuthorizationConfiguredDeniesAll(t *testing.T) {
+	cfg := JWTAuthConfig{JWKSURI: "https://issuer.example.com/jwks", Issuer: "https://issuer.example.com"}
+	bootstrap := renderBootstrap(cfg, 8080)
+	rbac := bootstrap.StaticResources.Listeners[0].FilterChains[0].Filters[1]
+	rules := rbac.TypedConfig["rules"].(map[string]interface{})
+	if policies, ok := rules["policies"].(map[string]interface{}); !ok || len(policies) != 0 {
+		t.Fatalf("expected no RBAC policies when no subjects/groups are allow-listed, got %+v", rules["policies"])
+	}
+}
+
+func TestRenderBootstrapSetsPayloadInMetadata(t *testing.T) {
+	cfg := JWTAuthConfig{JWKSURI: "https://issuer.example.com/jwks", Issuer: "https://issuer.example.com"}
+	bootstrap := renderBootstrap(cfg, 8080)
+	jwtFilter := bootstrap.StaticResources.Listeners[0].FilterChains[0].Filters[0]
+	providers := jwtFilter.TypedConfig["providers"].(map[string]interface{})
+	provider := providers["kserve"].(map[string]interface{})
+	if provider["payload_in_metadata"] != jwtPayloadMetadataKey {
+		t.Fatalf("expected payload_in_metadata to be set so RBAC principals can read claims, got %v", provider["payload_in_metadata"])
+	}
+}
+
+func TestBuildBootstrapConfigMap(t *testing.T) {
+	cfg := JWTAuthConfig{JWKSURI: "https://issuer.example.com/jwks", Issuer: "https://issuer.example.com", AllowedSubjects: []string{"alice"}}
+	configMap, err := BuildBootstrapConfigMap("ns", "isvc-predictor", cfg, 8080)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if configMap.Name != BootstrapConfigMapName("isvc-predictor") || configMap.Namespace != "ns" {
+		t.Fatalf("unexpected object metadata: %s/%s", configMap.Namespace, configMap.Name)
+	}
+	if _, ok := configMap.Data["envoy-bootstrap.yaml"]; !ok {
+		t.Fatalf("expected envoy-bootstrap.yaml data key")
+	}
+}
+
+func TestBuildJWTAuthSidecarContainer(t *testing.T) {
+	container := BuildJWTAuthSidecarContainer("isvc-predictor")
+	if container.Name != constants.JWTAuthEnvoyContainerName {
+		t.Fatalf("unexpected container name: %q", container.Name)
+	}
+	if len(container.Ports) != 1 || container.Ports[0].ContainerPort != constants.JWTAuthEnvoyPort {
+		t.Fatalf("unexpected container ports: %+v", container.Ports)
+	}
+}
+
+func TestBuildBootstrapVolume(t *testing.T) {
+	volume := BuildBootstrapVolume("isvc-predictor")
+	if volume.ConfigMap == nil || volume.ConfigMap.Name != BootstrapConfigMapName("isvc-predictor") {
+		t.Fatalf("unexpected volume: %+v", volume)
+	}
+}
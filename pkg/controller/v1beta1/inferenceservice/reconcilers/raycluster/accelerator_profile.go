@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// IncompatibleAcceleratorProfileReason is the Event reason
+// WarnIncompatibleAcceleratorProfiles uses, so operators can filter for it
+// with `kubectl get events --field-selector reason=...`.
+const IncompatibleAcceleratorProfileReason = "IncompatibleAcceleratorProfile"
+
+// BuildAcceleratorResourceRequirements returns the ResourceRequirements a pod
+// requesting profile should carry: profile.Count units of profile.Vendors[0]
+// on both Requests and Limits (GPU resources aren't overcommitted), or a
+// zero-value ResourceRequirements if profile is nil or lists no vendor.
+func BuildAcceleratorResourceRequirements(profile *v1beta1.AcceleratorProfile) corev1.ResourceRequirements {
+	if profile == nil || len(profile.Vendors) == 0 || profile.Count <= 0 {
+		return corev1.ResourceRequirements{}
+	}
+	qty := *resource.NewQuantity(int64(profile.Count), resource.DecimalSI)
+	resourceName := corev1.ResourceName(profile.Vendors[0])
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{resourceName: qty},
+		Limits:   corev1.ResourceList{resourceName: qty},
+	}
+}
+
+// BuildAcceleratorNodeSelector returns a node selector pinning the pod to a
+// node advertising one of profile's vendor resource names, via the
+// "<vendor>.present" node label convention some device plugins set. Absent
+// that label on a given vendor the scheduler still filters correctly on the
+// resource request itself, so this is an optimization, not a correctness
+// requirement; a nil/empty profile yields a nil selector.
+func BuildAcceleratorNodeSelector(profile *v1beta1.AcceleratorProfile) map[string]string {
+	if profile == nil || len(profile.Vendors) == 0 {
+		return nil
+	}
+	return map[string]string{fmt.Sprintf("%s.present", profile.Vendors[0]): "true"}
+}
+
+// BuildAcceleratorTolerations returns a toleration for each of profile's
+// vendor resource names, so the pod can land on nodes tainted to keep
+// non-accelerator workloads off GPU capacity (the standard
+// "<vendor>=present:NoSchedule" taint convention).
+func BuildAcceleratorTolerations(profile *v1beta1.AcceleratorProfile) []corev1.Toleration {
+	if profile == nil {
+		return nil
+	}
+	tolerations := make([]corev1.Toleration, 0, len(profile.Vendors))
+	for _, vendor := range profile.Vendors {
+		tolerations = append(tolerations, corev1.Toleration{
+			Key:      vendor,
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+		})
+	}
+	return tolerations
+}
+
+// BuildParallelSizeEnv computes TENSOR_PARALLEL_SIZE, PIPELINE_PARALLEL_SIZE
+// and RAY_NODE_COUNT from worker's AcceleratorProfile/parallel-size fields
+// rather than leaving them to be derived from container resource requests
+// elsewhere, so a profile change doesn't require separately updating env
+// vars hardcoded at the call site.
+func BuildParallelSizeEnv(worker *v1beta1.WorkerSpec) []corev1.EnvVar {
+	pipeline := 1
+	if worker.PipelineParallelSize != nil {
+		pipeline = *worker.PipelineParallelSize
+	}
+	tensor := 1
+	if worker.TensorParallelSize != nil {
+		tensor = *worker.TensorParallelSize
+	}
+	if worker.AcceleratorProfile != nil && worker.AcceleratorProfile.Count > 0 {
+		tensor = worker.AcceleratorProfile.Count
+	}
+	return []corev1.EnvVar{
+		{Name: constants.TensorParallelSizeEnvName, Value: strconv.Itoa(tensor)},
+		{Name: constants.PipelineParallelSizeEnvName, Value: strconv.Itoa(pipeline)},
+		{Name: constants.RayNodeCountEnvName, Value: strconv.Itoa(pipeline)},
+	}
+}
+
+// IncompatibleAcceleratorProfiles reports whether the head and worker
+// accelerator profiles disagree on per-node GPU count, which would leave one
+// half of the Ray cluster over- or under-provisioned relative to the
+// TENSOR_PARALLEL_SIZE the other half computes. A nil profile on either side
+// is treated as compatible, since that side isn't requesting GPUs at all
+// (e.g. a CPU-only head in front of GPU workers).
+func IncompatibleAcceleratorProfiles(head, worker *v1beta1.AcceleratorProfile) bool {
+	if head == nil || worker == nil {
+		return false
+	}
+	return head.Count != worker.Count
+}
+
+// WarnIncompatibleAcceleratorProfiles emits a Warning event against object
+// when head and worker disagree on per-node GPU count, and reports whether
+// it did. Reconcilers should still proceed with reconciling on an
+// incompatible pair rather than failing outright, since the mismatch is a
+// scheduling/performance problem for the Ray cluster, not one this
+// reconciler can itself resolve.
+func WarnIncompatibleAcceleratorProfiles(recorder record.EventRecorder, object runtime.Object, head, worker *v1beta1.AcceleratorProfile) bool {
+	if !IncompatibleAcceleratorProfiles(head, worker) {
+		return false
+	}
+	recorder.Eventf(object, corev1.EventTypeWarning, IncompatibleAcceleratorProfileReason,
+		"head accelerator profile %q requests %d GPU(s) per node but worker profile %q requests %d; the Ray cluster may be unable to shard evenly across nodes",
+		head.Name, head.Count, worker.Name, worker.Count)
+	return true
+}
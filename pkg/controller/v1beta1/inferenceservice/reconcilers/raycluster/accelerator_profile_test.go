@@ -0,0 +1,145 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildAcceleratorResourceRequirementsNilProfile(t *testing.T) {
+	got := BuildAcceleratorResourceRequirements(nil)
+	if got.Requests != nil || got.Limits != nil {
+		t.Fatalf("expected a zero-value ResourceRequirements for a nil profile, got %+v", got)
+	}
+}
+
+func TestBuildAcceleratorResourceRequirementsRequestsFirstVendor(t *testing.T) {
+	profile := &v1beta1.AcceleratorProfile{Name: "gaudi", Count: 2, Vendors: []string{"habana.ai/gaudi", "gaudi.habana.ai"}}
+	got := BuildAcceleratorResourceRequirements(profile)
+
+	qty := got.Requests[corev1.ResourceName("habana.ai/gaudi")]
+	if qty.Value() != 2 {
+		t.Fatalf("expected 2 units of the first vendor resource, got %+v", got.Requests)
+	}
+	if _, ok := got.Limits[corev1.ResourceName("habana.ai/gaudi")]; !ok {
+		t.Fatalf("expected Limits to mirror Requests, got %+v", got.Limits)
+	}
+}
+
+func TestBuildAcceleratorNodeSelectorNilProfile(t *testing.T) {
+	if got := BuildAcceleratorNodeSelector(nil); got != nil {
+		t.Fatalf("expected a nil selector for a nil profile, got %+v", got)
+	}
+}
+
+func TestBuildAcceleratorNodeSelectorUsesFirstVendor(t *testing.T) {
+	profile := &v1beta1.AcceleratorProfile{Vendors: []string{"nvidia.com/gpu"}}
+	got := BuildAcceleratorNodeSelector(profile)
+	if got["nvidia.com/gpu.present"] != "true" {
+		t.Fatalf("unexpected node selector: %+v", got)
+	}
+}
+
+func TestBuildAcceleratorTolerationsOnePerVendor(t *testing.T) {
+	profile := &v1beta1.AcceleratorProfile{Vendors: []string{"amd.com/gpu", "habana.ai/gaudi"}}
+	got := BuildAcceleratorTolerations(profile)
+	if len(got) != 2 {
+		t.Fatalf("expected one toleration per vendor, got %+v", got)
+	}
+	if got[0].Key != "amd.com/gpu" || got[0].Operator != corev1.TolerationOpExists || got[0].Effect != corev1.TaintEffectNoSchedule {
+		t.Fatalf("unexpected toleration: %+v", got[0])
+	}
+}
+
+func TestBuildParallelSizeEnvDefaults(t *testing.T) {
+	env := BuildParallelSizeEnv(&v1beta1.WorkerSpec{})
+	want := map[string]string{
+		constants.TensorParallelSizeEnvName:   "1",
+		constants.PipelineParallelSizeEnvName: "1",
+		constants.RayNodeCountEnvName:         "1",
+	}
+	for _, e := range env {
+		if want[e.Name] != e.Value {
+			t.Fatalf("unexpected env var %s=%s, want %s", e.Name, e.Value, want[e.Name])
+		}
+	}
+}
+
+func TestBuildParallelSizeEnvAcceleratorProfileOverridesTensorSize(t *testing.T) {
+	worker := &v1beta1.WorkerSpec{
+		TensorParallelSize: ptr.To(2),
+		AcceleratorProfile: &v1beta1.AcceleratorProfile{Count: 8},
+	}
+	env := BuildParallelSizeEnv(worker)
+	for _, e := range env {
+		if e.Name == constants.TensorParallelSizeEnvName && e.Value != "8" {
+			t.Fatalf("expected AcceleratorProfile.Count to override TensorParallelSize, got %s", e.Value)
+		}
+	}
+}
+
+func TestIncompatibleAcceleratorProfilesNilIsCompatible(t *testing.T) {
+	if IncompatibleAcceleratorProfiles(nil, &v1beta1.AcceleratorProfile{Count: 4}) {
+		t.Fatalf("expected a nil profile to be treated as compatible")
+	}
+}
+
+func TestIncompatibleAcceleratorProfilesDifferentCounts(t *testing.T) {
+	head := &v1beta1.AcceleratorProfile{Count: 8}
+	worker := &v1beta1.AcceleratorProfile{Count: 4}
+	if !IncompatibleAcceleratorProfiles(head, worker) {
+		t.Fatalf("expected mismatched per-node GPU counts to be reported incompatible")
+	}
+}
+
+func TestWarnIncompatibleAcceleratorProfilesEmitsEventOnMismatch(t *testing.T) {
+	recorder := record.NewFakeRecorder(1)
+	head := &v1beta1.AcceleratorProfile{Name: "head", Count: 8}
+	worker := &v1beta1.AcceleratorProfile{Name: "worker", Count: 4}
+
+	if !WarnIncompatibleAcceleratorProfiles(recorder, &corev1.Pod{}, head, worker) {
+		t.Fatalf("expected an incompatible pair to report true")
+	}
+	select {
+	case event := <-recorder.Events:
+		if event == "" {
+			t.Fatalf("expected a non-empty event")
+		}
+	default:
+		t.Fatalf("expected a warning event to be recorded")
+	}
+}
+
+func TestWarnIncompatibleAcceleratorProfilesNoEventOnMatch(t *testing.T) {
+	recorder := record.NewFakeRecorder(1)
+	profile := &v1beta1.AcceleratorProfile{Count: 4}
+
+	if WarnIncompatibleAcceleratorProfiles(recorder, &corev1.Pod{}, profile, profile) {
+		t.Fatalf("expected a compatible pair to report false")
+	}
+	select {
+	case event := <-recorder.Events:
+		t.Fatalf("expected no event to be recorded, got %q", event)
+	default:
+	}
+}
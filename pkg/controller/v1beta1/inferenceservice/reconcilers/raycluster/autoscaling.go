@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BuildHeadHPA returns the single HPA that drives multinode autoscaling:
+// it targets the head deployment alone and scales MinNodes..MaxNodes on
+// autoscaling.Metric as an external metric. The worker deployment is never
+// given its own HPA; ComputeWorkerReplicas recomputes its replica count from
+// this HPA's resulting head replica count instead, so the two can never
+// disagree on how many Ray nodes currently exist.
+func BuildHeadHPA(headMeta metav1.ObjectMeta, autoscaling *v1beta1.WorkerAutoscalingSpec) *autoscalingv2.HorizontalPodAutoscaler {
+	minNodes := autoscaling.MinNodes
+	maxNodes := autoscaling.MaxNodes
+	if maxNodes < minNodes {
+		maxNodes = minNodes
+	}
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: headMeta,
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       headMeta.Name,
+			},
+			MinReplicas: &minNodes,
+			MaxReplicas: maxNodes,
+			Metrics: []autoscalingv2.MetricSpec{
+				{
+					Type: autoscalingv2.ExternalMetricSourceType,
+					External: &autoscalingv2.ExternalMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{Name: autoscaling.Metric},
+						Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ComputeWorkerReplicas recomputes the worker deployment's replica count
+// from the head HPA's current replica count (nodes, i.e. how many
+// tensor-parallel groups are currently wanted), so the two deployments are
+// always derived from one number instead of scaling independently:
+// ceil((nodes * perNodeGPU - headGPU) / workerGPU), floored at zero.
+func ComputeWorkerReplicas(nodes int32, perNodeGPU, headGPU, workerGPU resource.Quantity) int32 {
+	if workerGPU.Value() <= 0 {
+		return 0
+	}
+	totalGPUNeeded := int64(nodes) * perNodeGPU.Value()
+	remaining := totalGPUNeeded - headGPU.Value()
+	if remaining <= 0 {
+		return 0
+	}
+	replicas := (remaining + workerGPU.Value() - 1) / workerGPU.Value()
+	return int32(replicas) // #nosec G115
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildHeadHPATargetsHeadDeployment(t *testing.T) {
+	meta := metav1.ObjectMeta{Namespace: "default", Name: "head"}
+	autoscaling := &v1beta1.WorkerAutoscalingSpec{MinNodes: 1, MaxNodes: 5, Metric: "queue_depth"}
+
+	hpa := BuildHeadHPA(meta, autoscaling)
+
+	if hpa.Spec.ScaleTargetRef.Name != "head" || hpa.Spec.ScaleTargetRef.Kind != "Deployment" {
+		t.Fatalf("unexpected scale target ref: %+v", hpa.Spec.ScaleTargetRef)
+	}
+	if *hpa.Spec.MinReplicas != 1 || hpa.Spec.MaxReplicas != 5 {
+		t.Fatalf("unexpected min/max replicas: min=%d max=%d", *hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas)
+	}
+	if len(hpa.Spec.Metrics) != 1 || hpa.Spec.Metrics[0].External.Metric.Name != "queue_depth" {
+		t.Fatalf("unexpected metrics: %+v", hpa.Spec.Metrics)
+	}
+}
+
+func TestBuildHeadHPAClampsMaxNodesToMinNodes(t *testing.T) {
+	autoscaling := &v1beta1.WorkerAutoscalingSpec{MinNodes: 5, MaxNodes: 2, Metric: "m"}
+	hpa := BuildHeadHPA(metav1.ObjectMeta{Name: "head"}, autoscaling)
+	if hpa.Spec.MaxReplicas != 5 {
+		t.Fatalf("expected MaxReplicas to be clamped up to MinNodes (5), got %d", hpa.Spec.MaxReplicas)
+	}
+}
+
+func TestComputeWorkerReplicasZeroWorkerGPU(t *testing.T) {
+	got := ComputeWorkerReplicas(4, resource.MustParse("1"), resource.MustParse("1"), resource.MustParse("0"))
+	if got != 0 {
+		t.Fatalf("expected 0 replicas when workerGPU is 0, got %d", got)
+	}
+}
+
+func TestComputeWorkerReplicasNoneNeeded(t *testing.T) {
+	got := ComputeWorkerReplicas(1, resource.MustParse("1"), resource.MustParse("2"), resource.MustParse("1"))
+	if got != 0 {
+		t.Fatalf("expected 0 replicas when the head alone already satisfies demand, got %d", got)
+	}
+}
+
+func TestComputeWorkerReplicasRoundsUp(t *testing.T) {
+	// 4 nodes * 2 GPU/node = 8 total; head uses 2; 6 remaining / 4 per worker = 1.5 -> 2.
+	got := ComputeWorkerReplicas(4, resource.MustParse("2"), resource.MustParse("2"), resource.MustParse("4"))
+	if got != 2 {
+		t.Fatalf("expected ceil(6/4) = 2 worker replicas, got %d", got)
+	}
+}
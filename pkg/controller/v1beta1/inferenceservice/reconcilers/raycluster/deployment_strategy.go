@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MultiNodeWorkload builds the head and worker workload objects for a
+// multinode predictor. The two strategy implementations below (Deployment
+// and StatefulSet) share this interface so the raw predictor reconciler can
+// build either without branching on strategy itself; only ResolveStrategy
+// needs to know the two exist.
+type MultiNodeWorkload interface {
+	// Head returns the single-replica head workload object.
+	Head(meta metav1.ObjectMeta, podTemplate corev1.PodTemplateSpec) client.Object
+	// Worker returns the worker pool workload object, bound to
+	// headlessServiceName for stable pod DNS names where the strategy
+	// supports it.
+	Worker(meta metav1.ObjectMeta, podTemplate corev1.PodTemplateSpec, replicas int32, headlessServiceName string) client.Object
+}
+
+// ResolveStrategy picks the MultiNodeWorkload strategy for a WorkerSpec:
+// worker.Strategy takes precedence over the cluster/isvc-level
+// DeploymentStrategyAnnotationKey annotation, mirroring
+// ingress.ShouldUseGatewayIngress's own per-isvc-field-overrides-annotation
+// precedence. Anything other than DeploymentStrategyStatefulSet keeps the
+// default two-Deployment strategy.
+func ResolveStrategy(annotations map[string]string, worker *v1beta1.WorkerSpec) MultiNodeWorkload {
+	strategy := annotations[constants.DeploymentStrategyAnnotationKey]
+	if worker != nil && worker.Strategy != "" {
+		strategy = worker.Strategy
+	}
+	if strategy == constants.DeploymentStrategyStatefulSet {
+		return statefulSetWorkload{}
+	}
+	return deploymentWorkload{}
+}
+
+// deploymentWorkload is today's default: two plain Deployments, with the
+// head's stable address published separately via RAY_HEAD_ADDRESS env
+// injection rather than DNS.
+type deploymentWorkload struct{}
+
+func (deploymentWorkload) Head(meta metav1.ObjectMeta, podTemplate corev1.PodTemplateSpec) client.Object {
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		ObjectMeta: meta,
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: podTemplate.Labels},
+			Template: podTemplate,
+		},
+	}
+}
+
+func (deploymentWorkload) Worker(meta metav1.ObjectMeta, podTemplate corev1.PodTemplateSpec, replicas int32, _ string) client.Object {
+	return &appsv1.Deployment{
+		ObjectMeta: meta,
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: podTemplate.Labels},
+			Template: podTemplate,
+		},
+	}
+}
+
+// statefulSetWorkload provisions the head as a single-replica StatefulSet
+// and the worker pool as a second StatefulSet, both bound to the existing
+// headless Service so pod DNS names (<name>-0.<headless>) stay stable across
+// restarts and any PVC-backed model cache survives rescheduling.
+type statefulSetWorkload struct{}
+
+func (statefulSetWorkload) Head(meta metav1.ObjectMeta, podTemplate corev1.PodTemplateSpec) client.Object {
+	replicas := int32(1)
+	return &appsv1.StatefulSet{
+		ObjectMeta: meta,
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    &replicas,
+			ServiceName: meta.Name,
+			Selector:    &metav1.LabelSelector{MatchLabels: podTemplate.Labels},
+			Template:    podTemplate,
+		},
+	}
+}
+
+func (statefulSetWorkload) Worker(meta metav1.ObjectMeta, podTemplate corev1.PodTemplateSpec, replicas int32, headlessServiceName string) client.Object {
+	return &appsv1.StatefulSet{
+		ObjectMeta: meta,
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    &replicas,
+			ServiceName: headlessServiceName,
+			Selector:    &metav1.LabelSelector{MatchLabels: podTemplate.Labels},
+			Template:    podTemplate,
+		},
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResolveStrategyDefaultsToDeployment(t *testing.T) {
+	strategy := ResolveStrategy(nil, nil)
+	if _, ok := strategy.(deploymentWorkload); !ok {
+		t.Fatalf("expected the default strategy to be deploymentWorkload, got %T", strategy)
+	}
+}
+
+func TestResolveStrategyHonorsAnnotation(t *testing.T) {
+	annotations := map[string]string{constants.DeploymentStrategyAnnotationKey: constants.DeploymentStrategyStatefulSet}
+	strategy := ResolveStrategy(annotations, nil)
+	if _, ok := strategy.(statefulSetWorkload); !ok {
+		t.Fatalf("expected the annotation to select statefulSetWorkload, got %T", strategy)
+	}
+}
+
+func TestResolveStrategyWorkerFieldOverridesAnnotation(t *testing.T) {
+	annotations := map[string]string{constants.DeploymentStrategyAnnotationKey: constants.DeploymentStrategyStatefulSet}
+	worker := &v1beta1.WorkerSpec{Strategy: constants.DeploymentStrategyDeployment}
+	strategy := ResolveStrategy(annotations, worker)
+	if _, ok := strategy.(deploymentWorkload); !ok {
+		t.Fatalf("expected worker.Strategy to take precedence over the annotation, got %T", strategy)
+	}
+}
+
+func TestDeploymentWorkloadHeadIsSingleReplica(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "head"}
+	podTemplate := corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "head"}}}
+
+	obj := deploymentWorkload{}.Head(meta, podTemplate)
+	deployment, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		t.Fatalf("expected a *appsv1.Deployment, got %T", obj)
+	}
+	if *deployment.Spec.Replicas != 1 {
+		t.Fatalf("expected the head deployment to always have 1 replica, got %d", *deployment.Spec.Replicas)
+	}
+}
+
+func TestDeploymentWorkloadWorkerUsesGivenReplicas(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "worker"}
+	podTemplate := corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "worker"}}}
+
+	obj := deploymentWorkload{}.Worker(meta, podTemplate, 4, "ignored-headless-svc")
+	deployment, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		t.Fatalf("expected a *appsv1.Deployment, got %T", obj)
+	}
+	if *deployment.Spec.Replicas != 4 {
+		t.Fatalf("expected 4 worker replicas, got %d", *deployment.Spec.Replicas)
+	}
+}
+
+func TestStatefulSetWorkloadHeadUsesOwnNameAsServiceName(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "head"}
+	podTemplate := corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "head"}}}
+
+	obj := statefulSetWorkload{}.Head(meta, podTemplate)
+	sts, ok := obj.(*appsv1.StatefulSet)
+	if !ok {
+		t.Fatalf("expected a *appsv1.StatefulSet, got %T", obj)
+	}
+	if sts.Spec.ServiceName != "head" {
+		t.Fatalf("expected the head's ServiceName to be its own name, got %q", sts.Spec.ServiceName)
+	}
+}
+
+func TestStatefulSetWorkloadWorkerUsesHeadlessServiceName(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "worker"}
+	podTemplate := corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "worker"}}}
+
+	obj := statefulSetWorkload{}.Worker(meta, podTemplate, 3, "my-headless-svc")
+	sts, ok := obj.(*appsv1.StatefulSet)
+	if !ok {
+		t.Fatalf("expected a *appsv1.StatefulSet, got %T", obj)
+	}
+	if sts.Spec.ServiceName != "my-headless-svc" {
+		t.Fatalf("expected the worker's ServiceName to be the given headless service, got %q", sts.Spec.ServiceName)
+	}
+	if *sts.Spec.Replicas != 3 {
+		t.Fatalf("expected 3 worker replicas, got %d", *sts.Spec.Replicas)
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package raycluster implements AutoscalerClassRayCluster: scaling a
+// WorkerSpec-enabled predictor's head and worker deployments together, in
+// fixed multiples of one Ray replica group's node count, instead of letting
+// HPA scale the worker deployment on its own (which would strand a
+// tensor/pipeline-parallel Ray cluster mid-resize).
+package raycluster
+
+import "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+
+// GroupSize returns the number of Ray nodes that make up one replica group,
+// defaulting either factor to 1 when unset so a WorkerSpec with no explicit
+// parallelism still scales one worker at a time.
+func GroupSize(worker *v1beta1.WorkerSpec) int32 {
+	pipeline := 1
+	if worker.PipelineParallelSize != nil {
+		pipeline = *worker.PipelineParallelSize
+	}
+	tensor := 1
+	if worker.TensorParallelSize != nil {
+		tensor = *worker.TensorParallelSize
+	}
+	return int32(pipeline * tensor) // #nosec G115
+}
+
+// ConstrainToGroupMultiple rounds desiredReplicas down to the nearest
+// multiple of groupSize, refusing to ever land on a partial replica group
+// (e.g. 3 worker pods for a group size of 4 tensor-parallel shards, which
+// would leave a Ray cluster one GPU short of being able to serve any
+// request). Never returns less than groupSize itself.
+func ConstrainToGroupMultiple(desiredReplicas, groupSize int32) int32 {
+	if groupSize <= 0 {
+		return desiredReplicas
+	}
+	constrained := (desiredReplicas / groupSize) * groupSize
+	if constrained < groupSize {
+		constrained = groupSize
+	}
+	return constrained
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"k8s.io/utils/ptr"
+)
+
+func TestGroupSizeDefaultsBothFactorsToOne(t *testing.T) {
+	if got := GroupSize(&v1beta1.WorkerSpec{}); got != 1 {
+		t.Fatalf("expected a default group size of 1, got %d", got)
+	}
+}
+
+func TestGroupSizeMultipliesPipelineAndTensor(t *testing.T) {
+	worker := &v1beta1.WorkerSpec{
+		PipelineParallelSize: ptr.To(2),
+		TensorParallelSize:   ptr.To(4),
+	}
+	if got := GroupSize(worker); got != 8 {
+		t.Fatalf("expected pipeline*tensor = 8, got %d", got)
+	}
+}
+
+func TestConstrainToGroupMultipleRoundsDown(t *testing.T) {
+	if got := ConstrainToGroupMultiple(7, 4); got != 4 {
+		t.Fatalf("expected 7 constrained to the nearest multiple of 4 to be 4, got %d", got)
+	}
+}
+
+func TestConstrainToGroupMultipleNeverGoesBelowGroupSize(t *testing.T) {
+	if got := ConstrainToGroupMultiple(2, 4); got != 4 {
+		t.Fatalf("expected a desired replica count below groupSize to floor at groupSize, got %d", got)
+	}
+}
+
+func TestConstrainToGroupMultipleZeroGroupSizePassesThrough(t *testing.T) {
+	if got := ConstrainToGroupMultiple(5, 0); got != 5 {
+		t.Fatalf("expected a zero groupSize to leave desiredReplicas unconstrained, got %d", got)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// leaderWorkerSetGroupVersion is the GroupVersion LeaderWorkerSetCRDAvailable
+// checks for among a cluster's served API resources.
+const leaderWorkerSetGroupVersion = "leaderworkerset.x-k8s.io/v1"
+
+// restartPolicyRecreateGroupOnPodRestart keeps the head and its workers as
+// one atomic rollout unit: if any pod in the group restarts, the whole group
+// is recreated together rather than letting the Ray cluster end up split
+// between old and new pods mid-update.
+const restartPolicyRecreateGroupOnPodRestart = "RecreateGroupOnPodRestart"
+
+// LeaderWorkerSetCRDAvailable reports whether the LeaderWorkerSet CRD
+// (leaderworkerset.x-k8s.io/v1) is installed in the target cluster, so
+// BuildLeaderWorkerSet is only used where it will actually be reconciled;
+// clusters without it keep today's two-Deployment/StatefulSet behavior.
+func LeaderWorkerSetCRDAvailable(servedAPIGroupVersions []string) bool {
+	for _, gv := range servedAPIGroupVersions {
+		if gv == leaderWorkerSetGroupVersion {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildLeaderWorkerSet assembles a LeaderWorkerSet in place of the separate
+// head/worker workload objects, grouping them as one atomic replica group:
+// size = PipelineParallelSize (the head plus however many workers one Ray
+// replica group needs) and replicas = MinReplicas groups. The CRD isn't
+// vendored, so this is built as unstructured.Unstructured the same way the
+// keda/otelcollector packages build their own unvendored CRs.
+func BuildLeaderWorkerSet(meta metav1.ObjectMeta, leaderTemplate, workerTemplate corev1.PodTemplateSpec, worker *v1beta1.WorkerSpec) (*unstructured.Unstructured, error) {
+	size := GroupSize(worker) / tensorParallelSizeOrOne(worker)
+	if size < 1 {
+		size = 1
+	}
+	replicas := int64(1)
+	if worker.MinReplicas != nil && *worker.MinReplicas > 0 {
+		replicas = int64(*worker.MinReplicas)
+	}
+
+	leaderMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&leaderTemplate)
+	if err != nil {
+		return nil, err
+	}
+	workerMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&workerTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	lws := &unstructured.Unstructured{}
+	lws.SetAPIVersion("leaderworkerset.x-k8s.io/v1")
+	lws.SetKind("LeaderWorkerSet")
+	lws.SetNamespace(meta.Namespace)
+	lws.SetName(meta.Name)
+	lws.SetLabels(meta.Labels)
+	lws.SetAnnotations(meta.Annotations)
+
+	if err := unstructured.SetNestedField(lws.Object, replicas, "spec", "replicas"); err != nil {
+		return nil, err
+	}
+	if err := unstructured.SetNestedField(lws.Object, int64(size), "spec", "leaderWorkerTemplate", "size"); err != nil {
+		return nil, err
+	}
+	if err := unstructured.SetNestedField(lws.Object, restartPolicyRecreateGroupOnPodRestart, "spec", "leaderWorkerTemplate", "restartPolicy"); err != nil {
+		return nil, err
+	}
+	if err := unstructured.SetNestedMap(lws.Object, leaderMap, "spec", "leaderWorkerTemplate", "leaderTemplate"); err != nil {
+		return nil, err
+	}
+	if err := unstructured.SetNestedMap(lws.Object, workerMap, "spec", "leaderWorkerTemplate", "workerTemplate"); err != nil {
+		return nil, err
+	}
+	return lws, nil
+}
+
+// tensorParallelSizeOrOne mirrors GroupSize's own defaulting so size can be
+// derived as GroupSize/TensorParallelSize (i.e. just PipelineParallelSize)
+// without duplicating the nil-check here.
+func tensorParallelSizeOrOne(worker *v1beta1.WorkerSpec) int32 {
+	if worker.TensorParallelSize == nil {
+		return 1
+	}
+	return int32(*worker.TensorParallelSize) // #nosec G115
+}
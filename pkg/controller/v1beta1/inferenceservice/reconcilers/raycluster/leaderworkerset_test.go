@@ -0,0 +1,112 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+)
+
+func TestLeaderWorkerSetCRDAvailable(t *testing.T) {
+	if LeaderWorkerSetCRDAvailable([]string{"apps/v1"}) {
+		t.Fatalf("expected leaderworkerset.x-k8s.io/v1 to be reported unavailable")
+	}
+	if !LeaderWorkerSetCRDAvailable([]string{"apps/v1", "leaderworkerset.x-k8s.io/v1"}) {
+		t.Fatalf("expected leaderworkerset.x-k8s.io/v1 to be reported available")
+	}
+}
+
+func TestBuildLeaderWorkerSetDerivesSizeFromPipelineParallelSize(t *testing.T) {
+	worker := &v1beta1.WorkerSpec{
+		PipelineParallelSize: ptr.To(3),
+		TensorParallelSize:   ptr.To(2),
+		MinReplicas:          ptr.To(2),
+	}
+	meta := metav1.ObjectMeta{Namespace: "default", Name: "isvc"}
+	leaderTemplate := corev1.PodTemplateSpec{}
+	workerTemplate := corev1.PodTemplateSpec{}
+
+	lws, err := BuildLeaderWorkerSet(meta, leaderTemplate, workerTemplate, worker)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	size, found, err := unstructured.NestedInt64(lws.Object, "spec", "leaderWorkerTemplate", "size")
+	if err != nil || !found {
+		t.Fatalf("expected spec.leaderWorkerTemplate.size to be set, err=%v found=%v", err, found)
+	}
+	if size != 3 {
+		t.Fatalf("expected size = GroupSize/TensorParallelSize = 3, got %d", size)
+	}
+
+	replicas, found, err := unstructured.NestedInt64(lws.Object, "spec", "replicas")
+	if err != nil || !found {
+		t.Fatalf("expected spec.replicas to be set, err=%v found=%v", err, found)
+	}
+	if replicas != 2 {
+		t.Fatalf("expected replicas = MinReplicas = 2, got %d", replicas)
+	}
+
+	restartPolicy, found, err := unstructured.NestedString(lws.Object, "spec", "leaderWorkerTemplate", "restartPolicy")
+	if err != nil || !found || restartPolicy != restartPolicyRecreateGroupOnPodRestart {
+		t.Fatalf("expected restartPolicy %q, got %q (found=%v err=%v)", restartPolicyRecreateGroupOnPodRestart, restartPolicy, found, err)
+	}
+}
+
+func TestBuildLeaderWorkerSetDefaultsReplicasToOne(t *testing.T) {
+	worker := &v1beta1.WorkerSpec{}
+	meta := metav1.ObjectMeta{Namespace: "default", Name: "isvc"}
+
+	lws, err := BuildLeaderWorkerSet(meta, corev1.PodTemplateSpec{}, corev1.PodTemplateSpec{}, worker)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	replicas, _, _ := unstructured.NestedInt64(lws.Object, "spec", "replicas")
+	if replicas != 1 {
+		t.Fatalf("expected replicas to default to 1 when MinReplicas is unset, got %d", replicas)
+	}
+
+	size, _, _ := unstructured.NestedInt64(lws.Object, "spec", "leaderWorkerTemplate", "size")
+	if size != 1 {
+		t.Fatalf("expected size to default to 1, got %d", size)
+	}
+}
+
+func TestBuildLeaderWorkerSetSetsMetadata(t *testing.T) {
+	worker := &v1beta1.WorkerSpec{}
+	meta := metav1.ObjectMeta{Namespace: "ns", Name: "name", Labels: map[string]string{"app": "isvc"}}
+
+	lws, err := BuildLeaderWorkerSet(meta, corev1.PodTemplateSpec{}, corev1.PodTemplateSpec{}, worker)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lws.GetNamespace() != "ns" || lws.GetName() != "name" {
+		t.Fatalf("unexpected metadata: namespace=%q name=%q", lws.GetNamespace(), lws.GetName())
+	}
+	if lws.GetAPIVersion() != "leaderworkerset.x-k8s.io/v1" || lws.GetKind() != "LeaderWorkerSet" {
+		t.Fatalf("unexpected apiVersion/kind: %q/%q", lws.GetAPIVersion(), lws.GetKind())
+	}
+	if lws.GetLabels()["app"] != "isvc" {
+		t.Fatalf("expected labels to be propagated, got %+v", lws.GetLabels())
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"context"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Orchestrator is the single entry point for AutoscalerClassRayCluster: on
+// its own, BuildHeadHPA only builds an object and NewRayClusterReconciler
+// only scales the worker Deployment given an already-resolved node count.
+// Orchestrator reconciles the head HPA itself and feeds its resulting
+// replica count into ComputeWorkerReplicas and RayClusterReconciler, so a
+// caller only has to reconcile one object per WorkerSpec-enabled predictor
+// instead of threading the head's node count through by hand.
+type Orchestrator struct {
+	client     client.Client
+	scheme     *runtime.Scheme
+	headMeta   metav1.ObjectMeta
+	workerMeta metav1.ObjectMeta
+	worker     *v1beta1.WorkerSpec
+
+	perNodeGPU resource.Quantity
+	headGPU    resource.Quantity
+	workerGPU  resource.Quantity
+}
+
+// NewOrchestrator builds an Orchestrator for one WorkerSpec-enabled
+// predictor. perNodeGPU, headGPU and workerGPU are the per-node accelerator
+// count and the head/worker container's own GPU request, the same inputs
+// ComputeWorkerReplicas takes. When worker.Autoscaling is unset there is no
+// head HPA to drive node count from, so Reconcile falls back to scaling the
+// worker deployment to exactly one replica group.
+func NewOrchestrator(cl client.Client,
+	scheme *runtime.Scheme,
+	headMeta, workerMeta metav1.ObjectMeta,
+	worker *v1beta1.WorkerSpec,
+	perNodeGPU, headGPU, workerGPU resource.Quantity,
+) *Orchestrator {
+	return &Orchestrator{
+		client:     cl,
+		scheme:     scheme,
+		headMeta:   headMeta,
+		workerMeta: workerMeta,
+		worker:     worker,
+		perNodeGPU: perNodeGPU,
+		headGPU:    headGPU,
+		workerGPU:  workerGPU,
+	}
+}
+
+// Reconcile reconciles the head HPA (when worker.Autoscaling is set) and the
+// worker Deployment, returning the worker Deployment the same way
+// RayClusterReconciler.Reconcile does.
+func (o *Orchestrator) Reconcile() (*appsv1.Deployment, error) {
+	nodes, err := o.reconcileHeadHPA()
+	if err != nil {
+		return nil, err
+	}
+
+	desiredWorkerReplicas := ComputeWorkerReplicas(nodes, o.perNodeGPU, o.headGPU, o.workerGPU)
+	rayClusterReconciler := NewRayClusterReconciler(o.client, o.scheme, o.workerMeta, o.worker, desiredWorkerReplicas)
+	return rayClusterReconciler.Reconcile()
+}
+
+// reconcileHeadHPA creates/updates the head HPA built by BuildHeadHPA and
+// returns its current replica count (the node count ComputeWorkerReplicas
+// scales the worker deployment from). When worker.Autoscaling is unset,
+// AutoscalerClassRayCluster isn't node-autoscaled at all, so no HPA is
+// reconciled and the replica group size is used as the node count directly.
+func (o *Orchestrator) reconcileHeadHPA() (int32, error) {
+	if o.worker.Autoscaling == nil {
+		return GroupSize(o.worker), nil
+	}
+
+	desired := BuildHeadHPA(o.headMeta, o.worker.Autoscaling)
+	existing := &autoscalingv2.HorizontalPodAutoscaler{}
+	err := o.client.Get(context.TODO(), types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, existing)
+	switch {
+	case apierr.IsNotFound(err):
+		if err := o.client.Create(context.TODO(), desired); err != nil {
+			return 0, err
+		}
+		return *desired.Spec.MinReplicas, nil
+	case err != nil:
+		return 0, err
+	case !equality.Semantic.DeepEqual(desired.Spec, existing.Spec):
+		desired.ResourceVersion = existing.ResourceVersion
+		if err := o.client.Update(context.TODO(), desired); err != nil {
+			return 0, err
+		}
+	}
+
+	if existing.Status.CurrentReplicas > 0 {
+		return existing.Status.CurrentReplicas, nil
+	}
+	return *desired.Spec.MinReplicas, nil
+}
+
+// SetControllerReferences sets owner as the controller owner of both the
+// head HPA (when worker.Autoscaling is set) and the worker Deployment.
+func (o *Orchestrator) SetControllerReferences(owner metav1.Object, scheme *runtime.Scheme) error {
+	if o.worker.Autoscaling != nil {
+		headHPA := BuildHeadHPA(o.headMeta, o.worker.Autoscaling)
+		if err := controllerutil.SetControllerReference(owner, headHPA, scheme); err != nil {
+			return err
+		}
+	}
+	rayClusterReconciler := NewRayClusterReconciler(o.client, o.scheme, o.workerMeta, o.worker, GroupSize(o.worker))
+	return rayClusterReconciler.SetControllerReferences(owner, scheme)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewOrchestratorStoresInputs(t *testing.T) {
+	headMeta := metav1.ObjectMeta{Name: "head", Namespace: "ns"}
+	workerMeta := metav1.ObjectMeta{Name: "worker", Namespace: "ns"}
+	worker := &v1beta1.WorkerSpec{}
+	perNodeGPU := resource.MustParse("2")
+
+	o := NewOrchestrator(nil, nil, headMeta, workerMeta, worker, perNodeGPU, resource.MustParse("1"), resource.MustParse("1"))
+
+	if o.headMeta.Name != "head" || o.workerMeta.Name != "worker" {
+		t.Fatalf("expected object metadata to be stored as given, got head=%+v worker=%+v", o.headMeta, o.workerMeta)
+	}
+	if o.worker != worker {
+		t.Fatalf("expected the WorkerSpec pointer to be stored as given")
+	}
+	if o.perNodeGPU.Cmp(perNodeGPU) != 0 {
+		t.Fatalf("expected perNodeGPU to be stored as given, got %s", o.perNodeGPU.String())
+	}
+}
+
+func TestReconcileHeadHPASkipsHPAWhenAutoscalingUnset(t *testing.T) {
+	pipeline := 2
+	tensor := 3
+	worker := &v1beta1.WorkerSpec{PipelineParallelSize: &pipeline, TensorParallelSize: &tensor}
+	o := NewOrchestrator(nil, nil, metav1.ObjectMeta{}, metav1.ObjectMeta{}, worker,
+		resource.MustParse("1"), resource.MustParse("1"), resource.MustParse("1"))
+
+	nodes, err := o.reconcileHeadHPA()
+	if err != nil {
+		t.Fatalf("expected no error when worker.Autoscaling is unset, got %v", err)
+	}
+	if want := GroupSize(worker); nodes != want {
+		t.Fatalf("expected the node count to fall back to the replica group size %d, got %d", want, nodes)
+	}
+}
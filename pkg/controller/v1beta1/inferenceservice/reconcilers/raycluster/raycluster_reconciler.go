@@ -0,0 +1,143 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"context"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	appsv1 "k8s.io/api/apps/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("RayClusterReconciler")
+
+// RayClusterReconciler is the struct of Raw K8S Object. Unlike HPAReconciler
+// it never creates the worker Deployment itself (the raw predictor
+// reconciler owns that); it only ever scales an existing one, always to a
+// multiple of GroupSize, and leaves the single-replica head deployment
+// alone. It does not resolve the external metric itself: the caller resolves
+// it the same way HPA's external metric source does (vLLM queue depth or
+// per-GPU utilization scraped via a ServiceMonitor) and passes the resulting
+// desiredReplicas in.
+type RayClusterReconciler struct {
+	client           client.Client
+	scheme           *runtime.Scheme
+	workerDeployment *appsv1.Deployment
+	minReplicas      int32
+	maxReplicas      int32
+	groupSize        int32
+}
+
+func NewRayClusterReconciler(client client.Client,
+	scheme *runtime.Scheme,
+	workerMeta metav1.ObjectMeta,
+	worker *v1beta1.WorkerSpec,
+	desiredReplicas int32) *RayClusterReconciler {
+	groupSize := GroupSize(worker)
+
+	minReplicas := groupSize
+	if worker.MinReplicas != nil {
+		minReplicas = ConstrainToGroupMultiple(int32(*worker.MinReplicas), groupSize) // #nosec G115
+		if minReplicas < groupSize {
+			minReplicas = groupSize
+		}
+	}
+	maxReplicas := ConstrainToGroupMultiple(int32(worker.MaxReplicas), groupSize) // #nosec G115
+	if maxReplicas < minReplicas {
+		maxReplicas = minReplicas
+	}
+
+	replicas := ConstrainToGroupMultiple(desiredReplicas, groupSize)
+	switch {
+	case replicas < minReplicas:
+		replicas = minReplicas
+	case replicas > maxReplicas:
+		replicas = maxReplicas
+	}
+
+	return &RayClusterReconciler{
+		client: client,
+		scheme: scheme,
+		workerDeployment: &appsv1.Deployment{
+			ObjectMeta: workerMeta,
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		},
+		minReplicas: minReplicas,
+		maxReplicas: maxReplicas,
+		groupSize:   groupSize,
+	}
+}
+
+// checkWorkerDeploymentExist checks whether the worker deployment exists and
+// already runs the desired replica count. It never reports CheckResultCreate:
+// if the deployment doesn't exist yet, scaling has nothing to do until the
+// raw predictor reconciler creates it.
+func (r *RayClusterReconciler) checkWorkerDeploymentExist(cl client.Client) (constants.CheckResultType, *appsv1.Deployment, error) {
+	existing := &appsv1.Deployment{}
+	err := cl.Get(context.TODO(), types.NamespacedName{
+		Namespace: r.workerDeployment.Namespace,
+		Name:      r.workerDeployment.Name,
+	}, existing)
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			return constants.CheckResultSkipped, nil, nil
+		}
+		return constants.CheckResultUnknown, nil, err
+	}
+	if existing.Spec.Replicas != nil && *existing.Spec.Replicas == *r.workerDeployment.Spec.Replicas {
+		return constants.CheckResultExisted, existing, nil
+	}
+	return constants.CheckResultUpdate, existing, nil
+}
+
+// Reconcile scales the worker deployment to the replica count resolved by
+// NewRayClusterReconciler. Scale-ins rely on each worker pod's preStop hook
+// to issue the Ray graceful-shutdown drain before the pod terminates; this
+// reconciler only ever changes the replica count once that hook is in place,
+// it doesn't drive the drain itself.
+func (r *RayClusterReconciler) Reconcile() (*appsv1.Deployment, error) {
+	checkResult, existing, err := r.checkWorkerDeploymentExist(r.client)
+	log.Info("worker deployment scale reconcile", "checkResult", checkResult, "err", err)
+	if err != nil {
+		return nil, err
+	}
+	if checkResult != constants.CheckResultUpdate {
+		return existing, nil
+	}
+
+	desired := *r.workerDeployment.Spec.Replicas
+	if existing.Spec.Replicas != nil && desired < *existing.Spec.Replicas {
+		log.Info("scaling in Ray worker replica group(s)", "from", *existing.Spec.Replicas, "to", desired)
+	}
+	existing.Spec.Replicas = &desired
+	if err := r.client.Update(context.TODO(), existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (r *RayClusterReconciler) SetControllerReferences(owner metav1.Object, scheme *runtime.Scheme) error {
+	return controllerutil.SetControllerReference(owner, r.workerDeployment, scheme)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestNewRayClusterReconcilerConstrainsReplicasToGroupMultiple(t *testing.T) {
+	worker := &v1beta1.WorkerSpec{
+		MinReplicas:          ptr.To(1),
+		MaxReplicas:          20,
+		PipelineParallelSize: ptr.To(1),
+		TensorParallelSize:   ptr.To(4),
+	}
+	meta := metav1.ObjectMeta{Namespace: "default", Name: "worker"}
+
+	r := NewRayClusterReconciler(nil, nil, meta, worker, 10)
+
+	if r.groupSize != 4 {
+		t.Fatalf("expected groupSize 4, got %d", r.groupSize)
+	}
+	if got := *r.workerDeployment.Spec.Replicas; got != 8 {
+		t.Fatalf("expected 10 desired replicas constrained down to 8 (nearest multiple of 4), got %d", got)
+	}
+}
+
+func TestNewRayClusterReconcilerFloorsAtMinReplicas(t *testing.T) {
+	worker := &v1beta1.WorkerSpec{
+		MinReplicas:          ptr.To(8),
+		MaxReplicas:          20,
+		PipelineParallelSize: ptr.To(1),
+		TensorParallelSize:   ptr.To(4),
+	}
+	meta := metav1.ObjectMeta{Namespace: "default", Name: "worker"}
+
+	r := NewRayClusterReconciler(nil, nil, meta, worker, 2)
+
+	if got := *r.workerDeployment.Spec.Replicas; got != 8 {
+		t.Fatalf("expected replicas to floor at minReplicas 8, got %d", got)
+	}
+}
+
+func TestNewRayClusterReconcilerCapsAtMaxReplicas(t *testing.T) {
+	worker := &v1beta1.WorkerSpec{
+		MinReplicas:          ptr.To(1),
+		MaxReplicas:          10,
+		PipelineParallelSize: ptr.To(1),
+		TensorParallelSize:   ptr.To(4),
+	}
+	meta := metav1.ObjectMeta{Namespace: "default", Name: "worker"}
+
+	r := NewRayClusterReconciler(nil, nil, meta, worker, 100)
+
+	if got := *r.workerDeployment.Spec.Replicas; got != 8 {
+		t.Fatalf("expected replicas to cap at maxReplicas constrained to groupSize (8), got %d", got)
+	}
+}
+
+func TestNewRayClusterReconcilerDefaultsMinReplicasToGroupSize(t *testing.T) {
+	worker := &v1beta1.WorkerSpec{
+		MaxReplicas:          20,
+		PipelineParallelSize: ptr.To(1),
+		TensorParallelSize:   ptr.To(4),
+	}
+	meta := metav1.ObjectMeta{Namespace: "default", Name: "worker"}
+
+	r := NewRayClusterReconciler(nil, nil, meta, worker, 1)
+
+	if r.minReplicas != 4 {
+		t.Fatalf("expected minReplicas to default to groupSize 4, got %d", r.minReplicas)
+	}
+}
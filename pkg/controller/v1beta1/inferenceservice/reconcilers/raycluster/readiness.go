@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// HeadHealthPath is the head pod's readiness endpoint: the Ray cluster is
+// considered joined, not just the head container started, once this returns
+// 200.
+const HeadHealthPath = "/v1/health/ready"
+
+// RayAliveNodeCountAnnotationKey is the head pod annotation a sidecar
+// publishes the Ray cluster's current alive-node count to, read in place of
+// execing into the pod on every reconcile.
+const RayAliveNodeCountAnnotationKey = "serving.kserve.io/ray-alive-node-count"
+
+// ReadinessChecker mirrors Helm 3's kube.ReadyChecker: one IsReady check per
+// concern, so PredictorReady gating for a multinode predictor can compose
+// several independent signals instead of one monolithic check. A false,nil
+// return means "not ready yet, keep polling"; a non-nil error means the
+// check itself couldn't be performed.
+type ReadinessChecker interface {
+	IsReady(ctx context.Context) (bool, error)
+}
+
+// workerReplicasReadyChecker passes once every desired worker replica
+// reports Ready, the same bar appsv1.DeploymentCondition.Available uses for
+// a plain (non-multinode) predictor.
+type workerReplicasReadyChecker struct {
+	client    client.Client
+	workerKey types.NamespacedName
+}
+
+func (c workerReplicasReadyChecker) IsReady(ctx context.Context) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	if err := c.client.Get(ctx, c.workerKey, deployment); err != nil {
+		return false, err
+	}
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	return deployment.Status.ReadyReplicas >= desired, nil
+}
+
+// headHealthChecker calls the head pod's HeadHealthPath endpoint directly,
+// since the head container reporting Running doesn't mean the Ray process
+// inside it has finished joining the cluster.
+type headHealthChecker struct {
+	httpGet func(url string) (*http.Response, error)
+	headURL string
+}
+
+func (c headHealthChecker) IsReady(_ context.Context) (bool, error) {
+	resp, err := c.httpGet(c.headURL)
+	if err != nil {
+		// Not yet reachable (pod still starting, DNS not yet resolvable)
+		// isn't a hard error, just "not ready yet".
+		return false, nil
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode == http.StatusOK, nil
+}
+
+// rayAliveNodeChecker compares the alive-node count a sidecar publishes onto
+// the head pod's RayAliveNodeCountAnnotationKey annotation against the
+// cluster's expected node count (one head plus however many workers one
+// replica group needs), so a head that's up but still waiting for workers to
+// join doesn't get reported ready.
+type rayAliveNodeChecker struct {
+	client            client.Client
+	headPodKey        types.NamespacedName
+	expectedNodeCount int32
+}
+
+func (c rayAliveNodeChecker) IsReady(ctx context.Context) (bool, error) {
+	pod := &corev1.Pod{}
+	if err := c.client.Get(ctx, c.headPodKey, pod); err != nil {
+		return false, err
+	}
+	raw, ok := pod.Annotations[RayAliveNodeCountAnnotationKey]
+	if !ok {
+		return false, nil
+	}
+	aliveNodeCount, err := strconv.Atoi(raw)
+	if err != nil {
+		return false, nil
+	}
+	return int32(aliveNodeCount) >= c.expectedNodeCount, nil
+}
+
+// CompositeReadinessChecker requires every one of Checkers to report ready.
+// The first one to report not-ready (or error) short-circuits the rest.
+type CompositeReadinessChecker struct {
+	Checkers []ReadinessChecker
+}
+
+func (c CompositeReadinessChecker) IsReady(ctx context.Context) (bool, error) {
+	for _, checker := range c.Checkers {
+		ready, err := checker.IsReady(ctx)
+		if err != nil || !ready {
+			return ready, err
+		}
+	}
+	return true, nil
+}
+
+// NewMultiNodeReadinessChecker builds the standard composite for a
+// WorkerSpec-enabled predictor: all worker replicas Ready, the head's
+// HeadHealthPath returning 200, and the Ray cluster reporting at least
+// expectedNodeCount alive nodes. PredictorReady should only flip True once
+// this reports ready.
+func NewMultiNodeReadinessChecker(cl client.Client, workerKey, headPodKey types.NamespacedName, headURL string, expectedNodeCount int32) ReadinessChecker {
+	return CompositeReadinessChecker{Checkers: []ReadinessChecker{
+		workerReplicasReadyChecker{client: cl, workerKey: workerKey},
+		headHealthChecker{httpGet: http.Get, headURL: headURL + HeadHealthPath},
+		rayAliveNodeChecker{client: cl, headPodKey: headPodKey, expectedNodeCount: expectedNodeCount},
+	}}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HeadStatusPath is the head pod's node-list status endpoint, distinct from
+// HeadHealthPath: health reports whether the head process itself is up,
+// status additionally reports which Ray nodes it currently sees as joined.
+const HeadStatusPath = "/api/ray/status"
+
+// ReasonRayClusterNotFormed is the PredictorReady condition reason set when
+// PollRayClusterFormed times out before the head-reported node count reaches
+// the expected RayNodeCountEnvName value.
+const ReasonRayClusterNotFormed = "RayClusterNotFormed"
+
+// rayStatusResponse is the shape HeadStatusPath is expected to return: the
+// addresses of every Ray node the head currently considers alive.
+type rayStatusResponse struct {
+	Nodes []string `json:"nodes"`
+}
+
+// rayClusterStatusChecker polls HeadStatusPath and reports ready once the
+// returned node count reaches expectedNodeCount, recording the last observed
+// node list so a timeout can be reported with a useful message.
+type rayClusterStatusChecker struct {
+	httpGet           func(url string) (*http.Response, error)
+	statusURL         string
+	expectedNodeCount int32
+	lastObservedNodes []string
+}
+
+func (c *rayClusterStatusChecker) IsReady(_ context.Context) (bool, error) {
+	resp, err := c.httpGet(c.statusURL)
+	if err != nil {
+		return false, nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, nil
+	}
+	var status rayStatusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return false, nil
+	}
+	c.lastObservedNodes = status.Nodes
+	return int32(len(status.Nodes)) >= c.expectedNodeCount, nil
+}
+
+// PollRayClusterFormed polls headURL+HeadStatusPath every interval until
+// either the reported node count reaches expectedNodeCount (ready=true) or
+// timeout elapses, in which case it returns the ReasonRayClusterNotFormed
+// reason and a message listing whatever nodes were last observed, for the
+// caller to set on the PredictorReady condition.
+func PollRayClusterFormed(ctx context.Context, headURL string, expectedNodeCount int32, timeout, interval time.Duration) (ready bool, reason string, message string) {
+	checker := &rayClusterStatusChecker{
+		httpGet:           http.Get,
+		statusURL:         headURL + HeadStatusPath,
+		expectedNodeCount: expectedNodeCount,
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		ok, _ := checker.IsReady(ctx)
+		if ok {
+			return true, "", ""
+		}
+		if time.Now().After(deadline) {
+			return false, ReasonRayClusterNotFormed, notFormedMessage(checker.lastObservedNodes, expectedNodeCount)
+		}
+		select {
+		case <-ctx.Done():
+			return false, ReasonRayClusterNotFormed, notFormedMessage(checker.lastObservedNodes, expectedNodeCount)
+		case <-time.After(interval):
+		}
+	}
+}
+
+func notFormedMessage(observedNodes []string, expectedNodeCount int32) string {
+	if len(observedNodes) == 0 {
+		return fmt.Sprintf("Ray cluster did not report any alive nodes (expected %d)", expectedNodeCount)
+	}
+	return fmt.Sprintf("Ray cluster reported %d of %d expected alive nodes: %s",
+		len(observedNodes), expectedNodeCount, strings.Join(observedNodes, ", "))
+}
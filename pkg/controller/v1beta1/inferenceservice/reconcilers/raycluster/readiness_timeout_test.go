@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+func TestRayClusterStatusCheckerReadyOnceNodeCountReached(t *testing.T) {
+	checker := &rayClusterStatusChecker{
+		httpGet: func(string) (*http.Response, error) {
+			return jsonResponse(http.StatusOK, `{"nodes":["10.0.0.1","10.0.0.2"]}`), nil
+		},
+		expectedNodeCount: 2,
+	}
+	ready, err := checker.IsReady(context.Background())
+	if err != nil || !ready {
+		t.Fatalf("expected ready=true, err=nil, got ready=%v err=%v", ready, err)
+	}
+}
+
+func TestRayClusterStatusCheckerNotReadyWithFewerNodes(t *testing.T) {
+	checker := &rayClusterStatusChecker{
+		httpGet: func(string) (*http.Response, error) {
+			return jsonResponse(http.StatusOK, `{"nodes":["10.0.0.1"]}`), nil
+		},
+		expectedNodeCount: 2,
+	}
+	ready, err := checker.IsReady(context.Background())
+	if err != nil || ready {
+		t.Fatalf("expected ready=false, err=nil, got ready=%v err=%v", ready, err)
+	}
+	if len(checker.lastObservedNodes) != 1 {
+		t.Fatalf("expected the observed node list to be recorded, got %+v", checker.lastObservedNodes)
+	}
+}
+
+func TestRayClusterStatusCheckerNotReadyOnRequestError(t *testing.T) {
+	checker := &rayClusterStatusChecker{
+		httpGet: func(string) (*http.Response, error) {
+			return nil, context.DeadlineExceeded
+		},
+		expectedNodeCount: 1,
+	}
+	ready, err := checker.IsReady(context.Background())
+	if err != nil || ready {
+		t.Fatalf("expected a request error to report not-ready without surfacing an error, got ready=%v err=%v", ready, err)
+	}
+}
+
+func TestRayClusterStatusCheckerNotReadyOnNonOKStatus(t *testing.T) {
+	checker := &rayClusterStatusChecker{
+		httpGet: func(string) (*http.Response, error) {
+			return jsonResponse(http.StatusServiceUnavailable, ""), nil
+		},
+		expectedNodeCount: 1,
+	}
+	ready, err := checker.IsReady(context.Background())
+	if err != nil || ready {
+		t.Fatalf("expected a non-200 response to report not-ready, got ready=%v err=%v", ready, err)
+	}
+}
+
+func TestPollRayClusterFormedTimesOutWithMessage(t *testing.T) {
+	ready, reason, message := PollRayClusterFormed(context.Background(), "http://127.0.0.1:1", 2, 30*time.Millisecond, 5*time.Millisecond)
+	if ready {
+		t.Fatalf("expected ready=false against an unreachable head")
+	}
+	if reason != ReasonRayClusterNotFormed {
+		t.Fatalf("expected reason %q, got %q", ReasonRayClusterNotFormed, reason)
+	}
+	if message == "" {
+		t.Fatalf("expected a non-empty timeout message")
+	}
+}
+
+func TestPollRayClusterFormedHonorsContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ready, reason, _ := PollRayClusterFormed(ctx, "http://127.0.0.1:1", 1, time.Second, 5*time.Millisecond)
+	if ready {
+		t.Fatalf("expected ready=false when the context is already canceled")
+	}
+	if reason != ReasonRayClusterNotFormed {
+		t.Fatalf("expected reason %q, got %q", ReasonRayClusterNotFormed, reason)
+	}
+}
+
+func TestNotFormedMessageNoNodesObserved(t *testing.T) {
+	got := notFormedMessage(nil, 3)
+	if got == "" {
+		t.Fatalf("expected a non-empty message")
+	}
+}
+
+func TestNotFormedMessageListsObservedNodes(t *testing.T) {
+	got := notFormedMessage([]string{"10.0.0.1"}, 3)
+	if got == "" {
+		t.Fatalf("expected a non-empty message")
+	}
+}
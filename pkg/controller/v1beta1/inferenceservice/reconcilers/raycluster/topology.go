@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// hostnameTopologyKey mirrors the placement package's own constant; kept
+// local rather than exported from there since the two packages' topology
+// concerns (zone/host spreading vs. GPU interconnect domains) are otherwise
+// unrelated.
+const hostnameTopologyKey = "kubernetes.io/hostname"
+
+// NeedsTopologyAwarePlacement reports whether worker's TensorParallelSize
+// requires the head's GPUs to be co-located, the only case
+// WorkerTopologySpec's affinity rules apply to.
+func NeedsTopologyAwarePlacement(worker *v1beta1.WorkerSpec) bool {
+	return worker.TensorParallelSize != nil && *worker.TensorParallelSize > 1 && worker.Topology != nil && worker.Topology.InterconnectDomainLabel != ""
+}
+
+// BuildHeadAffinity returns the head Deployment's node affinity towards a
+// node advertising topology.InterconnectDomainLabel: required when
+// RequireSameDomain is set, otherwise a soft preference. Returns nil when
+// topology-aware placement isn't requested.
+func BuildHeadAffinity(worker *v1beta1.WorkerSpec) *corev1.Affinity {
+	if !NeedsTopologyAwarePlacement(worker) {
+		return nil
+	}
+	term := corev1.NodeSelectorTerm{
+		MatchExpressions: []corev1.NodeSelectorRequirement{
+			{Key: worker.Topology.InterconnectDomainLabel, Operator: corev1.NodeSelectorOpExists},
+		},
+	}
+	if worker.Topology.RequireSameDomain {
+		return &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{term}},
+		}}
+	}
+	return &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{{Weight: 100, Preference: term}},
+	}}
+}
+
+// BuildHeadTopologySpreadConstraints returns a spread constraint keyed on
+// InterconnectDomainLabel, so a head that's ever scaled beyond one replica
+// doesn't concentrate every replica's GPUs onto a single interconnect
+// domain. A no-op for today's single-replica head, kept for when the head
+// itself becomes scalable.
+func BuildHeadTopologySpreadConstraints(worker *v1beta1.WorkerSpec, selectorLabels map[string]string) []corev1.TopologySpreadConstraint {
+	if !NeedsTopologyAwarePlacement(worker) {
+		return nil
+	}
+	return []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       worker.Topology.InterconnectDomainLabel,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: selectorLabels},
+		},
+	}
+}
+
+// BuildWorkerAffinity returns the worker Deployment's affinity: a soft
+// pod anti-affinity so worker replicas spread across hosts, plus a
+// node-affinity preference (or, once CrossDomainTolerance workers are
+// already placed, a hard requirement) for headDomainValue — the
+// InterconnectDomainLabel value observed on the already-scheduled head pod.
+// headDomainValue is empty until the head has a Node assigned, in which case
+// only the anti-affinity is returned.
+func BuildWorkerAffinity(worker *v1beta1.WorkerSpec, headDomainValue string, workerSelectorLabels map[string]string, scheduledOutsideDomain int32) *corev1.Affinity {
+	affinity := &corev1.Affinity{}
+	if len(workerSelectorLabels) > 0 {
+		affinity.PodAntiAffinity = &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						TopologyKey:   hostnameTopologyKey,
+						LabelSelector: &metav1.LabelSelector{MatchLabels: workerSelectorLabels},
+					},
+				},
+			},
+		}
+	}
+
+	if !NeedsTopologyAwarePlacement(worker) || headDomainValue == "" {
+		return affinity
+	}
+	term := corev1.NodeSelectorTerm{
+		MatchExpressions: []corev1.NodeSelectorRequirement{
+			{Key: worker.Topology.InterconnectDomainLabel, Operator: corev1.NodeSelectorOpIn, Values: []string{headDomainValue}},
+		},
+	}
+	tolerance := int32(0)
+	if worker.Topology.CrossDomainTolerance != nil {
+		tolerance = *worker.Topology.CrossDomainTolerance
+	}
+	if worker.Topology.RequireSameDomain && scheduledOutsideDomain >= tolerance {
+		affinity.NodeAffinity = &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{term}},
+		}
+		return affinity
+	}
+	affinity.NodeAffinity = &corev1.NodeAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{{Weight: 100, Preference: term}},
+	}
+	return affinity
+}
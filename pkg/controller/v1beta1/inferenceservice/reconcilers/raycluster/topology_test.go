@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"k8s.io/utils/ptr"
+)
+
+func topologyAwareWorker(requireSameDomain bool) *v1beta1.WorkerSpec {
+	return &v1beta1.WorkerSpec{
+		TensorParallelSize: ptr.To(2),
+		Topology: &v1beta1.WorkerTopologySpec{
+			InterconnectDomainLabel: "kserve.io/gpu-interconnect-domain",
+			RequireSameDomain:       requireSameDomain,
+		},
+	}
+}
+
+func TestNeedsTopologyAwarePlacementRequiresTensorParallelAndTopology(t *testing.T) {
+	if NeedsTopologyAwarePlacement(&v1beta1.WorkerSpec{}) {
+		t.Fatalf("expected no topology to mean not topology-aware")
+	}
+	if NeedsTopologyAwarePlacement(&v1beta1.WorkerSpec{TensorParallelSize: ptr.To(1), Topology: &v1beta1.WorkerTopologySpec{InterconnectDomainLabel: "x"}}) {
+		t.Fatalf("expected TensorParallelSize=1 to not require topology-aware placement")
+	}
+	if !NeedsTopologyAwarePlacement(topologyAwareWorker(false)) {
+		t.Fatalf("expected TensorParallelSize>1 plus a topology label to require topology-aware placement")
+	}
+}
+
+func TestBuildHeadAffinityNilWhenNotNeeded(t *testing.T) {
+	if got := BuildHeadAffinity(&v1beta1.WorkerSpec{}); got != nil {
+		t.Fatalf("expected nil affinity, got %+v", got)
+	}
+}
+
+func TestBuildHeadAffinitySoftPreferenceByDefault(t *testing.T) {
+	got := BuildHeadAffinity(topologyAwareWorker(false))
+	if got == nil || got.NodeAffinity == nil || len(got.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution) != 1 {
+		t.Fatalf("expected a soft node-affinity preference, got %+v", got)
+	}
+	if got.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		t.Fatalf("expected no hard requirement by default, got %+v", got.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+	}
+}
+
+func TestBuildHeadAffinityHardRequirementWhenRequireSameDomain(t *testing.T) {
+	got := BuildHeadAffinity(topologyAwareWorker(true))
+	if got == nil || got.NodeAffinity == nil || got.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		t.Fatalf("expected a hard node-affinity requirement, got %+v", got)
+	}
+}
+
+func TestBuildHeadTopologySpreadConstraintsNilWhenNotNeeded(t *testing.T) {
+	if got := BuildHeadTopologySpreadConstraints(&v1beta1.WorkerSpec{}, nil); got != nil {
+		t.Fatalf("expected nil constraints, got %+v", got)
+	}
+}
+
+func TestBuildHeadTopologySpreadConstraintsUsesInterconnectDomainLabel(t *testing.T) {
+	got := BuildHeadTopologySpreadConstraints(topologyAwareWorker(false), map[string]string{"app": "head"})
+	if len(got) != 1 || got[0].TopologyKey != "kserve.io/gpu-interconnect-domain" {
+		t.Fatalf("unexpected constraints: %+v", got)
+	}
+}
+
+func TestBuildWorkerAffinityAntiAffinityOnlyWithoutHeadDomain(t *testing.T) {
+	worker := topologyAwareWorker(false)
+	got := BuildWorkerAffinity(worker, "", map[string]string{"app": "worker"}, 0)
+	if got.PodAntiAffinity == nil {
+		t.Fatalf("expected pod anti-affinity to always be set when selector labels are given")
+	}
+	if got.NodeAffinity != nil {
+		t.Fatalf("expected no node affinity until the head has a domain value, got %+v", got.NodeAffinity)
+	}
+}
+
+func TestBuildWorkerAffinityNoAntiAffinityWithoutSelectorLabels(t *testing.T) {
+	got := BuildWorkerAffinity(&v1beta1.WorkerSpec{}, "", nil, 0)
+	if got.PodAntiAffinity != nil {
+		t.Fatalf("expected no anti-affinity without selector labels, got %+v", got.PodAntiAffinity)
+	}
+}
+
+func TestBuildWorkerAffinitySoftPreferenceForHeadDomain(t *testing.T) {
+	worker := topologyAwareWorker(false)
+	got := BuildWorkerAffinity(worker, "domain-a", map[string]string{"app": "worker"}, 0)
+	if got.NodeAffinity == nil || len(got.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution) != 1 {
+		t.Fatalf("expected a soft preference for the head's domain, got %+v", got.NodeAffinity)
+	}
+}
+
+func TestBuildWorkerAffinityHardRequirementOnceToleranceExceeded(t *testing.T) {
+	worker := topologyAwareWorker(true)
+	worker.Topology.CrossDomainTolerance = ptr.To(int32(2))
+
+	// Under tolerance: still a soft preference.
+	got := BuildWorkerAffinity(worker, "domain-a", map[string]string{"app": "worker"}, 1)
+	if got.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		t.Fatalf("expected a soft preference while under tolerance, got %+v", got.NodeAffinity)
+	}
+
+	// At/over tolerance: hard requirement kicks in.
+	got = BuildWorkerAffinity(worker, "domain-a", map[string]string{"app": "worker"}, 2)
+	if got.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		t.Fatalf("expected a hard requirement once tolerance is exceeded, got %+v", got.NodeAffinity)
+	}
+	if got.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Values[0] != "domain-a" {
+		t.Fatalf("expected the node selector to target the head's observed domain value")
+	}
+}
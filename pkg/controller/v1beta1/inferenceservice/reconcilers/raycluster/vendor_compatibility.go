@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import (
+	"fmt"
+)
+
+// ReasonIncompatibleGPUVendors is the validation failure reason set alongside
+// InvalidGPUAllocation when the head and worker accelerator vendors aren't
+// permitted to share a Ray cluster for the requested parallelism dimension.
+const ReasonIncompatibleGPUVendors = "IncompatibleGPUVendors"
+
+// GPUVendorCompatibilityOverrideAnnotationKey lets an admin bypass
+// ValidateVendorCompatibility for a specific InferenceService, e.g. while
+// validating a new vendor pairing before adding it to the cluster-wide
+// matrix.
+const GPUVendorCompatibilityOverrideAnnotationKey = "serving.kserve.io/gpu-vendor-compatibility-override"
+
+// VendorCompatibility records whether a pair of accelerator vendors may
+// participate in the same Ray cluster for each parallelism dimension.
+type VendorCompatibility struct {
+	AllowTensorParallel   bool
+	AllowPipelineParallel bool
+}
+
+// VendorCompatibilityMatrix maps a vendor pair (order-independent) to its
+// VendorCompatibility, configurable via the inferenceservice-config
+// ConfigMap so admins can permit pairings beyond the conservative default.
+type VendorCompatibilityMatrix map[[2]string]VendorCompatibility
+
+// vendorPairKey builds an order-independent key so Set/lookup don't care
+// which of head/worker is passed first.
+func vendorPairKey(a, b string) [2]string {
+	if a <= b {
+		return [2]string{a, b}
+	}
+	return [2]string{b, a}
+}
+
+// Set records compatibility for a vendor pair.
+func (m VendorCompatibilityMatrix) Set(vendorA, vendorB string, compat VendorCompatibility) {
+	m[vendorPairKey(vendorA, vendorB)] = compat
+}
+
+// Lookup returns the recorded compatibility for a vendor pair, defaulting to
+// "same vendor always compatible, cross-vendor allows pipeline parallel but
+// not tensor parallel" when the pair isn't explicitly configured — tensor
+// parallelism shards a layer's math across GPUs in lockstep, which assumes
+// interoperable kernels, while pipeline parallelism only ships tensors over
+// the network between stages.
+func (m VendorCompatibilityMatrix) Lookup(vendorA, vendorB string) VendorCompatibility {
+	if vendorA == vendorB {
+		return VendorCompatibility{AllowTensorParallel: true, AllowPipelineParallel: true}
+	}
+	if compat, ok := m[vendorPairKey(vendorA, vendorB)]; ok {
+		return compat
+	}
+	return VendorCompatibility{AllowTensorParallel: false, AllowPipelineParallel: true}
+}
+
+// DefaultVendorCompatibilityMatrix returns an empty matrix, i.e. every
+// vendor pair falls back to VendorCompatibilityMatrix.Lookup's own default.
+// Admins add entries via the inferenceservice-config ConfigMap to permit
+// (or forbid) specific pairs beyond that default.
+func DefaultVendorCompatibilityMatrix() VendorCompatibilityMatrix {
+	return VendorCompatibilityMatrix{}
+}
+
+// ValidateVendorCompatibility checks headVendor/workerVendor against matrix
+// for the requested tensorParallelSize/pipelineParallelSize, returning nil
+// when compatible (or when annotations carries
+// GPUVendorCompatibilityOverrideAnnotationKey=true) and an error naming both
+// vendors and the forbidden dimension otherwise.
+func ValidateVendorCompatibility(matrix VendorCompatibilityMatrix, headVendor, workerVendor string, tensorParallelSize, pipelineParallelSize int, annotations map[string]string) error {
+	if annotations[GPUVendorCompatibilityOverrideAnnotationKey] == "true" {
+		return nil
+	}
+	if headVendor == "" || workerVendor == "" || headVendor == workerVendor {
+		return nil
+	}
+	compat := matrix.Lookup(headVendor, workerVendor)
+	if tensorParallelSize > 1 && !compat.AllowTensorParallel {
+		return fmt.Errorf("%s: head vendor %q and worker vendor %q may not share tensor-parallel GPUs (set %s=true to override)",
+			ReasonIncompatibleGPUVendors, headVendor, workerVendor, GPUVendorCompatibilityOverrideAnnotationKey)
+	}
+	if pipelineParallelSize > 1 && !compat.AllowPipelineParallel {
+		return fmt.Errorf("%s: head vendor %q and worker vendor %q may not share pipeline-parallel stages (set %s=true to override)",
+			ReasonIncompatibleGPUVendors, headVendor, workerVendor, GPUVendorCompatibilityOverrideAnnotationKey)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raycluster
+
+import "testing"
+
+func TestVendorCompatibilityMatrixSetAndLookupOrderIndependent(t *testing.T) {
+	matrix := DefaultVendorCompatibilityMatrix()
+	matrix.Set("nvidia.com/gpu", "amd.com/gpu", VendorCompatibility{AllowTensorParallel: true, AllowPipelineParallel: true})
+
+	got := matrix.Lookup("amd.com/gpu", "nvidia.com/gpu")
+	if !got.AllowTensorParallel || !got.AllowPipelineParallel {
+		t.Fatalf("expected the configured pair to be found regardless of argument order, got %+v", got)
+	}
+}
+
+func TestVendorCompatibilityMatrixLookupSameVendorAlwaysCompatible(t *testing.T) {
+	matrix := DefaultVendorCompatibilityMatrix()
+	got := matrix.Lookup("nvidia.com/gpu", "nvidia.com/gpu")
+	if !got.AllowTensorParallel || !got.AllowPipelineParallel {
+		t.Fatalf("expected same-vendor pairs to always be compatible, got %+v", got)
+	}
+}
+
+func TestVendorCompatibilityMatrixLookupDefaultsToNoTensorParallel(t *testing.T) {
+	matrix := DefaultVendorCompatibilityMatrix()
+	got := matrix.Lookup("nvidia.com/gpu", "amd.com/gpu")
+	if got.AllowTensorParallel {
+		t.Fatalf("expected unconfigured cross-vendor pairs to disallow tensor parallelism by default")
+	}
+	if !got.AllowPipelineParallel {
+		t.Fatalf("expected unconfigured cross-vendor pairs to allow pipeline parallelism by default")
+	}
+}
+
+func TestValidateVendorCompatibilitySameVendorAlwaysOK(t *testing.T) {
+	matrix := DefaultVendorCompatibilityMatrix()
+	if err := ValidateVendorCompatibility(matrix, "nvidia.com/gpu", "nvidia.com/gpu", 4, 1, nil); err != nil {
+		t.Fatalf("expected no error for same-vendor pairs, got %v", err)
+	}
+}
+
+func TestValidateVendorCompatibilityRejectsTensorParallelCrossVendorByDefault(t *testing.T) {
+	matrix := DefaultVendorCompatibilityMatrix()
+	err := ValidateVendorCompatibility(matrix, "nvidia.com/gpu", "amd.com/gpu", 4, 1, nil)
+	if err == nil {
+		t.Fatalf("expected an error for a cross-vendor tensor-parallel pair")
+	}
+}
+
+func TestValidateVendorCompatibilityAllowsPipelineParallelCrossVendorByDefault(t *testing.T) {
+	matrix := DefaultVendorCompatibilityMatrix()
+	err := ValidateVendorCompatibility(matrix, "nvidia.com/gpu", "amd.com/gpu", 1, 4, nil)
+	if err != nil {
+		t.Fatalf("expected pipeline-parallel cross-vendor pairs to be allowed by default, got %v", err)
+	}
+}
+
+func TestValidateVendorCompatibilityOverrideAnnotationBypasses(t *testing.T) {
+	matrix := DefaultVendorCompatibilityMatrix()
+	annotations := map[string]string{GPUVendorCompatibilityOverrideAnnotationKey: "true"}
+	err := ValidateVendorCompatibility(matrix, "nvidia.com/gpu", "amd.com/gpu", 4, 1, annotations)
+	if err != nil {
+		t.Fatalf("expected the override annotation to bypass validation, got %v", err)
+	}
+}
+
+func TestValidateVendorCompatibilityConfiguredPairAllowed(t *testing.T) {
+	matrix := DefaultVendorCompatibilityMatrix()
+	matrix.Set("nvidia.com/gpu", "amd.com/gpu", VendorCompatibility{AllowTensorParallel: true, AllowPipelineParallel: true})
+	err := ValidateVendorCompatibility(matrix, "nvidia.com/gpu", "amd.com/gpu", 4, 4, nil)
+	if err != nil {
+		t.Fatalf("expected the configured pair to be allowed, got %v", err)
+	}
+}
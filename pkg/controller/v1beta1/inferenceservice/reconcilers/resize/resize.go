@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resize supports Kubernetes in-place pod vertical scaling
+// (Container.ResizePolicy) for predictor/transformer/explainer containers:
+// when a container's ResizePolicy marks CPU/memory as NotRequired, a
+// Resources-only change can be applied by patching the running Pod's resize
+// subresource instead of rolling the Deployment.
+package resize
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ApplyResizePolicy sets container.ResizePolicy to policy, so the resize
+// behavior threads through to every generated container spec (head, worker,
+// transformer, explainer) from one place instead of each caller setting it
+// individually.
+func ApplyResizePolicy(container *corev1.Container, policy []corev1.ContainerResizePolicy) {
+	container.ResizePolicy = policy
+}
+
+// resourceRestartRequired reports whether resizing resourceName requires a
+// restart under policy. Absent an explicit entry for resourceName, a resize
+// requires a restart (the conservative default Kubernetes falls back to),
+// so an empty/nil policy never qualifies for in-place resize.
+func resourceRestartRequired(policy []corev1.ContainerResizePolicy, resourceName corev1.ResourceName) bool {
+	for _, p := range policy {
+		if p.ResourceName == resourceName {
+			return p.RestartPolicy == corev1.RestartContainer
+		}
+	}
+	return true
+}
+
+// CanResizeInPlace reports whether desired can be applied to existing by
+// patching the running Pod's resources directly: the two containers must be
+// identical except for Resources, and every resource whose
+// requests/limits actually changed must be marked NotRequired in policy.
+func CanResizeInPlace(desired, existing *corev1.Container, policy []corev1.ContainerResizePolicy) bool {
+	desiredWithoutResources := desired.DeepCopy()
+	existingWithoutResources := existing.DeepCopy()
+	desiredWithoutResources.Resources = corev1.ResourceRequirements{}
+	existingWithoutResources.Resources = corev1.ResourceRequirements{}
+	if !equality.Semantic.DeepEqual(desiredWithoutResources, existingWithoutResources) {
+		return false
+	}
+
+	for _, resourceName := range changedResourceNames(desired.Resources, existing.Resources) {
+		if resourceRestartRequired(policy, resourceName) {
+			return false
+		}
+	}
+	return true
+}
+
+// changedResourceNames returns the resource names whose Requests or Limits
+// differ between desired and existing.
+func changedResourceNames(desired, existing corev1.ResourceRequirements) []corev1.ResourceName {
+	seen := map[corev1.ResourceName]struct{}{}
+	for name := range desired.Requests {
+		seen[name] = struct{}{}
+	}
+	for name := range desired.Limits {
+		seen[name] = struct{}{}
+	}
+	for name := range existing.Requests {
+		seen[name] = struct{}{}
+	}
+	for name := range existing.Limits {
+		seen[name] = struct{}{}
+	}
+
+	var changed []corev1.ResourceName
+	for name := range seen {
+		if !desired.Requests[name].Equal(existing.Requests[name]) || !desired.Limits[name].Equal(existing.Limits[name]) {
+			changed = append(changed, name)
+		}
+	}
+	return changed
+}
+
+// PatchPodResourcesInPlace updates containerName's Resources on pod via the
+// Pod's resize subresource, avoiding the Deployment rollout a normal Pod
+// template update would trigger.
+func PatchPodResourcesInPlace(ctx context.Context, cl client.Client, pod *corev1.Pod, containerName string, resources corev1.ResourceRequirements) error {
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == containerName {
+			pod.Spec.Containers[i].Resources = resources
+			break
+		}
+	}
+	return cl.SubResource("resize").Update(ctx, pod)
+}
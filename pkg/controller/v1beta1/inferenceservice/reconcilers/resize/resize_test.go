@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resize
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestApplyResizePolicySetsField(t *testing.T) {
+	container := &corev1.Container{}
+	policy := []corev1.ContainerResizePolicy{{ResourceName: corev1.ResourceCPU, RestartPolicy: corev1.NotRequired}}
+	ApplyResizePolicy(container, policy)
+	if len(container.ResizePolicy) != 1 || container.ResizePolicy[0].ResourceName != corev1.ResourceCPU {
+		t.Fatalf("unexpected resize policy: %+v", container.ResizePolicy)
+	}
+}
+
+func resourcesContainer(name string, cpu, memory string) *corev1.Container {
+	return &corev1.Container{
+		Name: name,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+		},
+	}
+}
+
+func TestCanResizeInPlaceTrueWhenOnlyResourcesDifferAndPolicyAllows(t *testing.T) {
+	desired := resourcesContainer("predictor", "200m", "256Mi")
+	existing := resourcesContainer("predictor", "100m", "256Mi")
+	policy := []corev1.ContainerResizePolicy{{ResourceName: corev1.ResourceCPU, RestartPolicy: corev1.NotRequired}}
+
+	if !CanResizeInPlace(desired, existing, policy) {
+		t.Fatalf("expected in-place resize to be allowed when only CPU changed and policy permits it")
+	}
+}
+
+func TestCanResizeInPlaceFalseWhenPolicyRequiresRestart(t *testing.T) {
+	desired := resourcesContainer("predictor", "200m", "256Mi")
+	existing := resourcesContainer("predictor", "100m", "256Mi")
+	policy := []corev1.ContainerResizePolicy{{ResourceName: corev1.ResourceCPU, RestartPolicy: corev1.RestartContainer}}
+
+	if CanResizeInPlace(desired, existing, policy) {
+		t.Fatalf("expected in-place resize to be rejected when policy requires a restart for the changed resource")
+	}
+}
+
+func TestCanResizeInPlaceFalseWhenNoPolicyEntryForChangedResource(t *testing.T) {
+	desired := resourcesContainer("predictor", "200m", "256Mi")
+	existing := resourcesContainer("predictor", "100m", "256Mi")
+
+	if CanResizeInPlace(desired, existing, nil) {
+		t.Fatalf("expected in-place resize to be rejected by default when no policy entry covers the changed resource")
+	}
+}
+
+func TestCanResizeInPlaceFalseWhenNonResourceFieldsDiffer(t *testing.T) {
+	desired := resourcesContainer("predictor", "100m", "256Mi")
+	existing := resourcesContainer("predictor", "100m", "256Mi")
+	desired.Image = "new-image"
+	policy := []corev1.ContainerResizePolicy{
+		{ResourceName: corev1.ResourceCPU, RestartPolicy: corev1.NotRequired},
+		{ResourceName: corev1.ResourceMemory, RestartPolicy: corev1.NotRequired},
+	}
+
+	if CanResizeInPlace(desired, existing, policy) {
+		t.Fatalf("expected in-place resize to be rejected when a non-resource field changed")
+	}
+}
+
+func TestCanResizeInPlaceTrueWhenNothingChanged(t *testing.T) {
+	desired := resourcesContainer("predictor", "100m", "256Mi")
+	existing := resourcesContainer("predictor", "100m", "256Mi")
+
+	if !CanResizeInPlace(desired, existing, nil) {
+		t.Fatalf("expected an identical container to be resizable in place trivially")
+	}
+}
+
+func TestCanResizeInPlaceTrueWhenUnrelatedResourceUnchanged(t *testing.T) {
+	desired := resourcesContainer("predictor", "100m", "256Mi")
+	existing := resourcesContainer("predictor", "100m", "256Mi")
+	desired.Resources.Limits = corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("1Gi")}
+	existing.Resources.Limits = corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("1Gi")}
+
+	if !CanResizeInPlace(desired, existing, nil) {
+		t.Fatalf("expected no change in resources to always be resizable in place")
+	}
+}
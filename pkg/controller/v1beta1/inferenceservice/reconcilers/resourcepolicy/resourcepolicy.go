@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resourcepolicy fills in cpu/memory/ephemeral-storage/gpu defaults
+// a user left unset on a predictor or storage-initializer container, and
+// rejects explicit requests that exceed a configured ceiling, so a cluster
+// admin can stop ephemeral-storage exhaustion from model downloads without
+// every InferenceService author having to size that field themselves.
+//
+// Policy is meant to be sourced from the inferenceservice-config ConfigMap
+// (a cluster-wide default) with per-namespace overrides selected by a label
+// on the ISVC's namespace; this package takes the already-resolved Policy as
+// a plain value and leaves that ConfigMap/label lookup, and the webhook
+// admission call, to their respective packages, neither of which exists in
+// this tree yet.
+package resourcepolicy
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ResourceDefaults holds a default and a ceiling for one resource name.
+// Default is applied when the user left the field unset; Limit, when
+// non-zero, rejects any explicit request/limit above it.
+type ResourceDefaults struct {
+	Default corev1.ResourceList
+	Limit   corev1.ResourceList
+}
+
+// Policy bounds and defaults the resources a predictor and its
+// storage-initializer init container request, mirroring a "TaskResources"
+// style config: one set of defaults/limits per container role.
+type Policy struct {
+	Predictor          ResourceDefaults
+	StorageInitializer ResourceDefaults
+}
+
+// ApplyDefaults fills any resource name present in policy.Default but absent
+// from resources.Requests/Limits, without overwriting a value the user
+// already set.
+func ApplyDefaults(resources *corev1.ResourceRequirements, policy ResourceDefaults) {
+	for name, qty := range policy.Default {
+		if resources.Requests == nil {
+			resources.Requests = corev1.ResourceList{}
+		}
+		if _, ok := resources.Requests[name]; !ok {
+			resources.Requests[name] = qty
+		}
+		if resources.Limits == nil {
+			resources.Limits = corev1.ResourceList{}
+		}
+		if _, ok := resources.Limits[name]; !ok {
+			resources.Limits[name] = qty
+		}
+	}
+}
+
+// ValidateCeiling rejects any resource name in resources.Requests/Limits
+// whose value exceeds policy.Limit's entry for that name. A resource name
+// absent from policy.Limit is unbounded.
+func ValidateCeiling(resources corev1.ResourceRequirements, policy ResourceDefaults) error {
+	for name, ceiling := range policy.Limit {
+		if qty, ok := resources.Requests[name]; ok && qty.Cmp(ceiling) > 0 {
+			return fmt.Errorf("requested %s %s exceeds the configured limit of %s", name, qty.String(), ceiling.String())
+		}
+		if qty, ok := resources.Limits[name]; ok && qty.Cmp(ceiling) > 0 {
+			return fmt.Errorf("requested %s limit %s exceeds the configured limit of %s", name, qty.String(), ceiling.String())
+		}
+	}
+	return nil
+}
+
+// perNamespaceOverrideLabelKey is the label on an ISVC's namespace selecting
+// a named override of the cluster-wide Policy, e.g.
+// "serving.kserve.io/resource-policy: gpu-heavy-team" picks the "gpu-heavy-team"
+// entry from the inferenceservice-config ConfigMap's per-project overrides.
+const perNamespaceOverrideLabelKey = "serving.kserve.io/resource-policy"
+
+// Resolve returns the override named by namespace's perNamespaceOverrideLabelKey
+// label, if overrides contains an entry for it, else clusterDefault.
+func Resolve(namespace *corev1.Namespace, clusterDefault Policy, overrides map[string]Policy) Policy {
+	name, ok := namespace.Labels[perNamespaceOverrideLabelKey]
+	if !ok {
+		return clusterDefault
+	}
+	if override, ok := overrides[name]; ok {
+		return override
+	}
+	return clusterDefault
+}
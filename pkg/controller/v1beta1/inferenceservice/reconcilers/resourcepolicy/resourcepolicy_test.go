@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcepolicy
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApplyDefaultsFillsUnsetFields(t *testing.T) {
+	resources := &corev1.ResourceRequirements{}
+	policy := ResourceDefaults{Default: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")}}
+
+	ApplyDefaults(resources, policy)
+
+	if got := resources.Requests[corev1.ResourceCPU]; got.String() != "500m" {
+		t.Fatalf("expected CPU request to default to 500m, got %s", got.String())
+	}
+	if got := resources.Limits[corev1.ResourceCPU]; got.String() != "500m" {
+		t.Fatalf("expected CPU limit to default to 500m, got %s", got.String())
+	}
+}
+
+func TestApplyDefaultsDoesNotOverwriteExplicitValue(t *testing.T) {
+	resources := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+	}
+	policy := ResourceDefaults{Default: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")}}
+
+	ApplyDefaults(resources, policy)
+
+	if got := resources.Requests[corev1.ResourceCPU]; got.String() != "1" {
+		t.Fatalf("expected the user's explicit CPU request to be preserved, got %s", got.String())
+	}
+}
+
+func TestValidateCeilingRejectsRequestAboveLimit(t *testing.T) {
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("20Gi")},
+	}
+	policy := ResourceDefaults{Limit: corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("10Gi")}}
+
+	if err := ValidateCeiling(resources, policy); err == nil {
+		t.Fatalf("expected an error when the request exceeds the configured ceiling")
+	}
+}
+
+func TestValidateCeilingAllowsRequestAtOrBelowLimit(t *testing.T) {
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("10Gi")},
+	}
+	policy := ResourceDefaults{Limit: corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("10Gi")}}
+
+	if err := ValidateCeiling(resources, policy); err != nil {
+		t.Fatalf("expected no error at exactly the ceiling, got %v", err)
+	}
+}
+
+func TestValidateCeilingIgnoresUnconfiguredResource(t *testing.T) {
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100")},
+	}
+	if err := ValidateCeiling(resources, ResourceDefaults{}); err != nil {
+		t.Fatalf("expected no error for a resource with no configured ceiling, got %v", err)
+	}
+}
+
+func TestResolveReturnsClusterDefaultWithoutLabel(t *testing.T) {
+	ns := &corev1.Namespace{}
+	clusterDefault := Policy{Predictor: ResourceDefaults{Default: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}}}
+
+	got := Resolve(ns, clusterDefault, nil)
+	if got.Predictor.Default[corev1.ResourceCPU] != clusterDefault.Predictor.Default[corev1.ResourceCPU] {
+		t.Fatalf("expected the cluster default to be returned, got %+v", got)
+	}
+}
+
+func TestResolveUsesNamedOverride(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{perNamespaceOverrideLabelKey: "gpu-heavy-team"}}}
+	clusterDefault := Policy{}
+	override := Policy{Predictor: ResourceDefaults{Default: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}}}
+
+	got := Resolve(ns, clusterDefault, map[string]Policy{"gpu-heavy-team": override})
+	if got.Predictor.Default[corev1.ResourceCPU] != override.Predictor.Default[corev1.ResourceCPU] {
+		t.Fatalf("expected the named override to be selected, got %+v", got)
+	}
+}
+
+func TestResolveFallsBackToDefaultWhenOverrideMissing(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{perNamespaceOverrideLabelKey: "unknown-team"}}}
+	clusterDefault := Policy{Predictor: ResourceDefaults{Default: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}}}
+
+	got := Resolve(ns, clusterDefault, map[string]Policy{})
+	if got.Predictor.Default[corev1.ResourceCPU] != clusterDefault.Predictor.Default[corev1.ResourceCPU] {
+		t.Fatalf("expected a fall back to the cluster default when the named override doesn't exist, got %+v", got)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stop
+
+import "github.com/kserve/kserve/pkg/constants"
+
+// ComponentStopAnnotationKey returns the per-component stop annotation, e.g.
+// "serving.kserve.io/stop-predictor", so a single InferenceService can keep
+// its predictor running while scaling down an idle explainer or transformer,
+// rather than only supporting an all-or-nothing stop via
+// constants.StopAnnotationKey.
+func ComponentStopAnnotationKey(component constants.InferenceServiceComponent) string {
+	return constants.KServeAPIGroupName + "/stop-" + string(component)
+}
+
+// IsComponentStopped reports whether a specific component should be stopped,
+// honoring both the per-component annotation and the existing whole-ISVC stop
+// annotation.
+func IsComponentStopped(annotations map[string]string, component constants.InferenceServiceComponent) bool {
+	if annotations[constants.StopAnnotationKey] == "true" {
+		return true
+	}
+	return annotations[ComponentStopAnnotationKey(component)] == "true"
+}
+
+// allComponents lists every component that supports an individual stop
+// annotation.
+var allComponents = []constants.InferenceServiceComponent{
+	constants.Predictor,
+	constants.Transformer,
+	constants.Explainer,
+}
+
+// StoppedComponents returns the subset of allComponents currently stopped,
+// which the reconciler uses to decide which components' Deployments to scale
+// to zero independently of the others.
+func StoppedComponents(annotations map[string]string) []constants.InferenceServiceComponent {
+	var stopped []constants.InferenceServiceComponent
+	for _, component := range allComponents {
+		if IsComponentStopped(annotations, component) {
+			stopped = append(stopped, component)
+		}
+	}
+	return stopped
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stop
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestComponentStopAnnotationKey(t *testing.T) {
+	if got := ComponentStopAnnotationKey(constants.Predictor); got != "serving.kserve.io/stop-predictor" {
+		t.Fatalf("unexpected annotation key: %q", got)
+	}
+}
+
+func TestIsComponentStoppedWholeISVCStop(t *testing.T) {
+	annotations := map[string]string{constants.StopAnnotationKey: "true"}
+	if !IsComponentStopped(annotations, constants.Transformer) {
+		t.Fatalf("expected the whole-ISVC stop annotation to stop every component")
+	}
+}
+
+func TestIsComponentStoppedPerComponent(t *testing.T) {
+	annotations := map[string]string{ComponentStopAnnotationKey(constants.Explainer): "true"}
+	if !IsComponentStopped(annotations, constants.Explainer) {
+		t.Fatalf("expected the explainer to be stopped")
+	}
+	if IsComponentStopped(annotations, constants.Predictor) {
+		t.Fatalf("expected the predictor to keep running")
+	}
+}
+
+func TestIsComponentStoppedNoAnnotations(t *testing.T) {
+	if IsComponentStopped(nil, constants.Predictor) {
+		t.Fatalf("expected no component to be stopped with no annotations")
+	}
+}
+
+func TestStoppedComponents(t *testing.T) {
+	annotations := map[string]string{
+		ComponentStopAnnotationKey(constants.Transformer): "true",
+		ComponentStopAnnotationKey(constants.Explainer):   "true",
+	}
+	stopped := StoppedComponents(annotations)
+	if len(stopped) != 2 || stopped[0] != constants.Transformer || stopped[1] != constants.Explainer {
+		t.Fatalf("unexpected stopped components: %+v", stopped)
+	}
+}
+
+func TestStoppedComponentsNoneStopped(t *testing.T) {
+	if stopped := StoppedComponents(nil); len(stopped) != 0 {
+		t.Fatalf("expected no stopped components, got %+v", stopped)
+	}
+}
+
+func TestStoppedComponentsWholeISVCStop(t *testing.T) {
+	annotations := map[string]string{constants.StopAnnotationKey: "true"}
+	stopped := StoppedComponents(annotations)
+	if len(stopped) != 3 {
+		t.Fatalf("expected every component to be stopped, got %+v", stopped)
+	}
+}
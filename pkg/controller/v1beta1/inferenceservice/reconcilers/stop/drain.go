@@ -0,0 +1,61 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stop
+
+import "time"
+
+// DrainGracePeriodAnnotationKey configures how long the reconciler waits,
+// after an InferenceService is marked stopped, before deleting its Deployment,
+// HPA, and routing objects. During the grace period the Deployment is scaled
+// to zero but left in place so in-flight requests drain and the resource can
+// be resumed instantly without a cold create.
+const DrainGracePeriodAnnotationKey = "serving.kserve.io/stop-drain-grace-period"
+
+// DefaultDrainGracePeriod is used when DrainGracePeriodAnnotationKey is unset.
+const DefaultDrainGracePeriod = 5 * time.Minute
+
+// DrainPhase identifies where a stopping InferenceService is in the teardown
+// sequence.
+type DrainPhase string
+
+const (
+	// DrainPhaseActive means the ISVC is not stopped; no draining is happening.
+	DrainPhaseActive DrainPhase = "Active"
+	// DrainPhaseDraining means the ISVC was just marked stopped: the Deployment
+	// is scaled to zero but not yet deleted, and child resources are preserved.
+	DrainPhaseDraining DrainPhase = "Draining"
+	// DrainPhaseStopped means the grace period has elapsed and child resources
+	// have been torn down.
+	DrainPhaseStopped DrainPhase = "Stopped"
+)
+
+// ComputeDrainPhase determines which phase a stopped InferenceService is in,
+// given when it was marked stopped and the configured grace period.
+func ComputeDrainPhase(stopped bool, stoppedAt time.Time, gracePeriod time.Duration, now time.Time) DrainPhase {
+	if !stopped {
+		return DrainPhaseActive
+	}
+	if stoppedAt.IsZero() {
+		// No recorded transition time: treat as already past the grace period
+		// rather than blocking teardown indefinitely.
+		return DrainPhaseStopped
+	}
+	if now.Before(stoppedAt.Add(gracePeriod)) {
+		return DrainPhaseDraining
+	}
+	return DrainPhaseStopped
+}
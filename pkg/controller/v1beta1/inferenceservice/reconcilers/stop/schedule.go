@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package stop implements scheduled and annotation-driven stop/start behavior
+// for InferenceServices, layered on top of the existing
+// serving.kserve.io/stop annotation handled by utils.GetForceStopRuntime.
+package stop
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ScheduleAnnotationKey configures recurring stop/resume windows for an
+// InferenceService, e.g. to scale a dev environment down outside business
+// hours. The annotation value is a JSON object: {"stop": "<cron>", "resume": "<cron>", "timezone": "<IANA tz>"}.
+const ScheduleAnnotationKey = "serving.kserve.io/stop-schedule"
+
+// Schedule describes a recurring stop/resume window.
+type Schedule struct {
+	Stop     string `json:"stop"`
+	Resume   string `json:"resume"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// IsWithinStopWindow reports whether `now` falls within the most recently
+// elapsed [stop, resume) window described by the schedule.
+func IsWithinStopWindow(schedule Schedule, now time.Time) (bool, error) {
+	loc := time.UTC
+	if schedule.Timezone != "" {
+		l, err := time.LoadLocation(schedule.Timezone)
+		if err != nil {
+			return false, fmt.Errorf("invalid timezone %q in stop schedule: %w", schedule.Timezone, err)
+		}
+		loc = l
+	}
+	now = now.In(loc)
+
+	stopSchedule, err := cronParser.Parse(schedule.Stop)
+	if err != nil {
+		return false, fmt.Errorf("invalid stop schedule %q: %w", schedule.Stop, err)
+	}
+	resumeSchedule, err := cronParser.Parse(schedule.Resume)
+	if err != nil {
+		return false, fmt.Errorf("invalid resume schedule %q: %w", schedule.Resume, err)
+	}
+
+	lastStop := mostRecentFireBefore(stopSchedule, now)
+	lastResume := mostRecentFireBefore(resumeSchedule, now)
+	if lastStop.IsZero() {
+		return false, nil
+	}
+	return lastResume.Before(lastStop), nil
+}
+
+// mostRecentFireBefore walks backwards from `now` to find the schedule's most
+// recent fire time at or before `now`. Cron schedules only expose Next, so we
+// search back from a bounded window rather than requiring a Prev() API.
+func mostRecentFireBefore(schedule cron.Schedule, now time.Time) time.Time {
+	const searchWindow = 366 * 24 * time.Hour
+	cursor := now.Add(-searchWindow)
+	var last time.Time
+	for {
+		next := schedule.Next(cursor)
+		if next.IsZero() || next.After(now) {
+			break
+		}
+		last = next
+		cursor = next
+	}
+	return last
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stop
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ParseSchedule reads ScheduleAnnotationKey from the InferenceService's
+// annotations. It returns ok=false when the annotation is unset, so callers
+// can distinguish "no schedule configured" from a malformed one.
+func ParseSchedule(annotations map[string]string) (schedule Schedule, ok bool, err error) {
+	value, present := annotations[ScheduleAnnotationKey]
+	if !present {
+		return Schedule{}, false, nil
+	}
+	if err := json.Unmarshal([]byte(value), &schedule); err != nil {
+		return Schedule{}, true, fmt.Errorf("invalid %s annotation: %w", ScheduleAnnotationKey, err)
+	}
+	return schedule, true, nil
+}
+
+// IsScheduledStop reports whether the InferenceService should be stopped right
+// now because of its ScheduleAnnotationKey window, independent of the
+// unconditional constants.StopAnnotationKey. A malformed schedule is treated
+// as "not stopped" rather than failing reconciliation outright; callers
+// should still surface err as a status condition.
+func IsScheduledStop(annotations map[string]string, now time.Time) (bool, error) {
+	schedule, ok, err := ParseSchedule(annotations)
+	if !ok || err != nil {
+		return false, err
+	}
+	return IsWithinStopWindow(schedule, now)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stop
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseScheduleNotPresent(t *testing.T) {
+	schedule, ok, err := ParseSchedule(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Fatalf("expected ok=false when the annotation is unset")
+	}
+	if schedule != (Schedule{}) {
+		t.Fatalf("expected a zero-value schedule, got %+v", schedule)
+	}
+}
+
+func TestParseScheduleValid(t *testing.T) {
+	annotations := map[string]string{
+		ScheduleAnnotationKey: `{"stop": "0 20 * * *", "resume": "0 8 * * *", "timezone": "America/New_York"}`,
+	}
+	schedule, ok, err := ParseSchedule(annotations)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatalf("expected ok=true when the annotation is present")
+	}
+	want := Schedule{Stop: "0 20 * * *", Resume: "0 8 * * *", Timezone: "America/New_York"}
+	if schedule != want {
+		t.Fatalf("got %+v, want %+v", schedule, want)
+	}
+}
+
+func TestParseScheduleMalformed(t *testing.T) {
+	annotations := map[string]string{ScheduleAnnotationKey: "not-json"}
+	_, ok, err := ParseSchedule(annotations)
+	if !ok {
+		t.Fatalf("expected ok=true since the annotation is present, even though it's malformed")
+	}
+	if err == nil {
+		t.Fatalf("expected an error for malformed JSON")
+	}
+}
+
+func TestIsScheduledStopNoAnnotation(t *testing.T) {
+	within, err := IsScheduledStop(nil, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if within {
+		t.Fatalf("expected false when no schedule is configured")
+	}
+}
+
+func TestIsScheduledStopMalformedTreatedAsNotStopped(t *testing.T) {
+	annotations := map[string]string{ScheduleAnnotationKey: "not-json"}
+	within, err := IsScheduledStop(annotations, time.Now())
+	if err == nil {
+		t.Fatalf("expected the malformed schedule's error to be surfaced")
+	}
+	if within {
+		t.Fatalf("expected a malformed schedule to be treated as not stopped")
+	}
+}
+
+func TestIsScheduledStopWithinWindow(t *testing.T) {
+	annotations := map[string]string{
+		ScheduleAnnotationKey: `{"stop": "0 20 * * *", "resume": "0 8 * * *"}`,
+	}
+	now := time.Date(2024, 1, 2, 23, 0, 0, 0, time.UTC)
+	within, err := IsScheduledStop(annotations, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !within {
+		t.Fatalf("expected to be within the scheduled stop window")
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stop
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsWithinStopWindowBeforeFirstStop(t *testing.T) {
+	schedule := Schedule{Stop: "0 20 * * *", Resume: "0 8 * * *"}
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	within, err := IsWithinStopWindow(schedule, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if within {
+		t.Fatalf("expected not to be within the stop window before the first stop has ever fired")
+	}
+}
+
+func TestIsWithinStopWindowAfterStopBeforeResume(t *testing.T) {
+	schedule := Schedule{Stop: "0 20 * * *", Resume: "0 8 * * *"}
+	now := time.Date(2024, 1, 2, 23, 0, 0, 0, time.UTC)
+	within, err := IsWithinStopWindow(schedule, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !within {
+		t.Fatalf("expected to be within the stop window after the 20:00 stop has fired and before the next 08:00 resume")
+	}
+}
+
+func TestIsWithinStopWindowAfterResume(t *testing.T) {
+	schedule := Schedule{Stop: "0 20 * * *", Resume: "0 8 * * *"}
+	now := time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC)
+	within, err := IsWithinStopWindow(schedule, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if within {
+		t.Fatalf("expected not to be within the stop window after the following resume has fired")
+	}
+}
+
+func TestIsWithinStopWindowHonorsTimezone(t *testing.T) {
+	schedule := Schedule{Stop: "0 20 * * *", Resume: "0 8 * * *", Timezone: "America/New_York"}
+	// 2024-01-02 01:30 UTC is 2024-01-01 20:30 in America/New_York (UTC-5 in January), just after the 20:00 stop.
+	now := time.Date(2024, 1, 2, 1, 30, 0, 0, time.UTC)
+	within, err := IsWithinStopWindow(schedule, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !within {
+		t.Fatalf("expected the stop window to be evaluated in the configured timezone")
+	}
+}
+
+func TestIsWithinStopWindowInvalidTimezone(t *testing.T) {
+	schedule := Schedule{Stop: "0 20 * * *", Resume: "0 8 * * *", Timezone: "Not/A/Zone"}
+	if _, err := IsWithinStopWindow(schedule, time.Now()); err == nil {
+		t.Fatalf("expected an error for an invalid timezone")
+	}
+}
+
+func TestIsWithinStopWindowInvalidStopExpression(t *testing.T) {
+	schedule := Schedule{Stop: "not-a-cron", Resume: "0 8 * * *"}
+	if _, err := IsWithinStopWindow(schedule, time.Now()); err == nil {
+		t.Fatalf("expected an error for an invalid stop cron expression")
+	}
+}
+
+func TestIsWithinStopWindowInvalidResumeExpression(t *testing.T) {
+	schedule := Schedule{Stop: "0 20 * * *", Resume: "not-a-cron"}
+	if _, err := IsWithinStopWindow(schedule, time.Now()); err == nil {
+		t.Fatalf("expected an error for an invalid resume cron expression")
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stop
+
+// ModeAnnotationKey selects between the two stop behaviors: "hard" (the
+// existing default - delete the Deployment, HPA, and routing objects) and
+// "soft" (scale the Deployment to zero but keep the Service/HTTPRoute/Ingress
+// so the ISVC's URL keeps resolving, just to no ready backends, and resuming
+// doesn't require re-reconciling routing from scratch).
+const ModeAnnotationKey = "serving.kserve.io/stop-mode"
+
+// Mode is the stop behavior requested for an InferenceService.
+type Mode string
+
+const (
+	// ModeHard tears down the Deployment, HPA, and routing objects.
+	ModeHard Mode = "hard"
+	// ModeSoft scales the Deployment to zero replicas but preserves routing
+	// objects (Service, HTTPRoute/Ingress) so the ISVC's address is stable
+	// across stop/resume cycles.
+	ModeSoft Mode = "soft"
+)
+
+// ResolveMode returns the requested stop Mode, defaulting to ModeHard for
+// backward compatibility with the existing stop annotation's behavior.
+func ResolveMode(annotations map[string]string) Mode {
+	switch Mode(annotations[ModeAnnotationKey]) {
+	case ModeSoft:
+		return ModeSoft
+	default:
+		return ModeHard
+	}
+}
+
+// ShouldPreserveRoutingObjects reports whether the Service/HTTPRoute/Ingress
+// generated for a component should survive a stop, based on the resolved Mode.
+func ShouldPreserveRoutingObjects(mode Mode) bool {
+	return mode == ModeSoft
+}
+
+// ChildResourceKind identifies a kind of child resource the reconciler manages
+// for a component, for use with ShouldPreserve.
+type ChildResourceKind string
+
+const (
+	ChildDeployment ChildResourceKind = "Deployment"
+	ChildHPA        ChildResourceKind = "HorizontalPodAutoscaler"
+	ChildService    ChildResourceKind = "Service"
+	ChildRoute      ChildResourceKind = "HTTPRoute"
+)
+
+// ShouldPreserve reports whether a given child resource kind should be left in
+// place (rather than deleted) while the component is stopped. The Deployment
+// is always scaled to zero rather than deleted in either mode; the HPA is
+// always removed since there is nothing to scale; routing objects are only
+// preserved in ModeSoft.
+func ShouldPreserve(mode Mode, kind ChildResourceKind) bool {
+	switch kind {
+	case ChildDeployment:
+		return true
+	case ChildHPA:
+		return false
+	case ChildService, ChildRoute:
+		return ShouldPreserveRoutingObjects(mode)
+	default:
+		return false
+	}
+}
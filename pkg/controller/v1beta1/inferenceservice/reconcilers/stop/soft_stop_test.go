@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stop
+
+import "testing"
+
+func TestResolveMode(t *testing.T) {
+	cases := []struct {
+		name        string
+		annotations map[string]string
+		want        Mode
+	}{
+		{"unset defaults to hard", nil, ModeHard},
+		{"soft", map[string]string{ModeAnnotationKey: "soft"}, ModeSoft},
+		{"hard explicit", map[string]string{ModeAnnotationKey: "hard"}, ModeHard},
+		{"unrecognized value defaults to hard", map[string]string{ModeAnnotationKey: "bogus"}, ModeHard},
+	}
+	for _, tc := range cases {
+		if got := ResolveMode(tc.annotations); got != tc.want {
+			t.Errorf("%s: ResolveMode() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestShouldPreserveRoutingObjects(t *testing.T) {
+	if ShouldPreserveRoutingObjects(ModeHard) {
+		t.Fatalf("expected ModeHard to not preserve routing objects")
+	}
+	if !ShouldPreserveRoutingObjects(ModeSoft) {
+		t.Fatalf("expected ModeSoft to preserve routing objects")
+	}
+}
+
+func TestShouldPreserve(t *testing.T) {
+	cases := []struct {
+		mode Mode
+		kind ChildResourceKind
+		want bool
+	}{
+		{ModeHard, ChildDeployment, true},
+		{ModeSoft, ChildDeployment, true},
+		{ModeHard, ChildHPA, false},
+		{ModeSoft, ChildHPA, false},
+		{ModeHard, ChildService, false},
+		{ModeSoft, ChildService, true},
+		{ModeHard, ChildRoute, false},
+		{ModeSoft, ChildRoute, true},
+		{ModeSoft, ChildResourceKind("Unknown"), false},
+	}
+	for _, tc := range cases {
+		if got := ShouldPreserve(tc.mode, tc.kind); got != tc.want {
+			t.Errorf("ShouldPreserve(%v, %v) = %v, want %v", tc.mode, tc.kind, got, tc.want)
+		}
+	}
+}
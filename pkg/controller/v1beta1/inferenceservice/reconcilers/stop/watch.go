@@ -0,0 +1,63 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stop
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// AnnotationChangedPredicate returns a predicate.Funcs that only lets update
+// events through when one of the stop-related annotations on the owning
+// InferenceService actually changed. The metadata-only Owns() watches already
+// cut the informer cache down to ObjectMeta; this predicate cuts the
+// remaining traffic further so a reconcile is only requeued when the stop
+// state itself could have changed, not on every unrelated child resource
+// status update.
+func AnnotationChangedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return true
+			}
+			return !equality.Semantic.DeepEqual(
+				relevantAnnotations(e.ObjectOld.GetAnnotations()),
+				relevantAnnotations(e.ObjectNew.GetAnnotations()),
+			)
+		},
+	}
+}
+
+// relevantAnnotations extracts the subset of annotations this package's
+// reconciler cares about, so unrelated annotation churn (e.g. kubectl's
+// last-applied-configuration) doesn't trigger a spurious reconcile.
+func relevantAnnotations(annotations map[string]string) map[string]string {
+	relevant := make(map[string]string, 3)
+	for _, key := range []string{ScheduleAnnotationKey, ModeAnnotationKey, DrainGracePeriodAnnotationKey} {
+		if value, ok := annotations[key]; ok {
+			relevant[key] = value
+		}
+	}
+	for _, component := range allComponents {
+		key := ComponentStopAnnotationKey(component)
+		if value, ok := annotations[key]; ok {
+			relevant[key] = value
+		}
+	}
+	return relevant
+}
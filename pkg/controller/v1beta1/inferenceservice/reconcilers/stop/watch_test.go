@@ -0,0 +1,103 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stop
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func withAnnotations(annotations map[string]string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+}
+
+func TestAnnotationChangedPredicateIgnoresUnrelatedAnnotationChurn(t *testing.T) {
+	predicate := AnnotationChangedPredicate()
+	update := event.UpdateEvent{
+		ObjectOld: withAnnotations(map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "old"}),
+		ObjectNew: withAnnotations(map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "new"}),
+	}
+	if predicate.Update(update) {
+		t.Fatalf("expected unrelated annotation churn to be filtered out")
+	}
+}
+
+func TestAnnotationChangedPredicateFiresOnScheduleChange(t *testing.T) {
+	predicate := AnnotationChangedPredicate()
+	update := event.UpdateEvent{
+		ObjectOld: withAnnotations(nil),
+		ObjectNew: withAnnotations(map[string]string{ScheduleAnnotationKey: `{"stop":"0 20 * * *","resume":"0 8 * * *"}`}),
+	}
+	if !predicate.Update(update) {
+		t.Fatalf("expected a schedule annotation change to trigger a reconcile")
+	}
+}
+
+func TestAnnotationChangedPredicateFiresOnComponentStopChange(t *testing.T) {
+	predicate := AnnotationChangedPredicate()
+	update := event.UpdateEvent{
+		ObjectOld: withAnnotations(nil),
+		ObjectNew: withAnnotations(map[string]string{ComponentStopAnnotationKey(constants.Predictor): "true"}),
+	}
+	if !predicate.Update(update) {
+		t.Fatalf("expected a per-component stop annotation change to trigger a reconcile")
+	}
+}
+
+func TestAnnotationChangedPredicateNoChange(t *testing.T) {
+	predicate := AnnotationChangedPredicate()
+	annotations := map[string]string{ModeAnnotationKey: "soft"}
+	update := event.UpdateEvent{
+		ObjectOld: withAnnotations(annotations),
+		ObjectNew: withAnnotations(annotations),
+	}
+	if predicate.Update(update) {
+		t.Fatalf("expected no reconcile when the relevant annotations are unchanged")
+	}
+}
+
+func TestAnnotationChangedPredicateNilObjectsAlwaysFire(t *testing.T) {
+	predicate := AnnotationChangedPredicate()
+	if !predicate.Update(event.UpdateEvent{}) {
+		t.Fatalf("expected nil old/new objects to fall back to firing the reconcile")
+	}
+}
+
+func TestRelevantAnnotationsFiltersOutUnrelatedKeys(t *testing.T) {
+	got := relevantAnnotations(map[string]string{
+		ScheduleAnnotationKey: "schedule",
+		"unrelated":           "value",
+		ComponentStopAnnotationKey(constants.Explainer): "true",
+	})
+	want := map[string]string{
+		ScheduleAnnotationKey:                           "schedule",
+		ComponentStopAnnotationKey(constants.Explainer): "true",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Fatalf("got %+v, want %+v", got, want)
+		}
+	}
+}
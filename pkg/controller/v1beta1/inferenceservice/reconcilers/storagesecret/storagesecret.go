@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package storagesecret resolves which Secret the storage-initializer
+// credentials builder should read for a given InferenceService and
+// component, so a namespace is no longer limited to a single cluster-wide
+// storageSpecSecretName: constants.StorageSecretNameAnnotationKey (and its
+// per-component variant) let an individual InferenceService opt into its own
+// Secret instead.
+//
+// This package only resolves the Secret name; the storage-initializer
+// credentials builder that dispatches to an S3/GCS/Azure/HF-Hub provider
+// using the resolved Secret, and the webhook validation that the Secret
+// exists at admission time, belong to the credentials builder and webhook
+// packages respectively, neither of which exists yet in this tree.
+package storagesecret
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// Resolve returns the Secret name component should use for its
+// storage-initializer credentials: the component-specific override
+// (constants.StorageSecretNameAnnotationKeyForComponent) if isvcMeta carries
+// one, else the ISVC-wide constants.StorageSecretNameAnnotationKey, else
+// defaultSecretName (the storage-initializer config's cluster-wide value).
+func Resolve(isvcMeta metav1.ObjectMeta, component constants.InferenceServiceComponent, defaultSecretName string) string {
+	if name, ok := isvcMeta.Annotations[constants.StorageSecretNameAnnotationKeyForComponent(component)]; ok && name != "" {
+		return name
+	}
+	if name, ok := isvcMeta.Annotations[constants.StorageSecretNameAnnotationKey]; ok && name != "" {
+		return name
+	}
+	return defaultSecretName
+}
+
+// ValidateExists returns an error naming the missing Secret if the name
+// Resolve would select for component isn't present in existingSecretNames,
+// the set of Secret names that actually exist in the ISVC's namespace. It's
+// meant to be called from the ISVC webhook at admission time, before a
+// missing Secret can surface as a pod stuck in ContainerCreating instead.
+func ValidateExists(isvcMeta metav1.ObjectMeta, component constants.InferenceServiceComponent, defaultSecretName string, existingSecretNames map[string]struct{}) error {
+	name := Resolve(isvcMeta, component, defaultSecretName)
+	if name == "" {
+		return nil
+	}
+	if _, ok := existingSecretNames[name]; !ok {
+		return fmt.Errorf("storage credential secret %q referenced by %s does not exist in namespace %q",
+			name, constants.StorageSecretNameAnnotationKey, isvcMeta.Namespace)
+	}
+	return nil
+}
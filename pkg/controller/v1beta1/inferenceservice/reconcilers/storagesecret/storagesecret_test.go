@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagesecret
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestResolveDefaultsToClusterWideSecret(t *testing.T) {
+	meta := metav1.ObjectMeta{}
+	got := Resolve(meta, constants.Predictor, "default-secret")
+	if got != "default-secret" {
+		t.Fatalf("expected the cluster-wide default, got %q", got)
+	}
+}
+
+func TestResolveISVCWideAnnotationOverridesDefault(t *testing.T) {
+	meta := metav1.ObjectMeta{Annotations: map[string]string{
+		constants.StorageSecretNameAnnotationKey: "isvc-secret",
+	}}
+	got := Resolve(meta, constants.Predictor, "default-secret")
+	if got != "isvc-secret" {
+		t.Fatalf("expected the ISVC-wide annotation to override the default, got %q", got)
+	}
+}
+
+func TestResolveComponentAnnotationOverridesISVCWide(t *testing.T) {
+	meta := metav1.ObjectMeta{Annotations: map[string]string{
+		constants.StorageSecretNameAnnotationKey:                                  "isvc-secret",
+		constants.StorageSecretNameAnnotationKeyForComponent(constants.Predictor): "predictor-secret",
+	}}
+	got := Resolve(meta, constants.Predictor, "default-secret")
+	if got != "predictor-secret" {
+		t.Fatalf("expected the component-specific annotation to win, got %q", got)
+	}
+}
+
+func TestValidateExistsNoErrorWhenNameUnset(t *testing.T) {
+	meta := metav1.ObjectMeta{}
+	if err := ValidateExists(meta, constants.Predictor, "", nil); err != nil {
+		t.Fatalf("expected no error when no secret name resolves, got %v", err)
+	}
+}
+
+func TestValidateExistsErrorsWhenMissing(t *testing.T) {
+	meta := metav1.ObjectMeta{Namespace: "ns", Annotations: map[string]string{
+		constants.StorageSecretNameAnnotationKey: "missing-secret",
+	}}
+	err := ValidateExists(meta, constants.Predictor, "", map[string]struct{}{"other-secret": {}})
+	if err == nil {
+		t.Fatalf("expected an error for a missing secret")
+	}
+}
+
+func TestValidateExistsNoErrorWhenPresent(t *testing.T) {
+	meta := metav1.ObjectMeta{Namespace: "ns", Annotations: map[string]string{
+		constants.StorageSecretNameAnnotationKey: "present-secret",
+	}}
+	err := ValidateExists(meta, constants.Predictor, "", map[string]struct{}{"present-secret": {}})
+	if err != nil {
+		t.Fatalf("expected no error when the secret exists, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protocol
+
+import "github.com/kserve/kserve/pkg/constants"
+
+// init registers kserve's own protocols so Lookup works for them the same
+// way it would for a third-party registration; nothing downstream needs to
+// special-case the built-ins versus a plugin's.
+func init() {
+	Register(string(constants.ProtocolV1), ProtocolSpec{
+		HTTPPathTemplates: map[string]string{
+			"predict": "/v1/models/%s:predict",
+			"explain": "/v1/models/%s:explain",
+		},
+		ContentType:      "application/json",
+		Probe:            PredictorProbe{HTTPPath: "/v1/models/%s"},
+		HealthEndpoint:   "/v1/models/%s",
+		MetadataEndpoint: "/v1/models/%s",
+		InferEndpoint:    "/v1/models/%s:predict",
+		SupportsBatching: true,
+		SupportsExplain:  true,
+		SupportsRawBytes: true,
+	})
+	Register(string(constants.ProtocolV2), ProtocolSpec{
+		HTTPPathTemplates: map[string]string{
+			"predict":         "/v2/models/%s/infer",
+			"generate_stream": "/v2/models/%s/generate_stream",
+		},
+		ContentType:           "application/json",
+		Probe:                 PredictorProbe{HTTPPath: "/v2/health/ready"},
+		HealthEndpoint:        "/v2/health/ready",
+		MetadataEndpoint:      "/v2/models/%s",
+		InferEndpoint:         "/v2/models/%s/infer",
+		SupportsBatching:      true,
+		SupportsStreaming:     true,
+		SupportsBinaryTensors: true,
+	})
+	Register(string(constants.ProtocolGRPCV1), ProtocolSpec{
+		GRPCService:      "tensorflow.serving.PredictionService",
+		GRPCMethods:      map[string]string{"predict": "Predict"},
+		DefaultPort:      9000,
+		ContentType:      "application/grpc",
+		Probe:            PredictorProbe{GRPCService: "tensorflow.serving.PredictionService"},
+		SupportsBatching: true,
+	})
+	Register(string(constants.ProtocolGRPCV2), ProtocolSpec{
+		GRPCService:           "inference.GRPCInferenceService",
+		GRPCMethods:           map[string]string{"predict": "ModelInfer"},
+		DefaultPort:           9000,
+		ContentType:           "application/grpc",
+		Probe:                 PredictorProbe{GRPCService: "inference.GRPCInferenceService"},
+		SupportsBatching:      true,
+		SupportsBinaryTensors: true,
+	})
+	// ProtocolGRPCStream's bidi handshake is probed the same way as
+	// GRPCV2's unary one (a successful Watch/health-check RPC); actually
+	// streaming chunks through pkg/agent's payload logger as NDJSON and
+	// pre/post-processing them in a transformer is left to those packages,
+	// which this snapshot doesn't contain.
+	Register(string(constants.ProtocolGRPCStream), ProtocolSpec{
+		GRPCService: "inference.GRPCInferenceService",
+		GRPCMethods: map[string]string{"predict": "ModelStreamInfer"},
+		HTTPPathTemplates: map[string]string{
+			"generate_stream": "/v2/models/%s/generate_stream",
+		},
+		DefaultPort:           9000,
+		ContentType:           "application/grpc",
+		Probe:                 PredictorProbe{GRPCService: "inference.GRPCInferenceService"},
+		SupportsStreaming:     true,
+		SupportsBinaryTensors: true,
+	})
+	Register(string(constants.ProtocolOpenAI), ProtocolSpec{
+		HTTPPathTemplates: map[string]string{
+			"chat_completions": constants.ChatCompletionsPath(),
+			"completions":      constants.CompletionsPath(),
+		},
+		ContentType:       "application/json",
+		Probe:             PredictorProbe{HTTPPath: "/health"},
+		HealthEndpoint:    "/health",
+		InferEndpoint:     constants.ChatCompletionsPath(),
+		SupportsStreaming: true,
+	})
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GRPCReflector checks, via gRPC server reflection, whether a target
+// advertises serviceName. It's an interface rather than a concrete
+// reflection client so callers that don't speak gRPC to their predictors
+// can pass nil and fall back to the HTTP probe.
+type GRPCReflector interface {
+	SupportsService(ctx context.Context, target string, serviceName string) (bool, error)
+}
+
+// NegotiateCacheTTL bounds how long NegotiateProtocol trusts a previous
+// negotiation result for a given target before probing it again, so a
+// predictor that's mid-rollout from v1 to v2 is picked up within one TTL
+// window rather than being pinned to whatever it spoke at pod start.
+const NegotiateCacheTTL = 30 * time.Second
+
+type negotiateCacheEntry struct {
+	protocol  Protocol
+	expiresAt time.Time
+}
+
+var (
+	negotiateMu    sync.Mutex
+	negotiateCache = map[string]negotiateCacheEntry{}
+)
+
+// NegotiateProtocol resolves constants.ProtocolAutoDetect for target (a
+// predictor endpoint, e.g. the host constants.PredictorURL returns) by
+// probing it, caching the result for NegotiateCacheTTL so repeated calls
+// against the same target don't each pay the probe cost. reflector may be
+// nil, in which case gRPC service reflection is skipped and the HTTP
+// health-check probe decides the result; httpClient may be nil, in which
+// case http.DefaultClient is used.
+func NegotiateProtocol(ctx context.Context, target string, reflector GRPCReflector, httpClient *http.Client) (Protocol, error) {
+	if p, ok := cachedProtocol(target); ok {
+		return p, nil
+	}
+	p, err := detectProtocol(ctx, target, reflector, httpClient)
+	if err != nil {
+		return Protocol{}, err
+	}
+	negotiateMu.Lock()
+	negotiateCache[target] = negotiateCacheEntry{protocol: p, expiresAt: time.Now().Add(NegotiateCacheTTL)}
+	negotiateMu.Unlock()
+	return p, nil
+}
+
+func cachedProtocol(target string) (Protocol, bool) {
+	negotiateMu.Lock()
+	defer negotiateMu.Unlock()
+	entry, ok := negotiateCache[target]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return Protocol{}, false
+	}
+	return entry.protocol, true
+}
+
+func detectProtocol(ctx context.Context, target string, reflector GRPCReflector, httpClient *http.Client) (Protocol, error) {
+	if reflector != nil {
+		if ok, err := reflector.SupportsService(ctx, target, "inference.GRPCInferenceService"); err == nil && ok {
+			if p, ok := Lookup("v2"); ok {
+				return p, nil
+			}
+		}
+		if ok, err := reflector.SupportsService(ctx, target, "tensorflow.serving.PredictionService"); err == nil && ok {
+			if p, ok := Lookup("v1"); ok {
+				return p, nil
+			}
+		}
+	}
+
+	client := httpClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if probeHTTP(ctx, client, target, "/v2/health/ready") {
+		if p, ok := Lookup("v2"); ok {
+			return p, nil
+		}
+	}
+	if probeHTTP(ctx, client, target, "/v1/models") {
+		if p, ok := Lookup("v1"); ok {
+			return p, nil
+		}
+	}
+	return Protocol{}, fmt.Errorf("protocol: could not negotiate a protocol for %q: no reflection match and neither /v2/health/ready nor /v1/models responded", target)
+}
+
+func probeHTTP(ctx context.Context, client *http.Client, target string, path string) bool {
+	host := target
+	if !strings.Contains(host, "://") {
+		host = "http://" + host
+	}
+	url := strings.TrimSuffix(host, "/") + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode >= 200 && resp.StatusCode < 300
+}
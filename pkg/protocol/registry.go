@@ -0,0 +1,174 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package protocol provides a pluggable registry of inference protocols, so
+// that the mesh/readiness-probe/payload-logger code paths that need to know
+// a protocol's wire shape can consult one source of truth instead of
+// switching on constants.InferenceServiceProtocol. kserve's own v1/v2/gRPC
+// protocols and third-party ones (TorchServe REST, Triton BLS, an
+// OpenAI-compatible chat endpoint, ...) register through the same API, so
+// an operator can select any of them via the InferenceService's
+// `protocol: <name>` field without a kserve fork.
+package protocol
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// PredictorProbe describes how to health-check a predictor speaking a given
+// Protocol: an HTTP path to poll, or a gRPC service/method for a health
+// check performed over the gRPC health-checking protocol instead.
+type PredictorProbe struct {
+	// HTTPPath is polled with a GET/HEAD request when non-empty.
+	HTTPPath string
+	// GRPCService is the fully-qualified gRPC service name (e.g.
+	// "inference.GRPCInferenceService") to health-check when HTTPPath is
+	// empty and this protocol is gRPC-based.
+	GRPCService string
+}
+
+// ProtocolSpec is everything the registry needs to answer "how do I talk to
+// a predictor using this protocol" without the caller hard-coding it.
+type ProtocolSpec struct {
+	// HTTPPathTemplates maps a logical operation (e.g. "predict", "explain",
+	// "generate_stream") to its path template, with "%s" standing in for the
+	// model name, e.g. "/v2/models/%s/infer".
+	HTTPPathTemplates map[string]string
+	// GRPCService and GRPCMethods describe the protocol's gRPC surface, when
+	// it has one; GRPCMethods maps the same logical operation names used in
+	// HTTPPathTemplates to their gRPC method name.
+	GRPCService string
+	GRPCMethods map[string]string
+	// DefaultPort is the predictor container port this protocol listens on
+	// when the ServingRuntime doesn't declare its own.
+	DefaultPort int32
+	// ContentType is the default Content-Type/grpc content-subtype a
+	// request carries when the caller doesn't override it.
+	ContentType string
+	// Probe describes how to health-check a predictor using this protocol.
+	Probe PredictorProbe
+
+	// Capability bits let callers (transformer, explainer, agent) branch on
+	// what a protocol supports instead of on its name, so a new protocol
+	// that supports some-but-not-all of these doesn't need every call site
+	// updated with another name check.
+	SupportsBatching      bool
+	SupportsStreaming     bool
+	SupportsExplain       bool
+	SupportsBinaryTensors bool
+	SupportsRawBytes      bool
+
+	// HealthEndpoint, MetadataEndpoint, and InferEndpoint are the HTTP path
+	// templates (same "%s" model-name convention as HTTPPathTemplates) for
+	// the three requests every protocol that has an HTTP surface supports;
+	// they're broken out from HTTPPathTemplates because every caller needs
+	// them, unlike the free-form per-operation entries there.
+	HealthEndpoint   string
+	MetadataEndpoint string
+	InferEndpoint    string
+}
+
+// Protocol is the handle Register returns: an immutable, named view of the
+// ProtocolSpec it was registered with.
+type Protocol struct {
+	name string
+	spec ProtocolSpec
+}
+
+// Name is the string an InferenceService's `protocol` field/annotation
+// would carry to select this Protocol, e.g. "v2", "custom-openai".
+func (p Protocol) Name() string { return p.name }
+
+// Spec returns the ProtocolSpec this Protocol was registered with.
+func (p Protocol) Spec() ProtocolSpec { return p.spec }
+
+// SupportsBatching reports whether this protocol's predictor can be put
+// behind the request batcher.
+func (p Protocol) SupportsBatching() bool { return p.spec.SupportsBatching }
+
+// SupportsStreaming reports whether this protocol keeps the connection open
+// past the first response chunk (see constants.IsStreamingProtocol).
+func (p Protocol) SupportsStreaming() bool { return p.spec.SupportsStreaming }
+
+// SupportsExplain reports whether this protocol has an explain endpoint.
+func (p Protocol) SupportsExplain() bool { return p.spec.SupportsExplain }
+
+// SupportsBinaryTensors reports whether this protocol can carry tensor
+// payloads in a binary (non-JSON-encoded) wire format.
+func (p Protocol) SupportsBinaryTensors() bool { return p.spec.SupportsBinaryTensors }
+
+// SupportsRawBytes reports whether this protocol accepts an unstructured
+// raw-bytes request body rather than requiring a typed envelope.
+func (p Protocol) SupportsRawBytes() bool { return p.spec.SupportsRawBytes }
+
+// GRPCServiceName returns the gRPC service FQN this protocol serves, or ""
+// if it has no gRPC surface.
+func (p Protocol) GRPCServiceName() string { return p.spec.GRPCService }
+
+// HTTPPath renders this protocol's path template for operation (e.g.
+// "predict") with modelName substituted in, returning false if this
+// protocol has no template for that operation. Templates with no "%s"
+// placeholder (e.g. a protocol-wide endpoint like chat completions) are
+// returned unchanged, ignoring modelName.
+func (p Protocol) HTTPPath(operation string, modelName string) (string, bool) {
+	template, ok := p.spec.HTTPPathTemplates[operation]
+	if !ok {
+		return "", false
+	}
+	if !strings.Contains(template, "%s") {
+		return template, true
+	}
+	return fmt.Sprintf(template, modelName), true
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Protocol{}
+)
+
+// Register adds a protocol under name to the registry, returning its
+// Protocol handle. Registering under an already-used name replaces the
+// previous entry, so a package can override a built-in protocol's spec
+// (e.g. to change its DefaultPort) without forking the registry.
+func Register(name string, spec ProtocolSpec) Protocol {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	p := Protocol{name: name, spec: spec}
+	registry[name] = p
+	return p
+}
+
+// Lookup returns the Protocol registered under name, if any.
+func Lookup(name string) (Protocol, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	p, ok := registry[name]
+	return p, ok
+}
+
+// Names returns every currently-registered protocol name. Callers that need
+// a stable order should sort the result themselves.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	return names
+}
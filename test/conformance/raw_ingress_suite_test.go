@@ -0,0 +1,81 @@
+//go:build conformance
+
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conformance runs the upstream Gateway API conformance suite against
+// the HTTPRoute/GRPCRoute objects generated by the raw-deployment ingress
+// reconciler, so changes to route generation can be checked against the spec
+// independently of KServe's own ginkgo controller tests.
+//
+// It is gated behind the "conformance" build tag and a live cluster
+// (KUBECONFIG) because it exercises a real Gateway API implementation; it does
+// not run as part of `go test ./...`.
+package conformance
+
+import (
+	"flag"
+	"testing"
+
+	"sigs.k8s.io/gateway-api/conformance"
+	confsuite "sigs.k8s.io/gateway-api/conformance/utils/suite"
+)
+
+var (
+	gatewayClassName = flag.String("gateway-class", "kserve-ingress-gateway", "GatewayClass to run conformance tests against")
+	showDebug        = flag.Bool("debug", false, "print debug logs")
+)
+
+// supportedFeatures lists the Gateway API features the raw-deployment ingress
+// reconciler is expected to support: HTTPRoute, plus the optional extensions
+// this reconciler also generates (BackendTLSPolicy, GRPCRoute).
+var supportedFeatures = []string{
+	"HTTPRoute",
+	"HTTPRouteBackendRequestHeaderModification",
+	"GRPCRoute",
+	"BackendTLSPolicy",
+}
+
+// TestGatewayAPIConformance runs the subset of the upstream Gateway API
+// conformance suite relevant to raw-deployment InferenceService ingress.
+func TestGatewayAPIConformance(t *testing.T) {
+	if testing.Short() {
+		t.Skip("conformance suite requires a live cluster; skipping in -short mode")
+	}
+
+	opts := confsuite.ConformanceOptions{
+		GatewayClassName:     *gatewayClassName,
+		Debug:                *showDebug,
+		CleanupBaseResources: true,
+		SupportedFeatures:    featureSet(supportedFeatures),
+		SkipTests:            skippedConformanceTests,
+	}
+
+	cSuite, err := confsuite.NewConformanceTestSuite(opts)
+	if err != nil {
+		t.Fatalf("failed to create conformance test suite: %v", err)
+	}
+	cSuite.Setup(t)
+	cSuite.Run(t, conformance.HTTPConformanceTests)
+}
+
+func featureSet(names []string) map[string]bool {
+	out := make(map[string]bool, len(names))
+	for _, n := range names {
+		out[n] = true
+	}
+	return out
+}